@@ -1,6 +1,10 @@
 package com.ronilsonalves.invoiceservice.config;
 
+import org.springframework.amqp.core.Binding;
+import org.springframework.amqp.core.BindingBuilder;
 import org.springframework.amqp.core.Queue;
+import org.springframework.amqp.core.QueueBuilder;
+import org.springframework.amqp.core.TopicExchange;
 import org.springframework.beans.factory.annotation.Value;
 import org.springframework.context.annotation.Bean;
 import org.springframework.context.annotation.Configuration;
@@ -10,8 +14,26 @@ public class RabbitMQSenderConfig {
     @Value("${queue.appointment-service.name}")
     private String appointmentServiceQueue;
 
+    @Value("${queue.appointment-service.exchange:scheduling.events}")
+    private String schedulingExchange;
+
+    @Value("${queue.appointment-service.dead-letter-exchange:scheduling.dlx}")
+    private String deadLetterExchange;
+
     @Bean
     public Queue appointmentQueue() {
-        return new Queue(this.appointmentServiceQueue,false);
+        return QueueBuilder.durable(this.appointmentServiceQueue)
+                .deadLetterExchange(this.deadLetterExchange)
+                .build();
+    }
+
+    @Bean
+    public TopicExchange schedulingExchange() {
+        return new TopicExchange(this.schedulingExchange, true, false);
+    }
+
+    @Bean
+    public Binding appointmentEventsBinding() {
+        return BindingBuilder.bind(appointmentQueue()).to(schedulingExchange()).with("appointment.#");
     }
 }
@@ -0,0 +1,10 @@
+package com.ronilsonalves.invoiceservice.data.dto;
+
+public record AppointmentEvent(
+        String eventId,
+        String eventType,
+        String occurredAt,
+        Integer schemaVersion,
+        Appointment payload
+) {
+}
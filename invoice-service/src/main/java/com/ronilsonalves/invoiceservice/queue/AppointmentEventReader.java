@@ -0,0 +1,68 @@
+package com.ronilsonalves.invoiceservice.queue;
+
+import com.fasterxml.jackson.databind.JsonNode;
+import com.fasterxml.jackson.databind.ObjectMapper;
+import com.ronilsonalves.invoiceservice.config.CustomLocalDateTimeObjectMapper;
+import com.ronilsonalves.invoiceservice.data.dto.Appointment;
+import com.ronilsonalves.invoiceservice.data.dto.AppointmentEvent;
+import org.springframework.amqp.AmqpRejectAndDontRequeueException;
+import org.springframework.amqp.core.Message;
+import org.springframework.amqp.core.MessageProperties;
+import org.springframework.stereotype.Component;
+
+import java.io.IOException;
+
+/**
+ * Reads the appointment events in any of the formats the scheduling-service publishes (RABBIT_MQ_MESSAGE_FORMAT):
+ * the event envelope, CloudEvents structured mode and CloudEvents binary mode, told apart by the content type and
+ * the cloudEvents: headers.
+ */
+@Component
+public class AppointmentEventReader {
+    private static final String CLOUD_EVENTS_CONTENT_TYPE = "application/cloudevents+json";
+    private static final String CLOUD_EVENTS_HEADER_PREFIX = "cloudEvents:";
+
+    private final ObjectMapper objectMapper =
+            new CustomLocalDateTimeObjectMapper(new ObjectMapper()).getCustomLocalDateTimeObjectMapper();
+
+    public AppointmentEvent read(Message message) {
+        AppointmentEvent event = decode(message);
+        if (event.eventId() == null || event.eventType() == null) {
+            throw new AmqpRejectAndDontRequeueException("the event id and type are required");
+        }
+        return event;
+    }
+
+    private AppointmentEvent decode(Message message) {
+        MessageProperties properties = message.getMessageProperties();
+        try {
+            if (CLOUD_EVENTS_CONTENT_TYPE.equals(properties.getContentType())) {
+                JsonNode cloudEvent = objectMapper.readTree(message.getBody());
+                return new AppointmentEvent(
+                        cloudEvent.path("id").asText(null),
+                        cloudEvent.path("type").asText(null),
+                        cloudEvent.path("time").asText(null),
+                        cloudEvent.path("schemaversion").asInt(1),
+                        objectMapper.treeToValue(cloudEvent.path("data"), Appointment.class));
+            }
+            if (properties.getHeader(CLOUD_EVENTS_HEADER_PREFIX + "specversion") != null) {
+                Object schemaVersion = properties.getHeader(CLOUD_EVENTS_HEADER_PREFIX + "schemaversion");
+                return new AppointmentEvent(
+                        header(properties, "id"),
+                        header(properties, "type"),
+                        header(properties, "time"),
+                        schemaVersion instanceof Number number ? number.intValue() : 1,
+                        objectMapper.readValue(message.getBody(), Appointment.class));
+            }
+            return objectMapper.readValue(message.getBody(), AppointmentEvent.class);
+        } catch (IOException e) {
+            // the message is parked at the dead letter exchange, it would fail again if requeued
+            throw new AmqpRejectAndDontRequeueException("invalid appointment event", e);
+        }
+    }
+
+    private String header(MessageProperties properties, String name) {
+        Object value = properties.getHeader(CLOUD_EVENTS_HEADER_PREFIX + name);
+        return value == null ? null : value.toString();
+    }
+}
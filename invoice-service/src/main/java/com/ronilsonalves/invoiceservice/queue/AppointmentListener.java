@@ -2,8 +2,10 @@ package com.ronilsonalves.invoiceservice.queue;
 
 import com.ronilsonalves.invoiceservice.api.service.impl.InvoiceServiceImpl;
 import com.ronilsonalves.invoiceservice.data.dto.Appointment;
+import com.ronilsonalves.invoiceservice.data.dto.AppointmentEvent;
 import com.ronilsonalves.invoiceservice.data.model.Invoice;
 import lombok.RequiredArgsConstructor;
+import org.springframework.amqp.core.Message;
 import org.springframework.amqp.rabbit.annotation.RabbitListener;
 import org.springframework.stereotype.Component;
 
@@ -17,10 +19,16 @@ import java.util.logging.Logger;
 public class AppointmentListener {
     private final Logger logger = Logger.getLogger(AppointmentListener.class.getName());
     private final InvoiceServiceImpl invoiceService;
+    private final AppointmentEventReader eventReader;
 
     @RabbitListener(queues = {"${queue.appointment-service.name}"})
-    public void receiveMessage(Appointment appointment) {
-        logger.log(Level.INFO,"Message received from RabbitMQ: "+appointment);
+    public void receiveMessage(Message message) {
+        AppointmentEvent event = eventReader.read(message);
+        logger.log(Level.INFO,"Message received from RabbitMQ: "+event);
+        if (!event.eventType().startsWith("appointment.") || event.eventType().equals("appointment.deleted")) {
+            return;
+        }
+        Appointment appointment = event.payload();
         List<Invoice> invoices =
                 invoiceService.listAll().stream().filter(invoice -> Objects.equals(invoice.getAppointmentId(), appointment.id())).toList();
         if (invoices.isEmpty()) {
@@ -6,7 +6,6 @@ import (
 	"github.com/ronilsonalves/GoLang-in-a-spring-cloud-architecture/scheduling-service/internal/appointment"
 	"github.com/ronilsonalves/GoLang-in-a-spring-cloud-architecture/scheduling-service/internal/domain"
 	"github.com/ronilsonalves/GoLang-in-a-spring-cloud-architecture/scheduling-service/pkg/web"
-	"log"
 	"net/http"
 	"strconv"
 	"strings"
@@ -23,33 +22,36 @@ func NewAppointmentHandler(s appointment.Service) *appointmentHandler {
 	}
 }
 
-// GetAll - get all appointments from db.
+// GetAll - get a page of the appointments from db.
 // @BasePath /api/v1
 // GetAllAppointments godoc
-// @Summary List all appointments
+// @Summary List appointments
 // @Schemes
-// @Description get all appointments from db.
+// @Description get a page of the appointments from db matching the filters, sorted by date and time unless requested otherwise. Patients and dentists only get their own appointments.
 // @Tags Appointments
 // @Accept json
 // @Produce json
-// @Success 200 {object} []domain.AppointmentDTO
+// @Param page query int false "Page, starting at 1"
+// @Param limit query int false "Items per page, 20 by default and up to 100"
+// @Param sort query string false "Sort field, prefixed with - for descending order" Enums(id, -id, dateAndTime, -dateAndTime, dentistCRO, -dentistCRO, patientRG, -patientRG, status, -status)
+// @Param from query string false "Appointments starting from, in format 30/01/2023 23:59"
+// @Param to query string false "Appointments starting before, in format 30/01/2023 23:59"
+// @Param dentistCRO query string false "Dentist license number"
+// @Param patientRG query string false "Patient identity number"
+// @Param status query string false "Appointment status" Enums(scheduled, confirmed, checked-in, completed, cancelled, no-show)
+// @Success 200 {object} domain.AppointmentPage
 // @Failure 400 {object} web.errorResponse
 // @Failure 401 {object} web.errorResponse
-// @Failure 404 {object} web.errorResponse
+// @Failure 403 {object} web.errorResponse
 // @Router /appointments [get]
 // @Security OAuth2Application
 func (h *appointmentHandler) GetAll() gin.HandlerFunc {
 	return func(ctx *gin.Context) {
-		response, err := h.s.GetAll()
-		if err != nil {
-			web.BadResponse(ctx, http.StatusBadRequest, "error", err.Error())
-			return
+		filter := domain.AppointmentFilter{
+			DentistCRO: ctx.Query("dentistCRO"),
+			PatientRG:  ctx.Query("patientRG"),
 		}
-		if response == nil {
-			web.BadResponse(ctx, http.StatusNotFound, "error", "was not found appointments registered")
-			return
-		}
-		web.ResponseOK(ctx, http.StatusOK, response)
+		h.list(ctx, filter)
 	}
 }
 
@@ -66,6 +68,7 @@ func (h *appointmentHandler) GetAll() gin.HandlerFunc {
 // @Success 200 {object} domain.AppointmentDTO
 // @Failure 400 {object} web.errorResponse
 // @Failure 401 {object} web.errorResponse
+// @Failure 403 {object} web.errorResponse
 // @Failure 404 {object} web.errorResponse
 // @Router /appointments/{id} [get]
 // @Security OAuth2Application
@@ -77,68 +80,68 @@ func (h *appointmentHandler) GetByID() gin.HandlerFunc {
 			web.BadResponse(ctx, http.StatusBadRequest, "error", "invalid id provided")
 			return
 		}
-		response, err := h.s.GetByID(id)
+		response, err := h.s.GetByID(ctx.Request.Context(), id)
 		if err != nil {
-			web.BadResponse(ctx, http.StatusNotFound, "error", err.Error())
+			web.BadResponse(ctx, errorStatus(err, http.StatusNotFound), "error", err.Error())
 			return
 		}
 		web.ResponseOK(ctx, http.StatusOK, response)
 	}
 }
 
-// GetAllByIdentityNumber - get all appointments by patient identity doc
+// GetAllByIdentityNumber - get a page of the appointments by patient identity doc
 // @BasePath /api/v1
 // GetAllByIdentityNumber godoc
-// @Summary Get all appointments by patient identity doc
+// @Summary List appointments by patient identity doc
 // @Schemes
-// @Description get all appointments by patient identity doc
+// @Description get a page of the appointments by patient identity doc, accepts the same page, sort and filters of the appointments list.
 // @Tags Appointments
 // @Accept json
 // @Produce json
 // @Param identity_number path int true "Patient Doc Number"
-// @Success 200 {object} []domain.AppointmentDTO
+// @Param page query int false "Page, starting at 1"
+// @Param limit query int false "Items per page, 20 by default and up to 100"
+// @Param sort query string false "Sort field, prefixed with - for descending order"
+// @Param from query string false "Appointments starting from, in format 30/01/2023 23:59"
+// @Param to query string false "Appointments starting before, in format 30/01/2023 23:59"
+// @Param status query string false "Appointment status"
+// @Success 200 {object} domain.AppointmentPage
 // @Failure 400 {object} web.errorResponse
 // @Failure 401 {object} web.errorResponse
-// @Failure 404 {object} web.errorResponse
+// @Failure 403 {object} web.errorResponse
 // @Router /appointments/patient/{identity_number} [get]
 // @Security OAuth2Application
 func (h *appointmentHandler) GetAllByIdentityNumber() gin.HandlerFunc {
 	return func(ctx *gin.Context) {
-		idParam := ctx.Param("identity_number")
-		response, err := h.s.GetAllByIdentityNumber(idParam)
-		if err != nil {
-			web.BadResponse(ctx, http.StatusNotFound, "error", err.Error())
-			return
-		}
-		web.ResponseOK(ctx, http.StatusOK, response)
+		h.list(ctx, domain.AppointmentFilter{PatientRG: ctx.Param("identity_number")})
 	}
 }
 
-// GetAllByLicenseNumber - get all appointments by dentist license doc
+// GetAllByLicenseNumber - get a page of the appointments by dentist license doc
 // @BasePath /api/v1
 // GetAllByLicenseNumber godoc
-// @Summary Get all appointments by dentist license doc
+// @Summary List appointments by dentist license doc
 // @Schemes
-// @Description Get all appointments by dentist license doc
+// @Description get a page of the appointments by dentist license doc, accepts the same page, sort and filters of the appointments list.
 // @Tags Appointments
 // @Accept json
 // @Produce json
 // @Param license_number path int true "Dentist License Number"
-// @Success 200 {object} []domain.AppointmentDTO
+// @Param page query int false "Page, starting at 1"
+// @Param limit query int false "Items per page, 20 by default and up to 100"
+// @Param sort query string false "Sort field, prefixed with - for descending order"
+// @Param from query string false "Appointments starting from, in format 30/01/2023 23:59"
+// @Param to query string false "Appointments starting before, in format 30/01/2023 23:59"
+// @Param status query string false "Appointment status"
+// @Success 200 {object} domain.AppointmentPage
 // @Failure 400 {object} web.errorResponse
 // @Failure 401 {object} web.errorResponse
-// @Failure 404 {object} web.errorResponse
+// @Failure 403 {object} web.errorResponse
 // @Router /appointments/dentist/{license_number} [get]
 // @Security OAuth2Application
 func (h *appointmentHandler) GetAllByLicenseNumber() gin.HandlerFunc {
 	return func(ctx *gin.Context) {
-		idParam := ctx.Param("license_number")
-		response, err := h.s.GetAllByLicenseNumber(idParam)
-		if err != nil {
-			web.BadResponse(ctx, http.StatusNotFound, "error", err.Error())
-			return
-		}
-		web.ResponseOK(ctx, http.StatusOK, response)
+		h.list(ctx, domain.AppointmentFilter{DentistCRO: ctx.Param("license_number")})
 	}
 }
 
@@ -155,6 +158,8 @@ func (h *appointmentHandler) GetAllByLicenseNumber() gin.HandlerFunc {
 // @Success 201 {object} domain.AppointmentDTO
 // @Failure 400 {object} web.errorResponse
 // @Failure 401 {object} web.errorResponse
+// @Failure 403 {object} web.errorResponse
+// @Failure 409 {object} web.errorResponse
 // @Router /appointments [post]
 // @Security OAuth2Application
 func (h *appointmentHandler) Post() gin.HandlerFunc {
@@ -171,9 +176,9 @@ func (h *appointmentHandler) Post() gin.HandlerFunc {
 			web.BadResponse(ctx, http.StatusBadRequest, "error", err.Error())
 			return
 		}
-		response, err := h.s.Create(appointment)
+		response, err := h.s.Create(ctx.Request.Context(), appointment)
 		if err != nil {
-			web.BadResponse(ctx, http.StatusBadRequest, "error", err.Error())
+			web.BadResponse(ctx, errorStatus(err, http.StatusBadRequest), "error", err.Error())
 			return
 		}
 		web.ResponseOK(ctx, http.StatusCreated, response)
@@ -194,6 +199,7 @@ func (h *appointmentHandler) Post() gin.HandlerFunc {
 // @Success 200 {object} domain.AppointmentDTO
 // @Failure 400 {object} web.errorResponse
 // @Failure 401 {object} web.errorResponse
+// @Failure 403 {object} web.errorResponse
 // @Failure 404 {object} web.errorResponse
 // @Failure 409 {object} web.errorResponse
 // @Router /appointments/{id} [put]
@@ -219,9 +225,9 @@ func (h *appointmentHandler) Put() gin.HandlerFunc {
 			web.BadResponse(ctx, http.StatusBadRequest, "error", err.Error())
 			return
 		}
-		response, err := h.s.Update(id, appointment)
+		response, err := h.s.Update(ctx.Request.Context(), id, appointment)
 		if err != nil {
-			web.BadResponse(ctx, http.StatusNotFound, "error", err.Error())
+			web.BadResponse(ctx, errorStatus(err, http.StatusBadRequest), "error", err.Error())
 			return
 		}
 		web.ResponseOK(ctx, http.StatusOK, response)
@@ -242,7 +248,9 @@ func (h *appointmentHandler) Put() gin.HandlerFunc {
 // @Success 200 {object} domain.AppointmentDTO
 // @Failure 400 {object} web.errorResponse
 // @Failure 401 {object} web.errorResponse
+// @Failure 403 {object} web.errorResponse
 // @Failure 404 {object} web.errorResponse
+// @Failure 409 {object} web.errorResponse
 // @Router /appointments/{id} [patch]
 // @Security OAuth2Application
 func (h *appointmentHandler) Patch() gin.HandlerFunc {
@@ -251,6 +259,8 @@ func (h *appointmentHandler) Patch() gin.HandlerFunc {
 		DateAndTime string `json:"dateAndTime,omitempty"`
 		DentistCRO  string `json:"dentistCRO,omitempty"`
 		PatientRG   string `json:"patientRG,omitempty"`
+		Procedure   string `json:"procedure,omitempty"`
+		Duration    int    `json:"duration,omitempty"`
 	}
 
 	return func(ctx *gin.Context) {
@@ -270,6 +280,12 @@ func (h *appointmentHandler) Patch() gin.HandlerFunc {
 			DateAndTime: r.DateAndTime,
 			DentistCRO:  r.DentistCRO,
 			PatientRG:   r.PatientRG,
+			Procedure:   r.Procedure,
+			Duration:    r.Duration,
+		}
+		if !isValidDuration(update.Duration) {
+			web.BadResponse(ctx, http.StatusBadRequest, "error", "the appointment duration must be up to 480 minutes")
+			return
 		}
 		if update.DateAndTime != "" {
 			if !validateDateTime(update.DateAndTime) {
@@ -277,9 +293,9 @@ func (h *appointmentHandler) Patch() gin.HandlerFunc {
 				return
 			}
 		}
-		response, err := h.s.Update(id, update)
+		response, err := h.s.Update(ctx.Request.Context(), id, update)
 		if err != nil {
-			web.BadResponse(ctx, http.StatusNotFound, "error", err.Error())
+			web.BadResponse(ctx, errorStatus(err, http.StatusBadRequest), "error", err.Error())
 			return
 		}
 		web.ResponseOK(ctx, http.StatusOK, response)
@@ -299,6 +315,7 @@ func (h *appointmentHandler) Patch() gin.HandlerFunc {
 // @Success 200 {object} web.errorResponse
 // @Failure 400 {object} web.errorResponse
 // @Failure 401 {object} web.errorResponse
+// @Failure 403 {object} web.errorResponse
 // @Failure 404 {object} web.errorResponse
 // @Router /appointments/{id} [delete]
 // @Security OAuth2Application
@@ -310,19 +327,220 @@ func (h *appointmentHandler) Delete() gin.HandlerFunc {
 			web.BadResponse(ctx, http.StatusBadRequest, "error", "invalid id provided")
 			return
 		}
-		err = h.s.Delete(id)
+		err = h.s.Delete(ctx.Request.Context(), id)
 		if err != nil {
-			web.BadResponse(ctx, http.StatusNotFound, "error", err.Error())
+			web.BadResponse(ctx, errorStatus(err, http.StatusNotFound), "error", err.Error())
 			return
 		}
 		web.DeleteResponse(ctx, http.StatusOK, "appointment removed")
 	}
 }
 
+// Confirm - confirm an appointment
+// @BasePath /api/v1
+// ConfirmAppointment godoc
+// @Summary Confirm an appointment by ID
+// @Schemes
+// @Description Confirm a scheduled appointment by ID
+// @Tags Appointments
+// @Accept json
+// @Produce json
+// @Param id path int true "Appointment ID"
+// @Success 200 {object} domain.AppointmentDTO
+// @Failure 400 {object} web.errorResponse
+// @Failure 401 {object} web.errorResponse
+// @Failure 403 {object} web.errorResponse
+// @Failure 404 {object} web.errorResponse
+// @Failure 409 {object} web.errorResponse
+// @Router /appointments/{id}/confirm [post]
+// @Security OAuth2Application
+func (h *appointmentHandler) Confirm() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		h.changeStatus(ctx, domain.StatusConfirmed, "")
+	}
+}
+
+// CheckIn - check the patient in for an appointment
+// @BasePath /api/v1
+// CheckInAppointment godoc
+// @Summary Check the patient in for an appointment by ID
+// @Schemes
+// @Description Check the patient in for a scheduled or confirmed appointment by ID
+// @Tags Appointments
+// @Accept json
+// @Produce json
+// @Param id path int true "Appointment ID"
+// @Success 200 {object} domain.AppointmentDTO
+// @Failure 400 {object} web.errorResponse
+// @Failure 401 {object} web.errorResponse
+// @Failure 403 {object} web.errorResponse
+// @Failure 404 {object} web.errorResponse
+// @Failure 409 {object} web.errorResponse
+// @Router /appointments/{id}/check-in [post]
+// @Security OAuth2Application
+func (h *appointmentHandler) CheckIn() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		h.changeStatus(ctx, domain.StatusCheckedIn, "")
+	}
+}
+
+// Complete - complete an appointment
+// @BasePath /api/v1
+// CompleteAppointment godoc
+// @Summary Complete an appointment by ID
+// @Schemes
+// @Description Complete an appointment whose patient is checked in by ID
+// @Tags Appointments
+// @Accept json
+// @Produce json
+// @Param id path int true "Appointment ID"
+// @Success 200 {object} domain.AppointmentDTO
+// @Failure 400 {object} web.errorResponse
+// @Failure 401 {object} web.errorResponse
+// @Failure 403 {object} web.errorResponse
+// @Failure 404 {object} web.errorResponse
+// @Failure 409 {object} web.errorResponse
+// @Router /appointments/{id}/complete [post]
+// @Security OAuth2Application
+func (h *appointmentHandler) Complete() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		h.changeStatus(ctx, domain.StatusCompleted, "")
+	}
+}
+
+// Cancel - cancel an appointment
+// @BasePath /api/v1
+// CancelAppointment godoc
+// @Summary Cancel an appointment by ID
+// @Schemes
+// @Description Cancel a scheduled or confirmed appointment by ID, it's kept for reporting and its interval becomes available again.
+// @Tags Appointments
+// @Accept json
+// @Produce json
+// @Param id path int true "Appointment ID"
+// @Param body body cancelRequest true "Body"
+// @Success 200 {object} domain.AppointmentDTO
+// @Failure 400 {object} web.errorResponse
+// @Failure 401 {object} web.errorResponse
+// @Failure 403 {object} web.errorResponse
+// @Failure 404 {object} web.errorResponse
+// @Failure 409 {object} web.errorResponse
+// @Router /appointments/{id}/cancel [post]
+// @Security OAuth2Application
+func (h *appointmentHandler) Cancel() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		var r cancelRequest
+		if err := ctx.ShouldBindJSON(&r); err != nil || strings.TrimSpace(r.Reason) == "" {
+			web.BadResponse(ctx, http.StatusBadRequest, "error", "the cancellation reason is required")
+			return
+		}
+		h.changeStatus(ctx, domain.StatusCancelled, strings.TrimSpace(r.Reason))
+	}
+}
+
+// NoShow - mark the patient didn't attend an appointment
+// @BasePath /api/v1
+// NoShowAppointment godoc
+// @Summary Mark an appointment as no-show by ID
+// @Schemes
+// @Description Mark the patient didn't attend a scheduled or confirmed appointment by ID, only after it starts. Its interval becomes available again.
+// @Tags Appointments
+// @Accept json
+// @Produce json
+// @Param id path int true "Appointment ID"
+// @Success 200 {object} domain.AppointmentDTO
+// @Failure 400 {object} web.errorResponse
+// @Failure 401 {object} web.errorResponse
+// @Failure 403 {object} web.errorResponse
+// @Failure 404 {object} web.errorResponse
+// @Failure 409 {object} web.errorResponse
+// @Router /appointments/{id}/no-show [post]
+// @Security OAuth2Application
+func (h *appointmentHandler) NoShow() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		h.changeStatus(ctx, domain.StatusNoShow, "")
+	}
+}
+
 // Aux functions bellow->
 
+// list - complete the filter provided with the date range and status query params and write the page requested.
+func (h *appointmentHandler) list(ctx *gin.Context, filter domain.AppointmentFilter) {
+	if param := ctx.Query("from"); param != "" {
+		from, err := domain.ParseDateTime(param)
+		if err != nil {
+			web.BadResponse(ctx, http.StatusBadRequest, "error", "invalid from provided, it must be in format: 30/01/2023 23:59")
+			return
+		}
+		filter.From = from
+	}
+	if param := ctx.Query("to"); param != "" {
+		to, err := domain.ParseDateTime(param)
+		if err != nil {
+			web.BadResponse(ctx, http.StatusBadRequest, "error", "invalid to provided, it must be in format: 30/01/2023 23:59")
+			return
+		}
+		filter.To = to
+	}
+	if param := ctx.Query("status"); param != "" {
+		filter.Status = domain.AppointmentStatus(param)
+		if !filter.Status.IsValid() {
+			web.BadResponse(ctx, http.StatusBadRequest, "error", "invalid status provided")
+			return
+		}
+	}
+	q, err := parseListQuery(ctx, filter)
+	if err != nil {
+		web.BadResponse(ctx, http.StatusBadRequest, "error", err.Error())
+		return
+	}
+	response, err := h.s.List(ctx.Request.Context(), q)
+	if err != nil {
+		web.BadResponse(ctx, errorStatus(err, http.StatusBadRequest), "error", err.Error())
+		return
+	}
+	respondPage(ctx, response)
+}
+
+type cancelRequest struct {
+	Reason string `json:"reason" binding:"required"`
+}
+
+// changeStatus - move the appointment of the id path param to the status provided and write the response.
+func (h *appointmentHandler) changeStatus(ctx *gin.Context, status domain.AppointmentStatus, reason string) {
+	id, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		web.BadResponse(ctx, http.StatusBadRequest, "error", "invalid id provided")
+		return
+	}
+	response, err := h.s.ChangeStatus(ctx.Request.Context(), id, status, reason)
+	if err != nil {
+		web.BadResponse(ctx, errorStatus(err, http.StatusBadRequest), "error", err.Error())
+		return
+	}
+	web.ResponseOK(ctx, http.StatusOK, response)
+}
+
+// errorStatus - the status code of an error from the service: access to someone else's appointments is 403, a missing
+// appointment is 404, an invalid date and time or duration is 400 and conflicts with its status, with other
+// appointments, with the dentist working hours or with the clinic closures are 409.
+func errorStatus(err error, fallback int) int {
+	switch {
+	case errors.Is(err, appointment.ErrInvalidAppointment):
+		return http.StatusBadRequest
+	case errors.Is(err, domain.ErrForbidden):
+		return http.StatusForbidden
+	case errors.Is(err, appointment.ErrNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, domain.ErrInvalidStatusTransition), errors.Is(err, appointment.ErrScheduleConflict),
+		errors.Is(err, appointment.ErrOutsideWorkingHours), errors.Is(err, appointment.ErrClinicClosed):
+		return http.StatusConflict
+	}
+	return fallback
+}
+
 func isEmptyAppointment(appointment *domain.Appointment) (bool, error) {
-	dateTimeParsed, err := time.Parse("02/01/2006 15:04", appointment.DateAndTime)
+	dateTimeParsed, err := domain.ParseDateTime(appointment.DateAndTime)
 	if err != nil {
 		return false, err
 	}
@@ -331,21 +549,29 @@ func isEmptyAppointment(appointment *domain.Appointment) (bool, error) {
 		return false, errors.New("fields can't be empty")
 	case !validateDateTime(appointment.DateAndTime):
 		return false, errors.New("please the appointment must be in format: 30/01/2023 23:59")
-	case dateTimeParsed.Local().Add(time.Hour * 3).Before(time.Now().Add(time.Hour)):
+	case dateTimeParsed.Before(time.Now().Add(time.Hour)):
 		return false, errors.New("the appointment must be in +1 hour from now")
+	case !isValidDuration(appointment.Duration):
+		return false, errors.New("the appointment duration must be up to 480 minutes")
 	}
+	// the end is always calculated from the start and the duration
+	appointment.EndDateAndTime = ""
 	return true, nil
 }
 
+// isValidDuration - a zero duration means the default one for the procedure
+func isValidDuration(duration int) bool {
+	return duration >= 0 && duration <= 480
+}
+
+// validateDateTime - whether the date and time provided is a valid 30/01/2023 23:59 one, the seconds are optional.
 func validateDateTime(dateTime string) bool {
 	datesInit := strings.Split(dateTime, " ")
 	if len(datesInit) != 2 {
-		log.Printf("invalid time, must be in format: 30/01/2023 23:59")
 		return false
 	}
 	breakDate := strings.Split(datesInit[0], "/")
 	if len(breakDate) != 3 {
-		log.Println("invalid time, must be in format: 30/01/2023 23:59 or 30/01/2023 23:59:59")
 		return false
 	}
 	breakTime := strings.Split(datesInit[1], ":")
@@ -361,14 +587,12 @@ func validateDateTime(dateTime string) bool {
 	}
 	condition := (listDate[0] < 1 || listDate[0] > 31) && (listDate[1] < 1 || listDate[1] > 12) && (listDate[2] < 1 || listDate[2] > 9999)
 	if condition {
-		log.Println("invalid time, must be between: 1 and 31/12/2023 23:59")
 		return false
 	}
 
 	for _, t := range breakTime {
 		clock, err := strconv.Atoi(t)
 		if err != nil {
-			log.Println("invalid time, must be in format 23:59 (hours and minutes)")
 			return false
 		}
 		listTime = append(listTime, clock)
@@ -377,7 +601,6 @@ func validateDateTime(dateTime string) bool {
 	if len(listTime) == 2 {
 		condition = (listTime[0] < 0 || listTime[0] > 23) && (listTime[1] < 0 || listTime[1] > 59)
 		if condition {
-			log.Println("invalid time, must be between: 00:00 and 23:59")
 			return false
 		}
 	}
@@ -385,7 +608,6 @@ func validateDateTime(dateTime string) bool {
 	if len(listTime) == 3 {
 		condition = (listTime[0] < 0 || listTime[0] > 23) && (listTime[1] < 0 || listTime[1] > 59)
 		if condition {
-			log.Println("invalid time, must be between: 00:00 and 23:59")
 			return false
 		}
 	}
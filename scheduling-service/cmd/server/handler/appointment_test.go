@@ -0,0 +1,208 @@
+package handler
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"github.com/ronilsonalves/GoLang-in-a-spring-cloud-architecture/scheduling-service/internal/appointment"
+	"github.com/ronilsonalves/GoLang-in-a-spring-cloud-architecture/scheduling-service/internal/domain"
+	"github.com/ronilsonalves/GoLang-in-a-spring-cloud-architecture/scheduling-service/pkg/store"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+// newTestRouter - the appointment routes on the memory stores, with the dentist CRO-1 and the patients RG-1 and RG-2,
+// along with their service. The requests run as the principal provided, or as the service itself when it's nil.
+func newTestRouter(t *testing.T, principal *domain.Principal) (*gin.Engine, appointment.Service) {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+	ctx := context.Background()
+	messages := store.NewMemoryOutbox()
+	dentists := store.NewMemoryDentist(messages)
+	patients := store.NewMemoryPatient(messages)
+	if _, err := dentists.Save(ctx, domain.Dentist{Name: "Ana", LastName: "Souza", CRO: "CRO-1"}); err != nil {
+		t.Fatalf("dentists.Save() error = %v", err)
+	}
+	for _, rg := range []string{"RG-1", "RG-2"} {
+		if _, err := patients.Save(ctx, domain.Patient{Name: "João", LastName: "Silva", RG: rg, CreatedAt: "30/01/2023 10:00:00"}); err != nil {
+			t.Fatalf("patients.Save() error = %v", err)
+		}
+	}
+	appointments := store.NewMemoryAp(dentists, patients, store.NewMemorySchedule(dentists), store.NewMemoryClosure(), messages)
+	s := appointment.NewService(appointment.NewRepository(appointments))
+	h := NewAppointmentHandler(s)
+
+	router := gin.New()
+	if principal != nil {
+		router.Use(func(c *gin.Context) {
+			c.Request = c.Request.WithContext(domain.ContextWithPrincipal(c.Request.Context(), *principal))
+		})
+	}
+	router.GET("/appointments/:id", h.GetByID())
+	router.POST("/appointments", h.Post())
+	router.POST("/appointments/:id/confirm", h.Confirm())
+	router.DELETE("/appointments/:id", h.Delete())
+	return router, s
+}
+
+func serve(router *gin.Engine, method, target string, body interface{}) *httptest.ResponseRecorder {
+	var content []byte
+	if body != nil {
+		content, _ = json.Marshal(body)
+	}
+	req := httptest.NewRequest(method, target, bytes.NewReader(content))
+	req.Header.Set("Content-Type", "application/json")
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+	return rec
+}
+
+// book - book the appointment of RG-1 with CRO-1 at the date and time provided, as the service itself.
+func book(t *testing.T, s appointment.Service, dateAndTime time.Time) {
+	t.Helper()
+	a := domain.Appointment{Description: "Check-up", DateAndTime: dateAndTime.Format(domain.DateTimeLayout), DentistCRO: "CRO-1", PatientRG: "RG-1", Duration: 60}
+	if _, err := s.Create(context.Background(), a); err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+}
+
+// appointmentAt - the request body of an appointment of RG-1 with CRO-1 at the date and time provided.
+func appointmentAt(dateAndTime time.Time) map[string]interface{} {
+	return map[string]interface{}{
+		"description": "Check-up",
+		"dateAndTime": dateAndTime.Format(domain.DateTimeLayout),
+		"dentistCRO":  "CRO-1",
+		"patientRG":   "RG-1",
+		"duration":    60,
+	}
+}
+
+func TestAppointmentHandlerPost(t *testing.T) {
+	year, month, day := time.Now().AddDate(0, 0, 7).Date()
+	nextWeek := time.Date(year, month, day, 10, 0, 0, 0, time.Local)
+	missingField := appointmentAt(nextWeek)
+	delete(missingField, "description")
+	otherPatient := appointmentAt(nextWeek.Add(2 * time.Hour))
+	otherPatient["patientRG"] = "RG-2"
+
+	tests := []struct {
+		name       string
+		principal  *domain.Principal
+		body       interface{}
+		wantStatus int
+	}{
+		{"booked", nil, appointmentAt(nextWeek.Add(2 * time.Hour)), http.StatusCreated},
+		{"overlapping the booked one", nil, appointmentAt(nextWeek.Add(30 * time.Minute)), http.StatusConflict},
+		{"within the next hour", nil, appointmentAt(time.Now().Add(30 * time.Minute)), http.StatusBadRequest},
+		{"missing field", nil, missingField, http.StatusBadRequest},
+		{"not JSON", nil, "not json", http.StatusBadRequest},
+		{"patient booking for another patient", &domain.Principal{Roles: []string{domain.RolePatient}, RG: "RG-1"}, otherPatient, http.StatusForbidden},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			router, s := newTestRouter(t, tt.principal)
+			book(t, s, nextWeek)
+			rec := serve(router, http.MethodPost, "/appointments", tt.body)
+			if rec.Code != tt.wantStatus {
+				t.Fatalf("status = %d, want %d: %s", rec.Code, tt.wantStatus, rec.Body.String())
+			}
+			if tt.wantStatus != http.StatusCreated {
+				return
+			}
+			var created domain.AppointmentDTO
+			if err := json.Unmarshal(rec.Body.Bytes(), &created); err != nil {
+				t.Fatalf("json.Unmarshal() error = %v", err)
+			}
+			if created.Id == 0 || created.Status != domain.StatusScheduled || created.Dentist.CRO != "CRO-1" {
+				t.Errorf("created = %+v, want a scheduled appointment with its dentist", created)
+			}
+		})
+	}
+}
+
+func TestAppointmentHandlerLifecycle(t *testing.T) {
+	year, month, day := time.Now().AddDate(0, 0, 7).Date()
+	router, _ := newTestRouter(t, nil)
+	rec := serve(router, http.MethodPost, "/appointments", appointmentAt(time.Date(year, month, day, 10, 0, 0, 0, time.Local)))
+	if rec.Code != http.StatusCreated {
+		t.Fatalf("booking status = %d: %s", rec.Code, rec.Body.String())
+	}
+	var created domain.AppointmentDTO
+	if err := json.Unmarshal(rec.Body.Bytes(), &created); err != nil {
+		t.Fatalf("json.Unmarshal() error = %v", err)
+	}
+	path := fmt.Sprintf("/appointments/%d", created.Id)
+
+	steps := []struct {
+		name       string
+		method     string
+		target     string
+		wantStatus int
+	}{
+		{"get", http.MethodGet, path, http.StatusOK},
+		{"get with an invalid id", http.MethodGet, "/appointments/first", http.StatusBadRequest},
+		{"get a missing one", http.MethodGet, "/appointments/99", http.StatusNotFound},
+		{"confirm", http.MethodPost, path + "/confirm", http.StatusOK},
+		{"confirm again", http.MethodPost, path + "/confirm", http.StatusConflict},
+		{"delete", http.MethodDelete, path, http.StatusOK},
+		{"get once deleted", http.MethodGet, path, http.StatusNotFound},
+		{"delete again", http.MethodDelete, path, http.StatusNotFound},
+	}
+	for _, step := range steps {
+		if rec := serve(router, step.method, step.target, nil); rec.Code != step.wantStatus {
+			t.Fatalf("%s: status = %d, want %d: %s", step.name, rec.Code, step.wantStatus, rec.Body.String())
+		}
+	}
+}
+
+func TestAppointmentHandlerGetByIDScopesThePrincipal(t *testing.T) {
+	year, month, day := time.Now().AddDate(0, 0, 7).Date()
+	tests := []struct {
+		name       string
+		principal  domain.Principal
+		wantStatus int
+	}{
+		{"patient of the appointment", domain.Principal{Roles: []string{domain.RolePatient}, RG: "RG-1"}, http.StatusOK},
+		{"another patient", domain.Principal{Roles: []string{domain.RolePatient}, RG: "RG-2"}, http.StatusForbidden},
+		{"dentist of the appointment", domain.Principal{Roles: []string{domain.RoleDentist}, CRO: "CRO-1"}, http.StatusOK},
+		{"another dentist", domain.Principal{Roles: []string{domain.RoleDentist}, CRO: "CRO-2"}, http.StatusForbidden},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			router, s := newTestRouter(t, &tt.principal)
+			book(t, s, time.Date(year, month, day, 10, 0, 0, 0, time.Local))
+			if rec := serve(router, http.MethodGet, "/appointments/1", nil); rec.Code != tt.wantStatus {
+				t.Errorf("status = %d, want %d: %s", rec.Code, tt.wantStatus, rec.Body.String())
+			}
+		})
+	}
+}
+
+func TestErrorStatus(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want int
+	}{
+		{"invalid", fmt.Errorf("%w: the duration can't be negative", appointment.ErrInvalidAppointment), http.StatusBadRequest},
+		{"forbidden", domain.ErrForbidden, http.StatusForbidden},
+		{"not found", appointment.ErrNotFound, http.StatusNotFound},
+		{"status transition", domain.ErrInvalidStatusTransition, http.StatusConflict},
+		{"overlapping", appointment.ErrScheduleConflict, http.StatusConflict},
+		{"outside the working hours", fmt.Errorf("%w: closed on sundays", appointment.ErrOutsideWorkingHours), http.StatusConflict},
+		{"clinic closed", appointment.ErrClinicClosed, http.StatusConflict},
+		{"anything else", errors.New("database unavailable"), http.StatusTeapot},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := errorStatus(tt.err, http.StatusTeapot); got != tt.want {
+				t.Errorf("errorStatus() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
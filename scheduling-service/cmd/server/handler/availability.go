@@ -0,0 +1,75 @@
+package handler
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/ronilsonalves/GoLang-in-a-spring-cloud-architecture/scheduling-service/internal/availability"
+	"github.com/ronilsonalves/GoLang-in-a-spring-cloud-architecture/scheduling-service/internal/domain"
+	"github.com/ronilsonalves/GoLang-in-a-spring-cloud-architecture/scheduling-service/pkg/web"
+	"net/http"
+	"strconv"
+)
+
+type availabilityHandler struct {
+	s availability.Service
+}
+
+func NewAvailabilityHandler(s availability.Service) *availabilityHandler {
+	return &availabilityHandler{
+		s: s,
+	}
+}
+
+// Get - search the free slots to book an appointment
+// @BasePath /api/v1
+// GetAvailability godoc
+// @Summary Search free slots
+// @Schemes
+// @Description Search the free slots to book an appointment between from and to, considering the dentists working hours, breaks, exceptions, appointments and clinic closures. Without dentistCRO every dentist is searched, with patientRG the periods the patient is booked are excluded, patients always search around their own appointments and can't ask for other patients. Dates are in format 30/01/2023 23:59 and the search covers up to 31 days.
+// @Tags Availability
+// @Accept json
+// @Produce json
+// @Param dentistCRO query string false "Dentist license number"
+// @Param patientRG query string false "Patient identity number"
+// @Param from query string true "Search start"
+// @Param to query string true "Search end"
+// @Param duration query int false "Appointment duration in minutes, 60 by default"
+// @Success 200 {object} []domain.Slot
+// @Failure 400 {object} web.errorResponse
+// @Failure 401 {object} web.errorResponse
+// @Failure 403 {object} web.errorResponse
+// @Router /availability [get]
+// @Security OAuth2Application
+func (h *availabilityHandler) Get() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		from, err := domain.ParseDateTime(ctx.Query("from"))
+		if err != nil {
+			web.BadResponse(ctx, http.StatusBadRequest, "error", "invalid from provided, it must be in format: 30/01/2023 23:59")
+			return
+		}
+		to, err := domain.ParseDateTime(ctx.Query("to"))
+		if err != nil {
+			web.BadResponse(ctx, http.StatusBadRequest, "error", "invalid to provided, it must be in format: 30/01/2023 23:59")
+			return
+		}
+		duration := domain.DefaultDuration
+		if param := ctx.Query("duration"); param != "" {
+			duration, err = strconv.Atoi(param)
+			if err != nil || !isValidDuration(duration) || duration == 0 {
+				web.BadResponse(ctx, http.StatusBadRequest, "error", "invalid duration provided")
+				return
+			}
+		}
+		response, err := h.s.FindSlots(ctx.Request.Context(), domain.SlotQuery{
+			DentistCRO: ctx.Query("dentistCRO"),
+			PatientRG:  ctx.Query("patientRG"),
+			From:       from,
+			To:         to,
+			Duration:   duration,
+		})
+		if err != nil {
+			web.BadResponse(ctx, errorStatus(err, http.StatusBadRequest), "error", err.Error())
+			return
+		}
+		web.ResponseOK(ctx, http.StatusOK, response)
+	}
+}
@@ -0,0 +1,113 @@
+package handler
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/ronilsonalves/GoLang-in-a-spring-cloud-architecture/scheduling-service/internal/closure"
+	"github.com/ronilsonalves/GoLang-in-a-spring-cloud-architecture/scheduling-service/internal/domain"
+	"github.com/ronilsonalves/GoLang-in-a-spring-cloud-architecture/scheduling-service/pkg/web"
+	"net/http"
+	"strconv"
+)
+
+type closureHandler struct {
+	s closure.Service
+}
+
+func NewClosureHandler(s closure.Service) *closureHandler {
+	return &closureHandler{
+		s: s,
+	}
+}
+
+// GetAll - get all clinic closures from db.
+// @BasePath /api/v1
+// GetAllClosures godoc
+// @Summary List all clinic closures
+// @Schemes
+// @Description get all periods the clinic is closed, like holidays, ordered by start.
+// @Tags Closures
+// @Accept json
+// @Produce json
+// @Success 200 {object} []domain.ClinicClosure
+// @Failure 400 {object} web.errorResponse
+// @Failure 401 {object} web.errorResponse
+// @Failure 403 {object} web.errorResponse
+// @Router /closures [get]
+// @Security OAuth2Application
+func (h *closureHandler) GetAll() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		response, err := h.s.GetAll(ctx.Request.Context())
+		if err != nil {
+			web.BadResponse(ctx, http.StatusBadRequest, "error", err.Error())
+			return
+		}
+		if response == nil {
+			response = []domain.ClinicClosure{}
+		}
+		web.ResponseOK(ctx, http.StatusOK, response)
+	}
+}
+
+// Post - add a clinic closure
+// @BasePath /api/v1
+// PostClosure godoc
+// @Summary Add a clinic closure
+// @Schemes
+// @Description Add a period the whole clinic is closed, no appointment can be booked during it. Dates are in format 30/01/2023 23:59.
+// @Tags Closures
+// @Accept json
+// @Produce json
+// @Param body body domain.ClinicClosure true "Body"
+// @Success 201 {object} domain.ClinicClosure
+// @Failure 400 {object} web.errorResponse
+// @Failure 401 {object} web.errorResponse
+// @Failure 403 {object} web.errorResponse
+// @Router /closures [post]
+// @Security OAuth2Application
+func (h *closureHandler) Post() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		var clinicClosure domain.ClinicClosure
+		if err := ctx.ShouldBindJSON(&clinicClosure); err != nil {
+			web.BadResponse(ctx, http.StatusBadRequest, "error", "invalid closure data, please verify field(s): "+err.Error())
+			return
+		}
+		response, err := h.s.Create(ctx.Request.Context(), clinicClosure)
+		if err != nil {
+			web.BadResponse(ctx, http.StatusBadRequest, "error", err.Error())
+			return
+		}
+		web.ResponseOK(ctx, http.StatusCreated, response)
+	}
+}
+
+// Delete - remove a clinic closure
+// @BasePath /api/v1
+// DeleteClosure godoc
+// @Summary Remove a clinic closure
+// @Schemes
+// @Description Remove a clinic closure by ID
+// @Tags Closures
+// @Accept json
+// @Produce json
+// @Param id path int true "Closure ID"
+// @Success 200 {object} web.errorResponse
+// @Failure 400 {object} web.errorResponse
+// @Failure 401 {object} web.errorResponse
+// @Failure 403 {object} web.errorResponse
+// @Failure 404 {object} web.errorResponse
+// @Router /closures/{id} [delete]
+// @Security OAuth2Application
+func (h *closureHandler) Delete() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		id, err := strconv.Atoi(ctx.Param("id"))
+		if err != nil {
+			web.BadResponse(ctx, http.StatusBadRequest, "error", "invalid id provided")
+			return
+		}
+		if err := h.s.Delete(ctx.Request.Context(), id); err != nil {
+			web.BadResponse(ctx, http.StatusNotFound, "error", err.Error())
+			return
+		}
+		web.DeleteResponse(ctx, http.StatusOK, "clinic closure removed")
+	}
+}
@@ -0,0 +1,173 @@
+package handler
+
+import (
+	"errors"
+	"github.com/gin-gonic/gin"
+	"github.com/ronilsonalves/GoLang-in-a-spring-cloud-architecture/scheduling-service/internal/deadletter"
+	"github.com/ronilsonalves/GoLang-in-a-spring-cloud-architecture/scheduling-service/internal/domain"
+	"github.com/ronilsonalves/GoLang-in-a-spring-cloud-architecture/scheduling-service/pkg/web"
+	"net/http"
+	"strconv"
+)
+
+type deadLetterHandler struct {
+	s deadletter.Service
+}
+
+func NewDeadLetterHandler(s deadletter.Service) *deadLetterHandler {
+	return &deadLetterHandler{
+		s: s,
+	}
+}
+
+// GetAll - get a page of the dead letters from db.
+// @BasePath /api/v1
+// GetAllDeadLetters godoc
+// @Summary List dead letters
+// @Schemes
+// @Description get a page of the messages parked at the dead letter queue after they couldn't be handled, the oldest first unless requested otherwise.
+// @Tags Admin
+// @Accept json
+// @Produce json
+// @Param page query int false "Page, starting at 1"
+// @Param limit query int false "Items per page, 20 by default and up to 100"
+// @Param sort query string false "Sort field, prefixed with - for descending order" Enums(id, -id, queue, -queue, routingKey, -routingKey)
+// @Param queue query string false "Queue the messages were dead-lettered from"
+// @Param routingKey query string false "Routing key the messages were published with, e.g. appointment.created"
+// @Success 200 {object} domain.DeadLetterPage
+// @Failure 400 {object} web.errorResponse
+// @Failure 401 {object} web.errorResponse
+// @Failure 403 {object} web.errorResponse
+// @Router /admin/dead-letters [get]
+// @Security OAuth2Application
+func (h *deadLetterHandler) GetAll() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		q, err := parseListQuery(ctx, domain.DeadLetterFilter{
+			Queue:      ctx.Query("queue"),
+			RoutingKey: ctx.Query("routingKey"),
+		})
+		if err != nil {
+			web.BadResponse(ctx, http.StatusBadRequest, "error", err.Error())
+			return
+		}
+		response, err := h.s.List(ctx.Request.Context(), q)
+		if err != nil {
+			web.BadResponse(ctx, http.StatusBadRequest, "error", err.Error())
+			return
+		}
+		respondPage(ctx, response)
+	}
+}
+
+// GetByID - inspect a dead letter by an ID
+// @BasePath /api/v1
+// GetDeadLetterByID godoc
+// @Summary Inspect a dead letter
+// @Schemes
+// @Description get a dead letter by a provided ID with its headers, body and the reason it was parked.
+// @Tags Admin
+// @Accept json
+// @Produce json
+// @Param id path int true "Dead letter ID"
+// @Success 200 {object} domain.DeadLetter
+// @Failure 400 {object} web.errorResponse
+// @Failure 401 {object} web.errorResponse
+// @Failure 403 {object} web.errorResponse
+// @Failure 404 {object} web.errorResponse
+// @Failure 500 {object} web.errorResponse
+// @Router /admin/dead-letters/{id} [get]
+// @Security OAuth2Application
+func (h *deadLetterHandler) GetByID() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		id, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+		if err != nil {
+			web.BadResponse(ctx, http.StatusBadRequest, "error", "invalid id provided")
+			return
+		}
+		response, err := h.s.GetByID(ctx.Request.Context(), id)
+		if err != nil {
+			web.BadResponse(ctx, deadLetterErrorStatus(err), "error", err.Error())
+			return
+		}
+		web.ResponseOK(ctx, http.StatusOK, response)
+	}
+}
+
+// Replay - send a dead letter back to its queue
+// @BasePath /api/v1
+// ReplayDeadLetter godoc
+// @Summary Replay a dead letter
+// @Schemes
+// @Description Send a dead letter back to the queue it came from, only its consumer receives it again with the retries started over. It's removed once sent.
+// @Tags Admin
+// @Accept json
+// @Produce json
+// @Param id path int true "Dead letter ID"
+// @Success 200 {object} web.errorResponse
+// @Failure 400 {object} web.errorResponse
+// @Failure 401 {object} web.errorResponse
+// @Failure 403 {object} web.errorResponse
+// @Failure 404 {object} web.errorResponse
+// @Failure 500 {object} web.errorResponse
+// @Failure 502 {object} web.errorResponse
+// @Router /admin/dead-letters/{id}/replay [post]
+// @Security OAuth2Application
+func (h *deadLetterHandler) Replay() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		id, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+		if err != nil {
+			web.BadResponse(ctx, http.StatusBadRequest, "error", "invalid id provided")
+			return
+		}
+		if err := h.s.Replay(ctx.Request.Context(), id); err != nil {
+			web.BadResponse(ctx, deadLetterErrorStatus(err), "error", err.Error())
+			return
+		}
+		web.DeleteResponse(ctx, http.StatusOK, "dead letter replayed")
+	}
+}
+
+// Delete - discard a dead letter
+// @BasePath /api/v1
+// DeleteDeadLetter godoc
+// @Summary Discard a dead letter
+// @Schemes
+// @Description Remove a dead letter by ID, it's never delivered again.
+// @Tags Admin
+// @Accept json
+// @Produce json
+// @Param id path int true "Dead letter ID"
+// @Success 200 {object} web.errorResponse
+// @Failure 400 {object} web.errorResponse
+// @Failure 401 {object} web.errorResponse
+// @Failure 403 {object} web.errorResponse
+// @Failure 404 {object} web.errorResponse
+// @Failure 500 {object} web.errorResponse
+// @Router /admin/dead-letters/{id} [delete]
+// @Security OAuth2Application
+func (h *deadLetterHandler) Delete() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		id, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+		if err != nil {
+			web.BadResponse(ctx, http.StatusBadRequest, "error", "invalid id provided")
+			return
+		}
+		if err := h.s.Discard(ctx.Request.Context(), id); err != nil {
+			web.BadResponse(ctx, deadLetterErrorStatus(err), "error", err.Error())
+			return
+		}
+		web.DeleteResponse(ctx, http.StatusOK, "dead letter discarded")
+	}
+}
+
+// deadLetterErrorStatus - the status code of an error from the service: a missing dead letter is 404, the broker
+// failing the replay is 502 and anything else, like the database being unavailable, is 500.
+func deadLetterErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, deadletter.ErrNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, deadletter.ErrReplayFailed):
+		return http.StatusBadGateway
+	}
+	return http.StatusInternalServerError
+}
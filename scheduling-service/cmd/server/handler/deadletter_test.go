@@ -0,0 +1,80 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"github.com/gin-gonic/gin"
+	"github.com/ronilsonalves/GoLang-in-a-spring-cloud-architecture/scheduling-service/internal/deadletter"
+	"github.com/ronilsonalves/GoLang-in-a-spring-cloud-architecture/scheduling-service/internal/domain"
+	"github.com/ronilsonalves/GoLang-in-a-spring-cloud-architecture/scheduling-service/pkg/store"
+	"net/http"
+	"testing"
+)
+
+// failingDeadLetters - a repository of dead letters whose database is unavailable.
+type failingDeadLetters struct {
+	deadletter.Repository
+}
+
+func (failingDeadLetters) GetByID(ctx context.Context, id int64) (domain.DeadLetter, error) {
+	return domain.DeadLetter{}, errors.New("database unavailable")
+}
+
+func (failingDeadLetters) Delete(ctx context.Context, id int64) error {
+	return errors.New("database unavailable")
+}
+
+// replayer - a broker taking the dead letters back, or failing them all with err.
+type replayer struct {
+	err error
+}
+
+func (r replayer) Replay(ctx context.Context, letter domain.DeadLetter) error {
+	return r.err
+}
+
+// newDeadLetterRouter - the dead letter routes on the repository provided, replaying through the replayer provided.
+func newDeadLetterRouter(r deadletter.Repository, replayer deadletter.Replayer) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	h := NewDeadLetterHandler(deadletter.NewService(r, replayer))
+	router := gin.New()
+	router.GET("/dead-letters/:id", h.GetByID())
+	router.POST("/dead-letters/:id/replay", h.Replay())
+	router.DELETE("/dead-letters/:id", h.Delete())
+	return router
+}
+
+func TestDeadLetterHandlerStatuses(t *testing.T) {
+	letters := deadletter.NewRepository(store.NewMemoryDeadLetter())
+	if _, err := letters.Create(context.Background(), domain.DeadLetter{MessageID: "1", Queue: "scheduling-service.invoices", Body: `{}`}); err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+
+	tests := []struct {
+		name       string
+		repository deadletter.Repository
+		replayErr  error
+		method     string
+		target     string
+		wantStatus int
+	}{
+		{"get", letters, nil, http.MethodGet, "/dead-letters/1", http.StatusOK},
+		{"get a missing one", letters, nil, http.MethodGet, "/dead-letters/99", http.StatusNotFound},
+		{"get with the database unavailable", failingDeadLetters{letters}, nil, http.MethodGet, "/dead-letters/1", http.StatusInternalServerError},
+		{"replay a missing one", letters, nil, http.MethodPost, "/dead-letters/99/replay", http.StatusNotFound},
+		{"replay with the database unavailable", failingDeadLetters{letters}, nil, http.MethodPost, "/dead-letters/1/replay", http.StatusInternalServerError},
+		{"replay refused by the broker", letters, errors.New("connection refused"), http.MethodPost, "/dead-letters/1/replay", http.StatusBadGateway},
+		{"discard a missing one", letters, nil, http.MethodDelete, "/dead-letters/99", http.StatusNotFound},
+		{"discard with the database unavailable", failingDeadLetters{letters}, nil, http.MethodDelete, "/dead-letters/1", http.StatusInternalServerError},
+		{"replay", letters, nil, http.MethodPost, "/dead-letters/1/replay", http.StatusOK},
+		{"get once replayed", letters, nil, http.MethodGet, "/dead-letters/1", http.StatusNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			router := newDeadLetterRouter(tt.repository, replayer{tt.replayErr})
+			if rec := serve(router, tt.method, tt.target, nil); rec.Code != tt.wantStatus {
+				t.Errorf("status = %d, want %d: %s", rec.Code, tt.wantStatus, rec.Body.String())
+			}
+		})
+	}
+}
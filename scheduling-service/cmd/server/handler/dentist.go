@@ -20,28 +20,42 @@ func NewDentistHandler(s dentist.Service) *dentistHandler {
 	}
 }
 
-// GetAll - get all dentists from db.
+// GetAll - get a page of the dentists from db.
 // @BasePath /api/v1
 // GetAllDentists godoc
-// @Summary List all dentists
+// @Summary List dentists
 // @Schemes
-// @Description get all dentists from db.
+// @Description get a page of the dentists from db matching the filters, sorted by id unless requested otherwise.
 // @Tags Dentists
 // @Accept json
 // @Produce json
-// @Success 200 {object} []domain.Dentist
+// @Param page query int false "Page, starting at 1"
+// @Param limit query int false "Items per page, 20 by default and up to 100"
+// @Param sort query string false "Sort field, prefixed with - for descending order" Enums(id, -id, name, -name, lastName, -lastName, cro, -cro)
+// @Param name query string false "Start of the name or the last name"
+// @Param cro query string false "Dentist license number"
+// @Success 200 {object} domain.DentistPage
 // @Failure 400 {object} web.errorResponse
 // @Failure 401 {object} web.errorResponse
+// @Failure 403 {object} web.errorResponse
 // @Router /dentists [get]
 // @Security OAuth2Application
 func (h *dentistHandler) GetAll() gin.HandlerFunc {
 	return func(ctx *gin.Context) {
-		response, err := h.s.GetAll()
+		q, err := parseListQuery(ctx, domain.DentistFilter{
+			Name: ctx.Query("name"),
+			CRO:  ctx.Query("cro"),
+		})
 		if err != nil {
 			web.BadResponse(ctx, http.StatusBadRequest, "error", err.Error())
 			return
 		}
-		web.ResponseOK(ctx, http.StatusOK, response)
+		response, err := h.s.List(ctx.Request.Context(), q)
+		if err != nil {
+			web.BadResponse(ctx, http.StatusBadRequest, "error", err.Error())
+			return
+		}
+		respondPage(ctx, response)
 	}
 }
 
@@ -58,6 +72,7 @@ func (h *dentistHandler) GetAll() gin.HandlerFunc {
 // @Success 200 {object} domain.Dentist
 // @Failure 400 {object} web.errorResponse
 // @Failure 401 {object} web.errorResponse
+// @Failure 403 {object} web.errorResponse
 // @Failure 404 {object} web.errorResponse
 // @Router /dentists/{id} [get]
 // @Security OAuth2Application
@@ -70,7 +85,7 @@ func (h *dentistHandler) GetByID() gin.HandlerFunc {
 			return
 		}
 
-		response, err := h.s.GetByID(id)
+		response, err := h.s.GetByID(ctx.Request.Context(), id)
 		if err != nil {
 			web.BadResponse(ctx, http.StatusNotFound, "error", "dentist not found")
 			return
@@ -92,6 +107,7 @@ func (h *dentistHandler) GetByID() gin.HandlerFunc {
 // @Success 201 {object} domain.Dentist
 // @Failure 400 {object} web.errorResponse
 // @Failure 401 {object} web.errorResponse
+// @Failure 403 {object} web.errorResponse
 // @Router /dentists [post]
 // @Security OAuth2Application
 func (h *dentistHandler) Post() gin.HandlerFunc {
@@ -109,7 +125,7 @@ func (h *dentistHandler) Post() gin.HandlerFunc {
 			return
 		}
 
-		response, err := h.s.Create(dentist)
+		response, err := h.s.Create(ctx.Request.Context(), dentist)
 		if err != nil {
 			web.BadResponse(ctx, http.StatusBadRequest, "error", err.Error())
 			return
@@ -132,6 +148,7 @@ func (h *dentistHandler) Post() gin.HandlerFunc {
 // @Success 200 {object} domain.Dentist
 // @Failure 400 {object} web.errorResponse
 // @Failure 401 {object} web.errorResponse
+// @Failure 403 {object} web.errorResponse
 // @Failure 409 {object} web.errorResponse
 // @Router /dentists/{id} [put]
 // @Security OAuth2Application
@@ -155,7 +172,7 @@ func (h *dentistHandler) Put() gin.HandlerFunc {
 			return
 		}
 
-		response, err := h.s.Update(id, dentist)
+		response, err := h.s.Update(ctx.Request.Context(), id, dentist)
 		if err != nil {
 			web.BadResponse(ctx, http.StatusConflict, "error", err.Error())
 			return
@@ -178,6 +195,7 @@ func (h *dentistHandler) Put() gin.HandlerFunc {
 // @Success 200 {object} domain.Dentist
 // @Failure 400 {object} web.errorResponse
 // @Failure 401 {object} web.errorResponse
+// @Failure 403 {object} web.errorResponse
 // @Router /dentists/{id} [patch]
 // @Security OAuth2Application
 func (h *dentistHandler) Patch() gin.HandlerFunc {
@@ -204,7 +222,7 @@ func (h *dentistHandler) Patch() gin.HandlerFunc {
 			CRO:      r.LicenseNumber,
 		}
 
-		updated, err := h.s.Update(id, update)
+		updated, err := h.s.Update(ctx.Request.Context(), id, update)
 		if err != nil {
 			web.BadResponse(ctx, http.StatusBadRequest, "error", err.Error())
 			return
@@ -227,6 +245,7 @@ func (h *dentistHandler) Patch() gin.HandlerFunc {
 // @Failure 400 {object} web.errorResponse
 // @Failure 404 {object} web.errorResponse
 // @Failure 401 {object} web.errorResponse
+// @Failure 403 {object} web.errorResponse
 // @Router /dentists/{id} [delete]
 // @Security OAuth2Application
 func (h *dentistHandler) Delete() gin.HandlerFunc {
@@ -237,7 +256,7 @@ func (h *dentistHandler) Delete() gin.HandlerFunc {
 			web.BadResponse(ctx, http.StatusBadRequest, "error", "invalid id provided")
 			return
 		}
-		err = h.s.Delete(id)
+		err = h.s.Delete(ctx.Request.Context(), id)
 		if err != nil {
 			web.BadResponse(ctx, http.StatusNotFound, "error", err.Error())
 			return
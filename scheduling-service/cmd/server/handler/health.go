@@ -0,0 +1,57 @@
+package handler
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/ronilsonalves/GoLang-in-a-spring-cloud-architecture/scheduling-service/pkg/health"
+	"net/http"
+)
+
+// The health routes are served at the root, outside of the API base path and without authentication, as the Eureka
+// registration advertises them, so they're left out of the swagger docs.
+type healthHandler struct {
+	indicator *health.Indicator
+}
+
+func NewHealthHandler(indicator *health.Indicator) *healthHandler {
+	return &healthHandler{
+		indicator: indicator,
+	}
+}
+
+// Health - the readiness of the service along with every dependency checked, the Eureka health check url.
+func (h *healthHandler) Health() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		respondHealth(ctx, h.indicator.Readiness(ctx.Request.Context()))
+	}
+}
+
+// Liveness - up while the process answers, a restart is only due when it doesn't.
+func (h *healthHandler) Liveness() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		respondHealth(ctx, h.indicator.Liveness())
+	}
+}
+
+// Readiness - up while the service can handle requests, down when a dependency is down or the service is shutting
+// down.
+func (h *healthHandler) Readiness() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		respondHealth(ctx, h.indicator.Readiness(ctx.Request.Context()))
+	}
+}
+
+// Status - the readiness of the service without the dependencies details, the Eureka status page url.
+func (h *healthHandler) Status() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		respondHealth(ctx, health.Health{Status: h.indicator.Readiness(ctx.Request.Context()).Status})
+	}
+}
+
+// respondHealth - write a health report, with 503 Service Unavailable when it's down as Spring Boot Actuator does.
+func respondHealth(ctx *gin.Context, report health.Health) {
+	status := http.StatusOK
+	if report.Status != health.StatusUp {
+		status = http.StatusServiceUnavailable
+	}
+	ctx.JSON(status, report)
+}
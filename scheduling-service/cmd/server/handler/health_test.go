@@ -0,0 +1,77 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/gin-gonic/gin"
+	"github.com/ronilsonalves/GoLang-in-a-spring-cloud-architecture/scheduling-service/pkg/health"
+	"net/http"
+	"testing"
+	"time"
+)
+
+// newHealthRouter - the health routes registered before the authentication, as the server does, with a stand-in
+// authentication refusing every request to the routes registered after it.
+func newHealthRouter(indicator *health.Indicator) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	h := NewHealthHandler(indicator)
+	router := gin.New()
+	router.GET("/health", h.Health())
+	router.GET("/health/liveness", h.Liveness())
+	router.GET("/health/readiness", h.Readiness())
+	router.GET("/status", h.Status())
+	router.Use(func(c *gin.Context) { c.AbortWithStatus(http.StatusUnauthorized) })
+	router.GET("/api/v1/appointments", func(c *gin.Context) { c.Status(http.StatusOK) })
+	return router
+}
+
+func TestHealthHandlerTurnsDownWhenShuttingDown(t *testing.T) {
+	indicator := health.NewIndicator(time.Second)
+	indicator.Register("db", func(ctx context.Context) error { return nil })
+	router := newHealthRouter(indicator)
+
+	if rec := serve(router, http.MethodGet, "/api/v1/appointments", nil); rec.Code != http.StatusUnauthorized {
+		t.Fatalf("a route after the authentication answered %d without a token, want 401", rec.Code)
+	}
+
+	steps := []struct {
+		name          string
+		shuttingDown  bool
+		target        string
+		wantStatus    int
+		wantHealth    health.Status
+		wantShutdown  bool
+		wantNoDetails bool
+	}{
+		{"health", false, "/health", http.StatusOK, health.StatusUp, false, false},
+		{"readiness", false, "/health/readiness", http.StatusOK, health.StatusUp, false, false},
+		{"status", false, "/status", http.StatusOK, health.StatusUp, false, true},
+		{"health while shutting down", true, "/health", http.StatusServiceUnavailable, health.StatusDown, true, false},
+		{"readiness while shutting down", true, "/health/readiness", http.StatusServiceUnavailable, health.StatusDown, true, false},
+		{"status while shutting down", true, "/status", http.StatusServiceUnavailable, health.StatusDown, false, true},
+		{"liveness while shutting down", true, "/health/liveness", http.StatusOK, health.StatusUp, false, true},
+	}
+	for _, step := range steps {
+		if step.shuttingDown {
+			indicator.ShuttingDown()
+		}
+		// the health routes are called without a token
+		rec := serve(router, http.MethodGet, step.target, nil)
+		if rec.Code != step.wantStatus {
+			t.Fatalf("%s: status = %d, want %d: %s", step.name, rec.Code, step.wantStatus, rec.Body.String())
+		}
+		var report health.Health
+		if err := json.Unmarshal(rec.Body.Bytes(), &report); err != nil {
+			t.Fatalf("%s: json.Unmarshal() error = %v", step.name, err)
+		}
+		if report.Status != step.wantHealth {
+			t.Errorf("%s: health = %s, want %s", step.name, report.Status, step.wantHealth)
+		}
+		if _, ok := report.Components["shutdown"]; ok != step.wantShutdown {
+			t.Errorf("%s: components = %v, want the shutdown reported %t", step.name, report.Components, step.wantShutdown)
+		}
+		if step.wantNoDetails && len(report.Components) > 0 {
+			t.Errorf("%s: components = %v, want none", step.name, report.Components)
+		}
+	}
+}
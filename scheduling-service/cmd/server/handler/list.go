@@ -0,0 +1,41 @@
+package handler
+
+import (
+	"errors"
+	"github.com/gin-gonic/gin"
+	"github.com/ronilsonalves/GoLang-in-a-spring-cloud-architecture/scheduling-service/internal/domain"
+	"github.com/ronilsonalves/GoLang-in-a-spring-cloud-architecture/scheduling-service/pkg/web"
+	"net/http"
+	"strconv"
+)
+
+// parseListQuery - read the page, limit and sort query params shared by every list endpoint.
+func parseListQuery[F any](ctx *gin.Context, filter F) (domain.ListQuery[F], error) {
+	q := domain.ListQuery[F]{Sort: ctx.Query("sort"), Filter: filter}
+	if param := ctx.Query("page"); param != "" {
+		page, err := strconv.Atoi(param)
+		if err != nil || page < 1 {
+			return q, errors.New("invalid page provided, pages start at 1")
+		}
+		q.Page = page
+	}
+	if param := ctx.Query("limit"); param != "" {
+		limit, err := strconv.Atoi(param)
+		if err != nil || limit < 1 || limit > domain.MaxPageLimit {
+			return q, errors.New("invalid limit provided, it must be between 1 and " + strconv.Itoa(domain.MaxPageLimit))
+		}
+		q.Limit = limit
+	}
+	return q, nil
+}
+
+// respondPage - write a page of a list, linking to the next page with the same filters when there are items after it.
+func respondPage[T any](ctx *gin.Context, page domain.Page[T]) {
+	if page.HasNext() {
+		query := ctx.Request.URL.Query()
+		query.Set("page", strconv.Itoa(page.Page+1))
+		query.Set("limit", strconv.Itoa(page.Limit))
+		page.Next = ctx.Request.URL.Path + "?" + query.Encode()
+	}
+	web.ResponseOK(ctx, http.StatusOK, page)
+}
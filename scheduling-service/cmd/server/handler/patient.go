@@ -20,28 +20,42 @@ func NewPatientHandler(s patient.Service) *patientHandler {
 	}
 }
 
-// GetAll - get all patients from db.
+// GetAll - get a page of the patients from db.
 // @BasePath /api/v1
 // GetAllPatients godoc
-// @Summary List all patients
+// @Summary List patients
 // @Schemes
-// @Description get all patients from db.
+// @Description get a page of the patients from db matching the filters, sorted by id unless requested otherwise.
 // @Tags Patients
 // @Accept json
 // @Produce json
-// @Success 200 {object} []domain.Patient
+// @Param page query int false "Page, starting at 1"
+// @Param limit query int false "Items per page, 20 by default and up to 100"
+// @Param sort query string false "Sort field, prefixed with - for descending order" Enums(id, -id, name, -name, lastName, -lastName, rg, -rg, createdAt, -createdAt)
+// @Param name query string false "Start of the name or the last name"
+// @Param rg query string false "Patient identity number"
+// @Success 200 {object} domain.PatientPage
 // @Failure 400 {object} web.errorResponse
 // @Failure 401 {object} web.errorResponse
+// @Failure 403 {object} web.errorResponse
 // @Router /patients [get]
 // @Security OAuth2Application
 func (h *patientHandler) GetAll() gin.HandlerFunc {
 	return func(ctx *gin.Context) {
-		patients, err := h.s.GetAll()
+		q, err := parseListQuery(ctx, domain.PatientFilter{
+			Name: ctx.Query("name"),
+			RG:   ctx.Query("rg"),
+		})
 		if err != nil {
 			web.BadResponse(ctx, http.StatusBadRequest, "error", err.Error())
 			return
 		}
-		web.ResponseOK(ctx, http.StatusOK, patients)
+		response, err := h.s.List(ctx.Request.Context(), q)
+		if err != nil {
+			web.BadResponse(ctx, http.StatusBadRequest, "error", err.Error())
+			return
+		}
+		respondPage(ctx, response)
 	}
 }
 
@@ -58,6 +72,7 @@ func (h *patientHandler) GetAll() gin.HandlerFunc {
 // @Success 200 {object} domain.Patient
 // @Failure 400 {object} web.errorResponse
 // @Failure 401 {object} web.errorResponse
+// @Failure 403 {object} web.errorResponse
 // @Failure 404 {object} web.errorResponse
 // @Router /patients/{id} [get]
 // @Security OAuth2Application
@@ -70,7 +85,7 @@ func (h *patientHandler) GetByID() gin.HandlerFunc {
 			return
 		}
 
-		patient, err := h.s.GetByID(id)
+		patient, err := h.s.GetByID(ctx.Request.Context(), id)
 		if err != nil {
 			web.BadResponse(ctx, http.StatusNotFound, "error", "patient not found")
 			return
@@ -92,6 +107,7 @@ func (h *patientHandler) GetByID() gin.HandlerFunc {
 // @Success 201 {object} domain.Patient
 // @Failure 400 {object} web.errorResponse
 // @Failure 401 {object} web.errorResponse
+// @Failure 403 {object} web.errorResponse
 // @Router /patients [post]
 // @Security OAuth2Application
 func (h *patientHandler) Post() gin.HandlerFunc {
@@ -109,7 +125,7 @@ func (h *patientHandler) Post() gin.HandlerFunc {
 			return
 		}
 
-		response, err := h.s.Create(patient)
+		response, err := h.s.Create(ctx.Request.Context(), patient)
 		if err != nil {
 			web.BadResponse(ctx, http.StatusBadRequest, "error", err.Error())
 			return
@@ -133,6 +149,7 @@ func (h *patientHandler) Post() gin.HandlerFunc {
 // @Success 200 {object} domain.Patient
 // @Failure 400 {object} web.errorResponse
 // @Failure 401 {object} web.errorResponse
+// @Failure 403 {object} web.errorResponse
 // @Failure 409 {object} web.errorResponse
 // @Router /patients [put]
 // @Security OAuth2Application
@@ -156,7 +173,7 @@ func (h *patientHandler) Put() gin.HandlerFunc {
 			return
 		}
 
-		response, err := h.s.Update(id, patient)
+		response, err := h.s.Update(ctx.Request.Context(), id, patient)
 		if err != nil {
 			web.BadResponse(ctx, http.StatusConflict, "error", err.Error())
 			return
@@ -179,6 +196,7 @@ func (h *patientHandler) Put() gin.HandlerFunc {
 // @Success 200 {object} domain.Patient
 // @Failure 400 {object} web.errorResponse
 // @Failure 401 {object} web.errorResponse
+// @Failure 403 {object} web.errorResponse
 // @Router /patients/{id} [patch]
 // @Security OAuth2Application
 func (h *patientHandler) Patch() gin.HandlerFunc {
@@ -212,7 +230,7 @@ func (h *patientHandler) Patch() gin.HandlerFunc {
 				return
 			}
 		}
-		response, err := h.s.Update(id, update)
+		response, err := h.s.Update(ctx.Request.Context(), id, update)
 		if err != nil {
 			web.BadResponse(ctx, http.StatusBadRequest, "error", err.Error())
 			return
@@ -235,6 +253,7 @@ func (h *patientHandler) Patch() gin.HandlerFunc {
 // @Failure 400 {object} web.errorResponse
 // @Failure 404 {object} web.errorResponse
 // @Failure 401 {object} web.errorResponse
+// @Failure 403 {object} web.errorResponse
 // @Router /patients/{id} [delete]
 // @Security OAuth2Application
 func (h *patientHandler) Delete() gin.HandlerFunc {
@@ -245,7 +264,7 @@ func (h *patientHandler) Delete() gin.HandlerFunc {
 			web.BadResponse(ctx, http.StatusBadRequest, "error", "invalid id provided")
 			return
 		}
-		err = h.s.Delete(id)
+		err = h.s.Delete(ctx.Request.Context(), id)
 		if err != nil {
 			web.BadResponse(ctx, http.StatusNotFound, "error", err.Error())
 			return
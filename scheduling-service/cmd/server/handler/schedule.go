@@ -0,0 +1,177 @@
+package handler
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/ronilsonalves/GoLang-in-a-spring-cloud-architecture/scheduling-service/internal/domain"
+	"github.com/ronilsonalves/GoLang-in-a-spring-cloud-architecture/scheduling-service/internal/schedule"
+	"github.com/ronilsonalves/GoLang-in-a-spring-cloud-architecture/scheduling-service/pkg/web"
+	"net/http"
+	"strconv"
+)
+
+type scheduleHandler struct {
+	s schedule.Service
+}
+
+func NewScheduleHandler(s schedule.Service) *scheduleHandler {
+	return &scheduleHandler{
+		s: s,
+	}
+}
+
+// Get - get the schedule of a dentist
+// @BasePath /api/v1
+// GetDentistSchedule godoc
+// @Summary Get the schedule of a dentist
+// @Schemes
+// @Description get the working hours, breaks and exceptions of a dentist by ID, without working hours the dentist is available all day, every day.
+// @Tags Dentists
+// @Accept json
+// @Produce json
+// @Param id path int true "Dentist ID"
+// @Success 200 {object} domain.DentistSchedule
+// @Failure 400 {object} web.errorResponse
+// @Failure 401 {object} web.errorResponse
+// @Failure 403 {object} web.errorResponse
+// @Failure 404 {object} web.errorResponse
+// @Router /dentists/{id}/schedule [get]
+// @Security OAuth2Application
+func (h *scheduleHandler) Get() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		id, err := strconv.Atoi(ctx.Param("id"))
+		if err != nil {
+			web.BadResponse(ctx, http.StatusBadRequest, "error", "invalid id provided")
+			return
+		}
+		response, err := h.s.GetByDentistID(ctx.Request.Context(), id)
+		if err != nil {
+			web.BadResponse(ctx, http.StatusNotFound, "error", err.Error())
+			return
+		}
+		web.ResponseOK(ctx, http.StatusOK, response)
+	}
+}
+
+// Put - replace the weekly schedule of a dentist
+// @BasePath /api/v1
+// PutDentistSchedule godoc
+// @Summary Replace the weekly schedule of a dentist
+// @Schemes
+// @Description Replace the working hours and breaks of a dentist by ID, exceptions are kept. Weekdays go from 0 (sunday) to 6 (saturday) and times are in format 23:59.
+// @Tags Dentists
+// @Accept json
+// @Produce json
+// @Param id path int true "Dentist ID"
+// @Param body body domain.DentistSchedule true "Body"
+// @Success 200 {object} domain.DentistSchedule
+// @Failure 400 {object} web.errorResponse
+// @Failure 401 {object} web.errorResponse
+// @Failure 403 {object} web.errorResponse
+// @Failure 404 {object} web.errorResponse
+// @Router /dentists/{id}/schedule [put]
+// @Security OAuth2Application
+func (h *scheduleHandler) Put() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		id, err := strconv.Atoi(ctx.Param("id"))
+		if err != nil {
+			web.BadResponse(ctx, http.StatusBadRequest, "error", "invalid id provided")
+			return
+		}
+		var dentistSchedule domain.DentistSchedule
+		if err := ctx.ShouldBindJSON(&dentistSchedule); err != nil {
+			web.BadResponse(ctx, http.StatusBadRequest, "error", "invalid schedule data, please verify field(s): "+err.Error())
+			return
+		}
+		if err := dentistSchedule.Validate(); err != nil {
+			web.BadResponse(ctx, http.StatusBadRequest, "error", err.Error())
+			return
+		}
+		response, err := h.s.UpdateWeekly(ctx.Request.Context(), id, dentistSchedule)
+		if err != nil {
+			web.BadResponse(ctx, http.StatusNotFound, "error", err.Error())
+			return
+		}
+		web.ResponseOK(ctx, http.StatusOK, response)
+	}
+}
+
+// PostException - add an exception to the schedule of a dentist
+// @BasePath /api/v1
+// PostDentistScheduleException godoc
+// @Summary Add an exception to the schedule of a dentist
+// @Schemes
+// @Description Add a period the dentist doesn't attend, like vacations or a day off.
+// @Tags Dentists
+// @Accept json
+// @Produce json
+// @Param id path int true "Dentist ID"
+// @Param body body domain.ScheduleException true "Body"
+// @Success 201 {object} domain.ScheduleException
+// @Failure 400 {object} web.errorResponse
+// @Failure 401 {object} web.errorResponse
+// @Failure 403 {object} web.errorResponse
+// @Failure 404 {object} web.errorResponse
+// @Router /dentists/{id}/schedule/exceptions [post]
+// @Security OAuth2Application
+func (h *scheduleHandler) PostException() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		id, err := strconv.Atoi(ctx.Param("id"))
+		if err != nil {
+			web.BadResponse(ctx, http.StatusBadRequest, "error", "invalid id provided")
+			return
+		}
+		var exception domain.ScheduleException
+		if err := ctx.ShouldBindJSON(&exception); err != nil {
+			web.BadResponse(ctx, http.StatusBadRequest, "error", "invalid exception data, please verify field(s): "+err.Error())
+			return
+		}
+		if err := exception.Validate(); err != nil {
+			web.BadResponse(ctx, http.StatusBadRequest, "error", err.Error())
+			return
+		}
+		response, err := h.s.CreateException(ctx.Request.Context(), id, exception)
+		if err != nil {
+			web.BadResponse(ctx, http.StatusNotFound, "error", err.Error())
+			return
+		}
+		web.ResponseOK(ctx, http.StatusCreated, response)
+	}
+}
+
+// DeleteException - remove an exception from the schedule of a dentist
+// @BasePath /api/v1
+// DeleteDentistScheduleException godoc
+// @Summary Remove an exception from the schedule of a dentist
+// @Schemes
+// @Description Remove an exception from the schedule of a dentist by ID
+// @Tags Dentists
+// @Accept json
+// @Produce json
+// @Param id path int true "Dentist ID"
+// @Param exception_id path int true "Exception ID"
+// @Success 200 {object} web.errorResponse
+// @Failure 400 {object} web.errorResponse
+// @Failure 401 {object} web.errorResponse
+// @Failure 403 {object} web.errorResponse
+// @Failure 404 {object} web.errorResponse
+// @Router /dentists/{id}/schedule/exceptions/{exception_id} [delete]
+// @Security OAuth2Application
+func (h *scheduleHandler) DeleteException() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		id, err := strconv.Atoi(ctx.Param("id"))
+		if err != nil {
+			web.BadResponse(ctx, http.StatusBadRequest, "error", "invalid id provided")
+			return
+		}
+		exceptionID, err := strconv.Atoi(ctx.Param("exception_id"))
+		if err != nil {
+			web.BadResponse(ctx, http.StatusBadRequest, "error", "invalid exception id provided")
+			return
+		}
+		if err := h.s.DeleteException(ctx.Request.Context(), id, exceptionID); err != nil {
+			web.BadResponse(ctx, http.StatusNotFound, "error", err.Error())
+			return
+		}
+		web.DeleteResponse(ctx, http.StatusOK, "schedule exception removed")
+	}
+}
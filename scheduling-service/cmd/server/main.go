@@ -1,22 +1,45 @@
 package main
 
 import (
-	"fmt"
+	"context"
+	"database/sql"
+	"errors"
+	"flag"
 	"github.com/gin-gonic/gin"
-	"github.com/joho/godotenv"
+	"github.com/go-kit/log"
+	"github.com/go-kit/log/level"
 	"github.com/ronilsonalves/GoLang-in-a-spring-cloud-architecture/scheduling-service/cmd/server/handler"
+	"github.com/ronilsonalves/GoLang-in-a-spring-cloud-architecture/scheduling-service/config"
 	"github.com/ronilsonalves/GoLang-in-a-spring-cloud-architecture/scheduling-service/docs"
 	"github.com/ronilsonalves/GoLang-in-a-spring-cloud-architecture/scheduling-service/internal/appointment"
+	"github.com/ronilsonalves/GoLang-in-a-spring-cloud-architecture/scheduling-service/internal/availability"
+	"github.com/ronilsonalves/GoLang-in-a-spring-cloud-architecture/scheduling-service/internal/billing"
+	"github.com/ronilsonalves/GoLang-in-a-spring-cloud-architecture/scheduling-service/internal/closure"
+	"github.com/ronilsonalves/GoLang-in-a-spring-cloud-architecture/scheduling-service/internal/deadletter"
 	"github.com/ronilsonalves/GoLang-in-a-spring-cloud-architecture/scheduling-service/internal/dentist"
+	"github.com/ronilsonalves/GoLang-in-a-spring-cloud-architecture/scheduling-service/internal/outbox"
 	"github.com/ronilsonalves/GoLang-in-a-spring-cloud-architecture/scheduling-service/internal/patient"
+	"github.com/ronilsonalves/GoLang-in-a-spring-cloud-architecture/scheduling-service/internal/schedule"
+	"github.com/ronilsonalves/GoLang-in-a-spring-cloud-architecture/scheduling-service/pkg/amqp"
+	"github.com/ronilsonalves/GoLang-in-a-spring-cloud-architecture/scheduling-service/pkg/broker"
+	"github.com/ronilsonalves/GoLang-in-a-spring-cloud-architecture/scheduling-service/pkg/health"
+	"github.com/ronilsonalves/GoLang-in-a-spring-cloud-architecture/scheduling-service/pkg/kafka"
+	"github.com/ronilsonalves/GoLang-in-a-spring-cloud-architecture/scheduling-service/pkg/logging"
+	"github.com/ronilsonalves/GoLang-in-a-spring-cloud-architecture/scheduling-service/pkg/metrics"
 	"github.com/ronilsonalves/GoLang-in-a-spring-cloud-architecture/scheduling-service/pkg/middleware"
 	"github.com/ronilsonalves/GoLang-in-a-spring-cloud-architecture/scheduling-service/pkg/sd"
 	"github.com/ronilsonalves/GoLang-in-a-spring-cloud-architecture/scheduling-service/pkg/store"
+	"github.com/ronilsonalves/GoLang-in-a-spring-cloud-architecture/scheduling-service/pkg/tracing"
 	swaggerFiles "github.com/swaggo/files"
 	ginSwagger "github.com/swaggo/gin-swagger"
-	"log"
+	"io"
+	"net"
+	"net/http"
 	"os"
 	"os/signal"
+	"strconv"
+	"sync"
+	"syscall"
 	"time"
 	_ "time/tzdata"
 )
@@ -44,84 +67,416 @@ func init() {
 // @name OAuth2Application
 func main() {
 
-	err := godotenv.Load()
-	if err != nil {
-		log.Fatalln("Error loading .env file", err.Error())
+	cfg, args := loadConfig()
+	logger := buildLogger(cfg)
+	if len(args) > 0 && args[0] == config.MigrateCommand {
+		runMigrations(cfg.Database, args[1:], logger)
+		return
 	}
-	eurekaRegister := sd.BuildFargoInstance()
-	eurekaRegister.Register()
 
-	c := make(chan os.Signal)
-	signal.Notify(c, os.Interrupt)
+	shutdownTracing := buildTracing(cfg, logger)
 
 	// DB INIT
-	sqlStore := store.NewSQLStore()
-	apStore := store.NewSQLAp()
+	stores := buildStores(cfg.Database, logger)
+
+	// the issuer is discovered once, at startup, so requests are never held by the discovery
+	jwtVerifier, err := middleware.NewJWTVerifier(context.Background(), middleware.JWTConfig{
+		IssuerURL: cfg.Auth.IssuerURL,
+		Audience:  cfg.Auth.Audience,
+		CAFile:    cfg.Auth.CAFile,
+		ClientID:  cfg.Auth.ClientID,
+	})
+	if err != nil {
+		fatal(logger, "failed to configure the access tokens verification", err)
+	}
+	authz, err := middleware.LoadPolicies(cfg.Auth.PolicyFile)
+	if err != nil {
+		fatal(logger, "failed to load the authorization policies", err)
+	}
+
+	// the orchestrator stops the container with SIGTERM, SIGINT comes from a terminal
+	stopping, stopSignals := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stopSignals()
+
+	// the outbox relay and the consumers run until the service stops, the shutdown waits for them to return
+	publisher := buildPublisher(cfg.Broker, logger)
+	relay := outbox.NewRelay(stores.outbox, publisher, outbox.RelayConfig{
+		Interval:     cfg.Outbox.RelayInterval,
+		BatchSize:    cfg.Outbox.BatchSize,
+		ClaimTimeout: cfg.Outbox.ClaimTimeout,
+		MaxAttempts:  cfg.Outbox.MaxAttempts,
+	})
+	background, stopBackground := context.WithCancel(logging.NewContext(context.Background(), logger))
+	var tasks sync.WaitGroup
+	runInBackground := func(run func(ctx context.Context)) {
+		tasks.Add(1)
+		go func() {
+			defer tasks.Done()
+			run(background)
+		}()
+	}
+	runInBackground(relay.Run)
+
+	billingService := billing.NewService(billing.NewRepository(stores.appointments))
+	deadLetterService := deadletter.NewService(deadletter.NewRepository(stores.deadLetters), publisher)
+	if rabbitMQ := cfg.Broker.RabbitMQ; cfg.Broker.Kind == config.MessageBrokerRabbitMQ {
+		consumerConfig := amqp.ConsumerConfig{
+			URL:                rabbitMQ.URL,
+			Exchange:           rabbitMQ.InvoiceExchange,
+			Queue:              rabbitMQ.InvoiceQueue,
+			RoutingKeys:        billing.RoutingKeys,
+			Prefetch:           rabbitMQ.Prefetch,
+			MaxRetries:         rabbitMQ.ConsumerRetries,
+			RetryDelay:         rabbitMQ.ConsumerRetryDelay,
+			ConfirmTimeout:     rabbitMQ.ConfirmTimeout,
+			DeadLetterExchange: rabbitMQ.DeadLetterExchange,
+			DeadLetterQueue:    rabbitMQ.DeadLetterQueue,
+		}
+		runInBackground(func(ctx context.Context) {
+			amqp.NewConsumer(consumerConfig).Run(ctx, billingService.HandleInvoiceEvent)
+		})
+		runInBackground(func(ctx context.Context) {
+			amqp.NewDeadLetterCollector(consumerConfig).Run(ctx, deadLetterService.Collect)
+		})
+	}
+
 	//Handlers INIT
-	appRepo := appointment.NewRepository(apStore)
+	appRepo := appointment.NewRepository(stores.appointments)
 	appService := appointment.NewService(appRepo)
 	appHandler := handler.NewAppointmentHandler(appService)
 
-	dentistRepo := dentist.NewRepository(sqlStore)
+	dentistRepo := dentist.NewRepository(stores.dentists)
 	dentistService := dentist.NewService(dentistRepo)
 	dentistHandler := handler.NewDentistHandler(dentistService)
 
-	patientRepo := patient.NewRepository(sqlStore)
+	scheduleRepo := schedule.NewRepository(stores.schedules)
+	scheduleService := schedule.NewService(scheduleRepo)
+	scheduleHandler := handler.NewScheduleHandler(scheduleService)
+
+	closureRepo := closure.NewRepository(stores.closures)
+	closureService := closure.NewService(closureRepo)
+	closureHandler := handler.NewClosureHandler(closureService)
+
+	availabilityRepo := availability.NewRepository(stores.dentists, stores.appointments, stores.schedules, stores.closures)
+	availabilityService := availability.NewService(availabilityRepo)
+	availabilityHandler := handler.NewAvailabilityHandler(availabilityService)
+
+	patientRepo := patient.NewRepository(stores.patients)
 	patientService := patient.NewService(patientRepo)
 	patientHandler := handler.NewPatientHandler(patientService)
 
+	deadLetterHandler := handler.NewDeadLetterHandler(deadLetterService)
+
+	indicator := buildHealthIndicator(cfg, stores, publisher, jwtVerifier)
+	healthHandler := handler.NewHealthHandler(indicator)
+
 	r := gin.New()
-	r.Use(gin.Recovery(), gin.Logger())
+	r.Use(middleware.Recovery(), middleware.Tracing(), middleware.RequestID(logger), middleware.AccessLog(),
+		middleware.Metrics(), middleware.Timeout(cfg.Server.RequestTimeout))
 
-	docs.SwaggerInfo.Host = os.Getenv("HOST") + ":" + os.Getenv("PORT")
-	docs.SwaggerInfo.BasePath = os.Getenv("BASE_PATH")
+	address := net.JoinHostPort(cfg.Server.Host, strconv.Itoa(cfg.Server.Port))
+	docs.SwaggerInfo.Host = address
+	docs.SwaggerInfo.BasePath = cfg.Server.BasePath
 	r.GET("/swagger/*any",
 		ginSwagger.WrapHandler(swaggerFiles.Handler))
 
-	r.Use(middleware.IsAuthorizedJWT())
+	// the health and metrics routes are registered before the authentication, Eureka, the gateway and Prometheus call
+	// them without a token
+	r.GET("/health", healthHandler.Health())
+	r.GET("/health/liveness", healthHandler.Liveness())
+	r.GET("/health/readiness", healthHandler.Readiness())
+	r.GET("/status", healthHandler.Status())
+	r.GET("/metrics", gin.WrapH(metrics.Handler()))
+
+	r.Use(middleware.IsAuthorizedJWT(jwtVerifier))
+
+	// Each route requires a permission, the roles allowed to it are declared at the policies file.
 
 	api := r.Group("/api/v1")
 	{
 		appointments := api.Group("/appointments")
 		{
-			appointments.GET("", appHandler.GetAll())
-			appointments.GET(":id", appHandler.GetByID())
-			appointments.GET("/patient/:identity_number", appHandler.GetAllByIdentityNumber())
-			appointments.GET("/dentist/:license_number", appHandler.GetAllByLicenseNumber())
-			appointments.POST("", appHandler.Post())
-			appointments.PUT(":id", appHandler.Put())
-			appointments.PATCH(":id", appHandler.Patch())
-			appointments.DELETE(":id", appHandler.Delete())
+			appointments.GET("", authz.Require("appointments:read"), appHandler.GetAll())
+			appointments.GET(":id", authz.Require("appointments:read"), appHandler.GetByID())
+			appointments.GET("/patient/:identity_number", authz.Require("appointments:read"), appHandler.GetAllByIdentityNumber())
+			appointments.GET("/dentist/:license_number", authz.Require("appointments:read"), appHandler.GetAllByLicenseNumber())
+			appointments.POST("", authz.Require("appointments:write"), appHandler.Post())
+			appointments.PUT(":id", authz.Require("appointments:write"), appHandler.Put())
+			appointments.PATCH(":id", authz.Require("appointments:write"), appHandler.Patch())
+			appointments.POST(":id/confirm", authz.Require("appointments:status"), appHandler.Confirm())
+			appointments.POST(":id/check-in", authz.Require("appointments:status"), appHandler.CheckIn())
+			appointments.POST(":id/complete", authz.Require("appointments:status"), appHandler.Complete())
+			appointments.POST(":id/cancel", authz.Require("appointments:status"), appHandler.Cancel())
+			appointments.POST(":id/no-show", authz.Require("appointments:status"), appHandler.NoShow())
+			appointments.DELETE(":id", authz.Require("appointments:delete"), appHandler.Delete())
 		}
 		dentists := api.Group("/dentists")
 		{
-			dentists.GET("", dentistHandler.GetAll())
-			dentists.GET(":id", dentistHandler.GetByID())
-			dentists.POST("", dentistHandler.Post())
-			dentists.PUT(":id", dentistHandler.Put())
-			dentists.PATCH(":id", dentistHandler.Patch())
-			dentists.DELETE(":id", dentistHandler.Delete())
+			dentists.GET("", authz.Require("dentists:read"), dentistHandler.GetAll())
+			dentists.GET(":id", authz.Require("dentists:read"), dentistHandler.GetByID())
+			dentists.POST("", authz.Require("dentists:write"), dentistHandler.Post())
+			dentists.PUT(":id", authz.Require("dentists:write"), dentistHandler.Put())
+			dentists.PATCH(":id", authz.Require("dentists:write"), dentistHandler.Patch())
+			dentists.DELETE(":id", authz.Require("dentists:delete"), dentistHandler.Delete())
+			dentists.GET(":id/schedule", authz.Require("dentists:read"), scheduleHandler.Get())
+			dentists.PUT(":id/schedule", authz.Require("schedules:write"), scheduleHandler.Put())
+			dentists.POST(":id/schedule/exceptions", authz.Require("schedules:write"), scheduleHandler.PostException())
+			dentists.DELETE(":id/schedule/exceptions/:exception_id", authz.Require("schedules:write"), scheduleHandler.DeleteException())
 		}
 		patients := api.Group("/patients")
 		{
-			patients.GET("", patientHandler.GetAll())
-			patients.GET(":id", patientHandler.GetByID())
-			patients.POST("", patientHandler.Post())
-			patients.PUT(":id", patientHandler.Put())
-			patients.PATCH(":id", patientHandler.Patch())
-			patients.DELETE(":id", patientHandler.Delete())
+			patients.GET("", authz.Require("patients:read"), patientHandler.GetAll())
+			patients.GET(":id", authz.Require("patients:read"), patientHandler.GetByID())
+			patients.POST("", authz.Require("patients:write"), patientHandler.Post())
+			patients.PUT(":id", authz.Require("patients:write"), patientHandler.Put())
+			patients.PATCH(":id", authz.Require("patients:write"), patientHandler.Patch())
+			patients.DELETE(":id", authz.Require("patients:delete"), patientHandler.Delete())
+		}
+		api.GET("/availability", authz.Require("availability:read"), availabilityHandler.Get())
+		closures := api.Group("/closures")
+		{
+			closures.GET("", authz.Require("closures:read"), closureHandler.GetAll())
+			closures.POST("", authz.Require("closures:write"), closureHandler.Post())
+			closures.DELETE(":id", authz.Require("closures:write"), closureHandler.Delete())
+		}
+		deadLetters := api.Group("/admin/dead-letters")
+		{
+			deadLetters.GET("", authz.Require("dead-letters:read"), deadLetterHandler.GetAll())
+			deadLetters.GET(":id", authz.Require("dead-letters:read"), deadLetterHandler.GetByID())
+			deadLetters.POST(":id/replay", authz.Require("dead-letters:write"), deadLetterHandler.Replay())
+			deadLetters.DELETE(":id", authz.Require("dead-letters:write"), deadLetterHandler.Delete())
 		}
 	}
+	if err := authz.Validate(); err != nil {
+		fatal(logger, "failed to register the routes", err)
+	}
+
+	// the instance is registered once its routes are ready to be served
+	eurekaRegister := sd.BuildFargoInstance(log.With(logger, "component", "registrar"), cfg.Eureka.ServerURL, cfg.Server.Port, cfg.Server.BasePath)
+	eurekaRegister.Register()
 
+	server := &http.Server{
+		Addr:    address,
+		Handler: r,
+	}
 	go func() {
-		select {
-		case signal := <-c:
-			_ = signal
-			time.Sleep(4 * time.Second)
-			eurekaRegister.Deregister()
-			os.Exit(1)
+		if err := server.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			fatal(logger, "failed to serve the API", err)
 		}
 	}()
 
-	r.Run(fmt.Sprintf("%s", os.Getenv("HOST")) + fmt.Sprintf(":%s", os.Getenv("PORT")))
+	<-stopping.Done()
+	stopSignals()
+	level.Info(logger).Log("msg", "shutting down, a second signal stops the service at once")
+
+	// readiness turns down and the instance leaves Eureka first, the gateway keeps sending requests until its
+	// registry is refreshed, so they're still served during the drain period
+	indicator.ShuttingDown()
+	eurekaRegister.Deregister()
+	time.Sleep(cfg.Server.ShutdownDrainPeriod)
+
+	// each stage has its own SHUTDOWN_TIMEOUT, so a slow drain of the requests doesn't leave the relay an expired
+	// context and the pending outbox messages behind
+	stage := func() (context.Context, context.CancelFunc) {
+		return context.WithTimeout(logging.NewContext(context.Background(), logger), cfg.Server.ShutdownTimeout)
+	}
+	clean := true
+	serverCtx, cancelServer := stage()
+	defer cancelServer()
+	if err := server.Shutdown(serverCtx); err != nil {
+		level.Error(logger).Log("msg", "failed to finish the requests in flight", "err", err)
+		clean = false
+	}
+
+	// the consumers finish the message at hand, then the events recorded by the last requests are published
+	stopBackground()
+	tasks.Wait()
+	relayCtx, cancelRelay := stage()
+	defer cancelRelay()
+	if err := relay.RelayPending(relayCtx); err != nil {
+		level.Error(logger).Log("msg", "failed to publish the pending outbox messages, they're published at the next start", "err", err)
+		clean = false
+	}
+	if closer, ok := publisher.(io.Closer); ok {
+		if err := closer.Close(); err != nil {
+			level.Error(logger).Log("msg", "failed to close the publisher", "err", err)
+			clean = false
+		}
+	}
+	if stores.database != nil {
+		if err := stores.database.Close(); err != nil {
+			level.Error(logger).Log("msg", "failed to close the database", "err", err)
+			clean = false
+		}
+	}
+	tracingCtx, cancelTracing := stage()
+	defer cancelTracing()
+	if err := shutdownTracing(tracingCtx); err != nil {
+		level.Error(logger).Log("msg", "failed to export the last spans", "err", err)
+		clean = false
+	}
+	if !clean {
+		os.Exit(1)
+	}
+	level.Info(logger).Log("msg", "shut down")
+}
+
+// loadConfig - load the settings of the service and the command line arguments left after the flags, listing every
+// setting missing or invalid before exiting when there's one.
+func loadConfig() (config.Config, []string) {
+	cfg, args, err := config.Load(os.Args[1:])
+	if errors.Is(err, flag.ErrHelp) {
+		os.Exit(0)
+	}
+	var invalid *config.ValidationError
+	if errors.As(err, &invalid) {
+		level.Error(log.NewJSONLogger(os.Stderr)).Log("msg", "invalid configuration", "problems", invalid.Problems)
+		os.Exit(2)
+	}
+	if err != nil {
+		// the flag set already printed the error and the usage
+		os.Exit(2)
+	}
+	return cfg, args
+}
+
+// buildLogger - initialize the logger of the service in the format and level set by LOG_FORMAT and LOG_LEVEL, the
+// standard log package writes through it as well.
+func buildLogger(cfg config.Config) log.Logger {
+	logger, err := logging.New(os.Stdout, cfg.Logging.Format, cfg.Logging.Level, cfg.ServiceName)
+	if err != nil {
+		fatal(log.NewLogfmtLogger(os.Stderr), "invalid logging settings", err)
+	}
+	logging.RedirectStandardLog(logger)
+	return logger
+}
+
+// fatal - log why the service can't start and exit.
+func fatal(logger log.Logger, msg string, err error) {
+	keyvals := []interface{}{"msg", msg}
+	if err != nil {
+		keyvals = append(keyvals, "err", err)
+	}
+	level.Error(logger).Log(keyvals...)
+	os.Exit(1)
+}
+
+// buildTracing - export the spans to the OpenTelemetry collector at OTEL_EXPORTER_OTLP_ENDPOINT, they aren't recorded
+// when it isn't set but the trace context of the requests is still passed on to the messages.
+func buildTracing(cfg config.Config, logger log.Logger) func(ctx context.Context) error {
+	if cfg.Tracing.OTLPEndpoint == "" {
+		return tracing.Setup(cfg.ServiceName, nil, cfg.Tracing.SampleRatio)
+	}
+	timeout := time.Duration(cfg.Tracing.OTLPTimeoutMillis) * time.Millisecond
+	exporter, err := tracing.NewOTLPExporter(context.Background(), cfg.Tracing.OTLPEndpoint, timeout)
+	if err != nil {
+		fatal(logger, "failed to configure the spans export", err)
+	}
+	return tracing.Setup(cfg.ServiceName, exporter, cfg.Tracing.SampleRatio)
+}
+
+// eventPublisher - publishes the outbox events and replays the dead letters.
+type eventPublisher interface {
+	outbox.Publisher
+	deadletter.Replayer
+}
+
+// buildPublisher - initialize the publisher of the outbox events for the broker selected by MESSAGE_BROKER, RabbitMQ
+// by default.
+func buildPublisher(cfg config.BrokerConfig, logger log.Logger) eventPublisher {
+	switch cfg.Kind {
+	case config.MessageBrokerMemory:
+		return broker.NewMemoryPublisher()
+	case config.MessageBrokerKafka:
+		return kafka.NewPublisher(kafka.PublisherConfig{
+			Brokers:      cfg.Kafka.Brokers,
+			Topic:        cfg.Kafka.Topic,
+			MaxRetries:   cfg.Kafka.PublishRetries,
+			WriteTimeout: cfg.Kafka.WriteTimeout,
+		})
+	case config.MessageBrokerRabbitMQ:
+		rabbitMQ := cfg.RabbitMQ
+		format, err := amqp.ParseMessageFormat(rabbitMQ.MessageFormat)
+		if err != nil {
+			fatal(logger, "invalid RABBIT_MQ_MESSAGE_FORMAT", err)
+		}
+		return amqp.NewPublisher(amqp.PublisherConfig{
+			URL:                rabbitMQ.URL,
+			Exchange:           rabbitMQ.Exchange,
+			Format:             format,
+			Source:             rabbitMQ.CloudEventsSource,
+			DeadLetterExchange: rabbitMQ.DeadLetterExchange,
+			DeadLetterQueue:    rabbitMQ.DeadLetterQueue,
+			PoolSize:           rabbitMQ.ChannelPool,
+			MaxRetries:         rabbitMQ.PublishRetries,
+			RetryBackoff:       rabbitMQ.RetryBackoff,
+			ConfirmTimeout:     rabbitMQ.ConfirmTimeout,
+		})
+	default:
+		panic("unknown MESSAGE_BROKER: " + cfg.Kind)
+	}
+}
+
+// buildHealthIndicator - initialize the readiness checks of the database, the broker and the OIDC provider, each one
+// only when the service depends on it.
+func buildHealthIndicator(cfg config.Config, stores stores, publisher eventPublisher, jwtVerifier *middleware.JWTVerifier) *health.Indicator {
+	indicator := health.NewIndicator(cfg.Server.HealthCheckTimeout)
+	if stores.database != nil {
+		indicator.Register("db", stores.database.PingContext)
+	}
+	if checker, ok := publisher.(health.Checker); ok {
+		indicator.Register(cfg.Broker.Kind, checker.Check)
+	}
+	indicator.Register("oidc", jwtVerifier.Check)
+	return indicator
+}
+
+// stores - every store used by the service, built for the driver selected.
+type stores struct {
+	dentists     store.DentistStore
+	patients     store.PatientStore
+	appointments store.ApStore
+	schedules    store.ScheduleStore
+	closures     store.ClosureStore
+	outbox       store.OutboxStore
+	deadLetters  store.DeadLetterStore
+	// database - the connection pool of the MySQL stores, nil with the memory driver.
+	database *sql.DB
+}
+
+// buildStores - initialize the stores for the driver selected by STORE_DRIVER, MySQL by default.
+func buildStores(cfg config.DatabaseConfig, logger log.Logger) stores {
+	switch cfg.Driver {
+	case config.StoreDriverMemory:
+		messages := store.NewMemoryOutbox()
+		dentists := store.NewMemoryDentist(messages)
+		patients := store.NewMemoryPatient(messages)
+		schedules := store.NewMemorySchedule(dentists)
+		closures := store.NewMemoryClosure()
+		return stores{
+			dentists:     dentists,
+			patients:     patients,
+			appointments: store.NewMemoryAp(dentists, patients, schedules, closures, messages),
+			schedules:    schedules,
+			closures:     closures,
+			outbox:       messages,
+			deadLetters:  store.NewMemoryDeadLetter(),
+		}
+	case config.StoreDriverMySQL:
+		database := store.NewSQLDatabase(cfg)
+		prepareSchema(database, cfg.MigrateOnStart, logger)
+		metrics.RegisterDB(database, "mysql")
+		return stores{
+			dentists:     store.NewSQLDentist(database),
+			patients:     store.NewSQLPatient(database),
+			appointments: store.NewSQLAp(database),
+			schedules:    store.NewSQLSchedule(database),
+			closures:     store.NewSQLClosure(database),
+			outbox:       store.NewSQLOutbox(database),
+			deadLetters:  store.NewSQLDeadLetter(database),
+			database:     database,
+		}
+	default:
+		panic("unknown STORE_DRIVER: " + cfg.Driver)
+	}
 }
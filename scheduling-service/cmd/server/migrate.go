@@ -0,0 +1,66 @@
+package main
+
+import (
+	"database/sql"
+	"fmt"
+	"github.com/go-kit/log"
+	"github.com/ronilsonalves/GoLang-in-a-spring-cloud-architecture/scheduling-service/config"
+	"github.com/ronilsonalves/GoLang-in-a-spring-cloud-architecture/scheduling-service/migrations"
+	"github.com/ronilsonalves/GoLang-in-a-spring-cloud-architecture/scheduling-service/pkg/migration"
+	"github.com/ronilsonalves/GoLang-in-a-spring-cloud-architecture/scheduling-service/pkg/store"
+)
+
+// runMigrations - handle the migrate subcommand: migrate up|down|status
+func runMigrations(cfg config.DatabaseConfig, args []string, logger log.Logger) {
+	if len(args) != 1 {
+		fatal(logger, "usage: server migrate up|down|status", nil)
+	}
+	if cfg.Driver != config.StoreDriverMySQL {
+		fatal(logger, "migrations are only available for the mysql store driver", nil)
+	}
+
+	database := store.NewSQLDatabase(cfg)
+	defer database.Close()
+	migrator, err := migration.NewMigrator(database, migrations.FS, logger)
+	if err != nil {
+		fatal(logger, "failed to load migrations", err)
+	}
+
+	switch args[0] {
+	case "up":
+		err = migrator.Up()
+	case "down":
+		err = migrator.Down()
+	case "status":
+		var statuses []migration.Status
+		statuses, err = migrator.Status()
+		for _, status := range statuses {
+			appliedAt := "pending"
+			if status.Applied {
+				appliedAt = "applied at " + status.AppliedAt.Format("02/01/2006 15:04:05")
+			}
+			fmt.Printf("%04d_%s\t%s\n", status.Version, status.Name, appliedAt)
+		}
+	default:
+		fatal(logger, "unknown migrate command "+args[0], nil)
+	}
+	if err != nil {
+		fatal(logger, "failed to migrate the database", err)
+	}
+}
+
+// prepareSchema - apply pending migrations when DB_MIGRATE_ON_START is set, otherwise refuse to start with a schema behind.
+func prepareSchema(database *sql.DB, migrateOnStart bool, logger log.Logger) {
+	migrator, err := migration.NewMigrator(database, migrations.FS, logger)
+	if err != nil {
+		fatal(logger, "failed to load migrations", err)
+	}
+	if migrateOnStart {
+		err = migrator.Up()
+	} else {
+		err = migrator.EnsureUpToDate()
+	}
+	if err != nil {
+		fatal(logger, "failed to migrate the database", err)
+	}
+}
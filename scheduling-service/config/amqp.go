@@ -0,0 +1,79 @@
+package config
+
+import "time"
+
+// RabbitMQConfig - the settings of the RabbitMQ publisher and of the consumers of the invoice events.
+type RabbitMQConfig struct {
+	// URL - the RabbitMQ connection url, required when RabbitMQ is the broker.
+	URL string `yaml:"url" env:"RABBIT_MQ_URL_CONN"`
+	// Exchange - the topic exchange the events are published to.
+	Exchange string `yaml:"exchange" env:"RABBIT_MQ_EXCHANGE"`
+	// MessageFormat - how the events are written into the messages: envelope, cloudevents-binary or
+	// cloudevents-structured. Every consumer of the events must read the format chosen, the invoice service reads the
+	// three of them.
+	MessageFormat string `yaml:"messageFormat" env:"RABBIT_MQ_MESSAGE_FORMAT"`
+	// CloudEventsSource - the source attribute of the events published in a CloudEvents format.
+	CloudEventsSource string `yaml:"cloudEventsSource" env:"CLOUDEVENTS_SOURCE"`
+	// ChannelPool - the channels kept open for publishing.
+	ChannelPool int `yaml:"channelPool" env:"RABBIT_MQ_CHANNEL_POOL"`
+	// PublishRetries - the retries of a message the broker didn't confirm, reconnecting when needed.
+	PublishRetries int `yaml:"publishRetries" env:"RABBIT_MQ_PUBLISH_RETRIES"`
+	// RetryBackoff - the wait before the first retry, doubled at each retry.
+	RetryBackoff time.Duration `yaml:"retryBackoff" env:"RABBIT_MQ_RETRY_BACKOFF"`
+	// ConfirmTimeout - how long the broker has to confirm a message.
+	ConfirmTimeout time.Duration `yaml:"confirmTimeout" env:"RABBIT_MQ_CONFIRM_TIMEOUT"`
+	// Prefetch - the messages delivered to a consumer before it acknowledges them.
+	Prefetch int `yaml:"prefetch" env:"RABBIT_MQ_PREFETCH"`
+	// ConsumerRetryDelay - how long a message that failed waits at the retry queue before it's delivered again, and
+	// the wait before reconnecting.
+	ConsumerRetryDelay time.Duration `yaml:"consumerRetryDelay" env:"RABBIT_MQ_CONSUMER_RETRY_DELAY"`
+	// ConsumerRetries - the times a message that failed is delivered again before being parked.
+	ConsumerRetries int `yaml:"consumerRetries" env:"RABBIT_MQ_CONSUMER_RETRIES"`
+	// DeadLetterExchange - where the messages that can't be handled are parked.
+	DeadLetterExchange string `yaml:"deadLetterExchange" env:"RABBIT_MQ_DEAD_LETTER_EXCHANGE"`
+	// DeadLetterQueue - the queue collecting the parked messages, moved to the database to be replayed or discarded.
+	DeadLetterQueue string `yaml:"deadLetterQueue" env:"RABBIT_MQ_DEAD_LETTER_QUEUE"`
+	// InvoiceExchange - the topic exchange the invoice service publishes its events to.
+	InvoiceExchange string `yaml:"invoiceExchange" env:"INVOICE_EVENTS_EXCHANGE"`
+	// InvoiceQueue - the queue of this service receiving the invoice events.
+	InvoiceQueue string `yaml:"invoiceQueue" env:"INVOICE_EVENTS_QUEUE"`
+}
+
+// OutboxConfig - how the outbox relay publishes the events recorded.
+type OutboxConfig struct {
+	// RelayInterval - how often the outbox is polled for messages to publish.
+	RelayInterval time.Duration `yaml:"relayInterval" env:"OUTBOX_RELAY_INTERVAL"`
+	// BatchSize - the messages read from the outbox at a time.
+	BatchSize int `yaml:"batchSize" env:"OUTBOX_BATCH_SIZE"`
+	// ClaimTimeout - how long the messages read are held from the relays of other instances, it must outlast their
+	// publishing. A message that failed is retried once it expires.
+	ClaimTimeout time.Duration `yaml:"claimTimeout" env:"OUTBOX_CLAIM_TIMEOUT"`
+	// MaxAttempts - the attempts to publish a message before it's parked, so the newer events of its entity go on.
+	MaxAttempts int `yaml:"maxAttempts" env:"OUTBOX_MAX_ATTEMPTS"`
+}
+
+func (c RabbitMQConfig) validate(v *validator) {
+	if c.URL == "" {
+		v.add("RABBIT_MQ_URL_CONN", "is required when MESSAGE_BROKER is rabbitmq")
+	}
+	v.required("RABBIT_MQ_EXCHANGE", c.Exchange)
+	v.oneOf("RABBIT_MQ_MESSAGE_FORMAT", c.MessageFormat, "envelope", "cloudevents-binary", "cloudevents-structured")
+	v.positive("RABBIT_MQ_CHANNEL_POOL", c.ChannelPool)
+	v.notNegative("RABBIT_MQ_PUBLISH_RETRIES", c.PublishRetries)
+	v.positiveDuration("RABBIT_MQ_RETRY_BACKOFF", c.RetryBackoff)
+	v.positiveDuration("RABBIT_MQ_CONFIRM_TIMEOUT", c.ConfirmTimeout)
+	v.positive("RABBIT_MQ_PREFETCH", c.Prefetch)
+	v.positiveDuration("RABBIT_MQ_CONSUMER_RETRY_DELAY", c.ConsumerRetryDelay)
+	v.notNegative("RABBIT_MQ_CONSUMER_RETRIES", c.ConsumerRetries)
+	v.required("RABBIT_MQ_DEAD_LETTER_EXCHANGE", c.DeadLetterExchange)
+	v.required("RABBIT_MQ_DEAD_LETTER_QUEUE", c.DeadLetterQueue)
+	v.required("INVOICE_EVENTS_EXCHANGE", c.InvoiceExchange)
+	v.required("INVOICE_EVENTS_QUEUE", c.InvoiceQueue)
+}
+
+func (c OutboxConfig) validate(v *validator) {
+	v.positiveDuration("OUTBOX_RELAY_INTERVAL", c.RelayInterval)
+	v.positive("OUTBOX_BATCH_SIZE", c.BatchSize)
+	v.positiveDuration("OUTBOX_CLAIM_TIMEOUT", c.ClaimTimeout)
+	v.positive("OUTBOX_MAX_ATTEMPTS", c.MaxAttempts)
+}
@@ -0,0 +1,23 @@
+package config
+
+// AuthConfig - how the access tokens are verified and which roles each route allows.
+type AuthConfig struct {
+	// IssuerURL - the realm issuing the access tokens, its discovery document is read once at startup.
+	IssuerURL string `yaml:"issuerUrl" env:"REALM_CONFIG_URL"`
+	// ClientID - the client whose roles are granted to the users besides their realm roles.
+	ClientID string `yaml:"clientId" env:"CLIENT_ID"`
+	// Audience - the audience the access tokens must have, the client id by default.
+	Audience string `yaml:"audience" env:"OIDC_AUDIENCE"`
+	// CAFile - a PEM bundle trusted besides the system roots when calling the issuer, for realms with private CAs.
+	CAFile string `yaml:"caFile" env:"OIDC_CA_FILE"`
+	// PolicyFile - the JSON file mapping each permission of the routes to the roles allowed.
+	PolicyFile string `yaml:"policyFile" env:"AUTHZ_POLICY_FILE"`
+	// Token - the token the static token authentication requires at the SECRET_TOKEN header.
+	Token string `yaml:"token" env:"TOKEN"`
+}
+
+func (c AuthConfig) validate(v *validator) {
+	v.required("REALM_CONFIG_URL", c.IssuerURL)
+	v.required("CLIENT_ID", c.ClientID)
+	v.required("AUTHZ_POLICY_FILE", c.PolicyFile)
+}
@@ -0,0 +1,47 @@
+package config
+
+import "time"
+
+const (
+	// MessageBrokerRabbitMQ - publish the events to RabbitMQ and consume the invoice events from it, the default broker.
+	MessageBrokerRabbitMQ = "rabbitmq"
+	// MessageBrokerKafka - publish the events to a Kafka topic, the invoice events aren't consumed.
+	MessageBrokerKafka = "kafka"
+	// MessageBrokerMemory - keep the events published in memory, used for tests and local development without a broker.
+	MessageBrokerMemory = "memory"
+)
+
+// BrokerConfig - the broker the events are published to and the settings of each one.
+type BrokerConfig struct {
+	// Kind - the broker the events are published to: rabbitmq, kafka or memory.
+	Kind     string         `yaml:"kind" env:"MESSAGE_BROKER"`
+	RabbitMQ RabbitMQConfig `yaml:"rabbitmq"`
+	Kafka    KafkaConfig    `yaml:"kafka"`
+}
+
+// KafkaConfig - the settings of the Kafka publisher.
+type KafkaConfig struct {
+	// Brokers - the Kafka brokers the partitions of the topic are discovered from.
+	Brokers []string `yaml:"brokers" env:"KAFKA_BROKERS"`
+	// Topic - the topic the events are written to.
+	Topic string `yaml:"topic" env:"KAFKA_TOPIC"`
+	// PublishRetries - the retries of a message the brokers didn't acknowledge.
+	PublishRetries int `yaml:"publishRetries" env:"KAFKA_PUBLISH_RETRIES"`
+	// WriteTimeout - how long the brokers have to acknowledge a message.
+	WriteTimeout time.Duration `yaml:"writeTimeout" env:"KAFKA_WRITE_TIMEOUT"`
+}
+
+func (c BrokerConfig) validate(v *validator) {
+	v.oneOf("MESSAGE_BROKER", c.Kind, MessageBrokerRabbitMQ, MessageBrokerKafka, MessageBrokerMemory)
+	switch c.Kind {
+	case MessageBrokerRabbitMQ:
+		c.RabbitMQ.validate(v)
+	case MessageBrokerKafka:
+		if len(c.Kafka.Brokers) == 0 {
+			v.add("KAFKA_BROKERS", "is required when MESSAGE_BROKER is kafka")
+		}
+		v.required("KAFKA_TOPIC", c.Kafka.Topic)
+		v.notNegative("KAFKA_PUBLISH_RETRIES", c.Kafka.PublishRetries)
+		v.positiveDuration("KAFKA_WRITE_TIMEOUT", c.Kafka.WriteTimeout)
+	}
+}
@@ -0,0 +1,292 @@
+package config
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"github.com/joho/godotenv"
+	"gopkg.in/yaml.v3"
+	"io"
+	"io/fs"
+	"os"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Config - every setting of the service. Load reads them from the defaults, an optional YAML file, the env variables,
+// a .env file included, and the command line flags, each source overriding the previous one. Every setting is tagged
+// with its YAML key and its env variable, its flag is the env variable in lower case with dashes, e.g. -rabbit-mq-url-conn.
+type Config struct {
+	// ServiceName - the service the spans and the log lines are reported by.
+	ServiceName string         `yaml:"serviceName" env:"OTEL_SERVICE_NAME"`
+	Server      ServerConfig   `yaml:"server"`
+	Database    DatabaseConfig `yaml:"database"`
+	Auth        AuthConfig     `yaml:"auth"`
+	Broker      BrokerConfig   `yaml:"broker"`
+	Outbox      OutboxConfig   `yaml:"outbox"`
+	Eureka      EurekaConfig   `yaml:"eureka"`
+	Tracing     TracingConfig  `yaml:"tracing"`
+	Logging     LoggingConfig  `yaml:"logging"`
+}
+
+// ServerConfig - where the API listens and how long its requests have.
+type ServerConfig struct {
+	// Host - the address the API listens at, every interface when empty.
+	Host string `yaml:"host" env:"HOST"`
+	// Port - the port the API listens at, registered at Eureka.
+	Port int `yaml:"port" env:"PORT"`
+	// BasePath - the path the API is served under, shown at the swagger docs and at Eureka.
+	BasePath string `yaml:"basePath" env:"BASE_PATH"`
+	// RequestTimeout - the deadline of each request, its database and authorization calls are cancelled once it passes.
+	RequestTimeout time.Duration `yaml:"requestTimeout" env:"REQUEST_TIMEOUT"`
+	// HealthCheckTimeout - how long each dependency has to answer the readiness checks.
+	HealthCheckTimeout time.Duration `yaml:"healthCheckTimeout" env:"HEALTH_CHECK_TIMEOUT"`
+	// ShutdownDrainPeriod - how long the requests keep being served after the instance leaves Eureka, until the
+	// gateway refreshes its registry.
+	ShutdownDrainPeriod time.Duration `yaml:"shutdownDrainPeriod" env:"SHUTDOWN_DRAIN_PERIOD"`
+	// ShutdownTimeout - how long each stage of the shutdown has once the drain period is over: the requests in flight,
+	// the pending outbox messages and the export of the last spans each get their own.
+	ShutdownTimeout time.Duration `yaml:"shutdownTimeout" env:"SHUTDOWN_TIMEOUT"`
+}
+
+// EurekaConfig - the registry the instance registers at.
+type EurekaConfig struct {
+	// ServerURL - the Eureka server, e.g. http://localhost:8761/eureka.
+	ServerURL string `yaml:"serverUrl" env:"EUREKA_SERVER_URL"`
+}
+
+// Default - the settings used when no source sets them.
+func Default() Config {
+	return Config{
+		ServiceName: "scheduling-service",
+		Server: ServerConfig{
+			Port:                9000,
+			RequestTimeout:      30 * time.Second,
+			HealthCheckTimeout:  3 * time.Second,
+			ShutdownDrainPeriod: 4 * time.Second,
+			ShutdownTimeout:     30 * time.Second,
+		},
+		Database: DatabaseConfig{
+			Driver: StoreDriverMySQL,
+			Port:   3306,
+		},
+		Auth: AuthConfig{
+			PolicyFile: "config/policies.json",
+		},
+		Broker: BrokerConfig{
+			Kind: MessageBrokerRabbitMQ,
+			RabbitMQ: RabbitMQConfig{
+				Exchange:           "scheduling.events",
+				MessageFormat:      "envelope",
+				CloudEventsSource:  "/scheduling-service",
+				ChannelPool:        4,
+				PublishRetries:     3,
+				RetryBackoff:       200 * time.Millisecond,
+				ConfirmTimeout:     5 * time.Second,
+				Prefetch:           10,
+				ConsumerRetryDelay: 5 * time.Second,
+				ConsumerRetries:    3,
+				DeadLetterExchange: "scheduling.dlx",
+				DeadLetterQueue:    "scheduling.dlq",
+				InvoiceExchange:    "invoice.events",
+				InvoiceQueue:       "scheduling-service.invoices",
+			},
+			Kafka: KafkaConfig{
+				Topic:          "scheduling.events",
+				PublishRetries: 3,
+				WriteTimeout:   10 * time.Second,
+			},
+		},
+		Outbox: OutboxConfig{
+			RelayInterval: time.Second,
+			BatchSize:     100,
+			ClaimTimeout:  time.Minute,
+			MaxAttempts:   10,
+		},
+		Tracing: TracingConfig{
+			OTLPTimeoutMillis: 10000,
+			SampleRatio:       1,
+		},
+		Logging: LoggingConfig{
+			Format: "json",
+			Level:  "info",
+		},
+	}
+}
+
+// MigrateCommand - the subcommand applying the database migrations, it runs with the database settings only.
+const MigrateCommand = "migrate"
+
+// Load - load the settings from the defaults, the YAML file set by the -config flag or CONFIG_FILE, the env variables,
+// loading a .env file first when there's one, and the flags found at args, the command line without the program name.
+// The arguments left after the flags are returned, e.g. the migrate subcommand. The invalid and missing settings are
+// reported together as a ValidationError, only the database and logging ones for the migrate subcommand.
+func Load(args []string) (Config, []string, error) {
+	cfg := Default()
+	settings := settingsOf(&cfg)
+	var problems []string
+	// the settings that couldn't be parsed, they aren't validated again
+	unparsed := map[string]bool{}
+	set := func(s setting, raw string) {
+		if err := s.set(raw); err != nil {
+			problems = append(problems, err.Error())
+			unparsed[s.env] = true
+		}
+	}
+
+	// the variables already set win over the .env file, which is optional
+	if err := godotenv.Load(); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		problems = append(problems, "invalid .env file: "+err.Error())
+	}
+
+	flags := flag.NewFlagSet("scheduling-service", flag.ContinueOnError)
+	configFile := flags.String("config", os.Getenv("CONFIG_FILE"), "YAML file with the settings, overrides CONFIG_FILE")
+	values := map[string]*string{}
+	for _, s := range settings {
+		values[s.env] = flags.String(s.flag(), "", "overrides "+s.env+" and "+s.path)
+	}
+	if err := flags.Parse(args); err != nil {
+		return cfg, nil, err
+	}
+
+	if *configFile != "" {
+		if err := loadFile(*configFile, &cfg); err != nil {
+			problems = append(problems, err.Error())
+		}
+	}
+	for _, s := range settings {
+		if value, ok := os.LookupEnv(s.env); ok && value != "" {
+			set(s, value)
+		}
+	}
+	flags.Visit(func(f *flag.Flag) {
+		for _, s := range settings {
+			if s.flag() == f.Name {
+				set(s, *values[s.env])
+			}
+		}
+	})
+
+	if cfg.Auth.Audience == "" {
+		cfg.Auth.Audience = cfg.Auth.ClientID
+	}
+	rest := flags.Args()
+	var command string
+	if len(rest) > 0 {
+		command = rest[0]
+	}
+	problems = append(problems, cfg.validate(settings, unparsed, command)...)
+	if len(problems) > 0 {
+		return cfg, rest, &ValidationError{Problems: problems}
+	}
+	return cfg, rest, nil
+}
+
+// ValidationError - every setting that is missing or invalid, reported at once so they're fixed in one go.
+type ValidationError struct {
+	Problems []string
+}
+
+func (e *ValidationError) Error() string {
+	return "invalid configuration: " + strings.Join(e.Problems, "; ")
+}
+
+// loadFile - read the settings found at a YAML file, its unknown keys are refused so typos don't go unnoticed.
+func loadFile(path string, cfg *Config) error {
+	file, err := os.Open(path)
+	if err != nil {
+		return fmt.Errorf("failed to read the config file: %w", err)
+	}
+	defer file.Close()
+	decoder := yaml.NewDecoder(file)
+	decoder.KnownFields(true)
+	if err := decoder.Decode(cfg); err != nil && !errors.Is(err, io.EOF) {
+		return fmt.Errorf("invalid config file %s: %w", path, err)
+	}
+	return nil
+}
+
+// setting - a field of Config along with its env variable and YAML key.
+type setting struct {
+	env   string
+	path  string
+	value reflect.Value
+}
+
+// settingsOf - every setting of the config provided, the fields of its sections included.
+func settingsOf(cfg *Config) []setting {
+	return collectSettings(reflect.ValueOf(cfg).Elem(), "")
+}
+
+func collectSettings(section reflect.Value, prefix string) []setting {
+	var settings []setting
+	for i := 0; i < section.NumField(); i++ {
+		field := section.Type().Field(i)
+		path := prefix + field.Tag.Get("yaml")
+		if field.Type.Kind() == reflect.Struct {
+			settings = append(settings, collectSettings(section.Field(i), path+".")...)
+			continue
+		}
+		settings = append(settings, setting{env: field.Tag.Get("env"), path: path, value: section.Field(i)})
+	}
+	return settings
+}
+
+// name - how the setting is named at the problems, by both its env variable and its YAML key.
+func (s setting) name() string {
+	return s.env + " (" + s.path + ")"
+}
+
+// flag - the command line flag of the setting, e.g. -rabbit-mq-url-conn.
+func (s setting) flag() string {
+	return strings.ReplaceAll(strings.ToLower(s.env), "_", "-")
+}
+
+// set - parse the text provided into the setting by the type of its field.
+func (s setting) set(raw string) error {
+	var problem string
+	switch s.value.Interface().(type) {
+	case string:
+		s.value.SetString(raw)
+	case time.Duration:
+		parsed, err := time.ParseDuration(raw)
+		if err != nil {
+			problem = "it must be a duration like 30s"
+		}
+		s.value.SetInt(int64(parsed))
+	case int:
+		parsed, err := strconv.Atoi(raw)
+		if err != nil {
+			problem = "it must be a whole number"
+		}
+		s.value.SetInt(int64(parsed))
+	case float64:
+		parsed, err := strconv.ParseFloat(raw, 64)
+		if err != nil {
+			problem = "it must be a number"
+		}
+		s.value.SetFloat(parsed)
+	case bool:
+		parsed, err := strconv.ParseBool(raw)
+		if err != nil {
+			problem = "it must be true or false"
+		}
+		s.value.SetBool(parsed)
+	case []string:
+		var list []string
+		for _, item := range strings.Split(raw, ",") {
+			if item = strings.TrimSpace(item); item != "" {
+				list = append(list, item)
+			}
+		}
+		s.value.Set(reflect.ValueOf(list))
+	default:
+		problem = "its type isn't supported"
+	}
+	if problem != "" {
+		return fmt.Errorf("%s has an invalid value %q, %s", s.name(), raw, problem)
+	}
+	return nil
+}
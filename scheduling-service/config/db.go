@@ -2,32 +2,58 @@ package config
 
 import (
 	"database/sql"
-	"fmt"
-	"github.com/joho/godotenv"
-	"log"
-	"os"
+	"github.com/go-sql-driver/mysql"
+	"github.com/ronilsonalves/GoLang-in-a-spring-cloud-architecture/scheduling-service/pkg/tracing"
+	"net"
+	"strconv"
 )
 
-var URLDbConnection = ""
+const (
+	// StoreDriverMySQL - persist data at the MySQL database configured, the default driver.
+	StoreDriverMySQL = "mysql"
+	// StoreDriverMemory - keep data in memory, used for tests and local development without a database.
+	StoreDriverMemory = "memory"
+)
 
-// LoadConfig - load info about db connection from env variables
-func LoadConfig() {
-	var err error
-	if err = godotenv.Load(); err != nil {
-		log.Fatalln(err)
-	}
+// DatabaseConfig - the store the data is kept at and how to connect to the MySQL one.
+type DatabaseConfig struct {
+	// Driver - mysql or memory, to run without a database.
+	Driver   string `yaml:"driver" env:"STORE_DRIVER"`
+	Host     string `yaml:"host" env:"DATABASE_URL"`
+	Port     int    `yaml:"port" env:"DATABASE_PORT"`
+	Name     string `yaml:"name" env:"DATABASE_NAME"`
+	User     string `yaml:"user" env:"MYSQL_USER"`
+	Password string `yaml:"password" env:"MYSQL_PASSWORD"`
+	// MigrateOnStart - apply pending migrations at startup instead of refusing to start when the schema is behind.
+	MigrateOnStart bool `yaml:"migrateOnStart" env:"DB_MIGRATE_ON_START"`
+}
 
-	URLDbConnection = fmt.Sprintf("%s:%s@tcp(%s:%s)/%s",
-		os.Getenv("MYSQL_USER"), os.Getenv("MYSQL_PASSWORD"), os.Getenv("DATABASE_URL"),
-		os.Getenv("DATABASE_PORT"), os.Getenv("DATABASE_NAME"))
+func (c DatabaseConfig) validate(v *validator) {
+	v.oneOf("STORE_DRIVER", c.Driver, StoreDriverMySQL, StoreDriverMemory)
+	if c.Driver != StoreDriverMySQL {
+		return
+	}
+	v.required("DATABASE_URL", c.Host)
+	v.required("DATABASE_NAME", c.Name)
+	v.required("MYSQL_USER", c.User)
+	if c.Port < 1 || c.Port > 65535 {
+		v.add("DATABASE_PORT", "must be a port between 1 and 65535")
+	}
 }
 
-// ConnectDatabase - perform a sql.Open() to connect to database
-func ConnectDatabase() (*sql.DB, error) {
-	db, err := sql.Open("mysql", URLDbConnection)
+// ConnectDatabase - open the connection pool of the database configured, each statement is traced
+func ConnectDatabase(config DatabaseConfig) (*sql.DB, error) {
+	dsn := mysql.NewConfig()
+	dsn.User = config.User
+	dsn.Passwd = config.Password
+	dsn.Net = "tcp"
+	dsn.Addr = net.JoinHostPort(config.Host, strconv.Itoa(config.Port))
+	dsn.DBName = config.Name
+	connector, err := mysql.NewConnector(dsn)
 	if err != nil {
 		return nil, err
 	}
+	db := sql.OpenDB(tracing.WrapConnector(connector, "mysql"))
 	if err = db.Ping(); err != nil {
 		db.Close()
 		return nil, err
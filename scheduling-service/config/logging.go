@@ -0,0 +1,14 @@
+package config
+
+// LoggingConfig - how the log lines are written.
+type LoggingConfig struct {
+	// Format - the format of the log lines, json or logfmt.
+	Format string `yaml:"format" env:"LOG_FORMAT"`
+	// Level - the lines below this level are dropped: debug, info, warn or error.
+	Level string `yaml:"level" env:"LOG_LEVEL"`
+}
+
+func (c LoggingConfig) validate(v *validator) {
+	v.oneOf("LOG_FORMAT", c.Format, "json", "logfmt")
+	v.oneOf("LOG_LEVEL", c.Level, "debug", "info", "warn", "error")
+}
@@ -0,0 +1,21 @@
+package config
+
+// TracingConfig - where the spans are exported and how many traces are recorded.
+type TracingConfig struct {
+	// OTLPEndpoint - the OpenTelemetry collector the spans are exported to with OTLP over HTTP, e.g.
+	// http://localhost:4318, the spans aren't recorded when it is empty.
+	OTLPEndpoint string `yaml:"otlpEndpoint" env:"OTEL_EXPORTER_OTLP_ENDPOINT"`
+	// OTLPTimeoutMillis - how long the collector has to accept each batch of spans, in milliseconds as OpenTelemetry
+	// sets it.
+	OTLPTimeoutMillis int `yaml:"otlpTimeoutMillis" env:"OTEL_EXPORTER_OTLP_TIMEOUT"`
+	// SampleRatio - the share of the traces started here that are recorded, the ones started upstream follow the
+	// decision of the caller.
+	SampleRatio float64 `yaml:"sampleRatio" env:"OTEL_TRACES_SAMPLER_ARG"`
+}
+
+func (c TracingConfig) validate(v *validator) {
+	v.positive("OTEL_EXPORTER_OTLP_TIMEOUT", c.OTLPTimeoutMillis)
+	if c.SampleRatio < 0 || c.SampleRatio > 1 {
+		v.add("OTEL_TRACES_SAMPLER_ARG", "must be a ratio between 0 and 1")
+	}
+}
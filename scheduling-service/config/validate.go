@@ -0,0 +1,85 @@
+package config
+
+import (
+	"strings"
+	"time"
+)
+
+// validate - the problems of the settings loaded, every one of them rather than the first, but the settings unparsed
+// that are already reported. The migrate command only needs the database and logging settings, the others aren't
+// validated for it.
+func (c Config) validate(settings []setting, unparsed map[string]bool, command string) []string {
+	v := &validator{names: map[string]string{}, skipped: unparsed}
+	for _, s := range settings {
+		v.names[s.env] = s.name()
+	}
+	v.required("OTEL_SERVICE_NAME", c.ServiceName)
+	c.Database.validate(v)
+	c.Logging.validate(v)
+	if command == MigrateCommand {
+		return v.problems
+	}
+	c.Server.validate(v)
+	c.Auth.validate(v)
+	c.Broker.validate(v)
+	c.Outbox.validate(v)
+	c.Tracing.validate(v)
+	return v.problems
+}
+
+func (c ServerConfig) validate(v *validator) {
+	if c.Port < 1 || c.Port > 65535 {
+		v.add("PORT", "must be a port between 1 and 65535")
+	}
+	v.positiveDuration("REQUEST_TIMEOUT", c.RequestTimeout)
+	v.positiveDuration("HEALTH_CHECK_TIMEOUT", c.HealthCheckTimeout)
+	v.positiveDuration("SHUTDOWN_DRAIN_PERIOD", c.ShutdownDrainPeriod)
+	v.positiveDuration("SHUTDOWN_TIMEOUT", c.ShutdownTimeout)
+}
+
+// validator - collects the problems of the settings, named by their env variable and YAML key.
+type validator struct {
+	names    map[string]string
+	skipped  map[string]bool
+	problems []string
+}
+
+func (v *validator) add(env, problem string) {
+	if v.skipped[env] {
+		return
+	}
+	v.problems = append(v.problems, v.names[env]+" "+problem)
+}
+
+func (v *validator) required(env, value string) {
+	if strings.TrimSpace(value) == "" {
+		v.add(env, "is required")
+	}
+}
+
+func (v *validator) positive(env string, value int) {
+	if value < 1 {
+		v.add(env, "must be a positive number")
+	}
+}
+
+func (v *validator) notNegative(env string, value int) {
+	if value < 0 {
+		v.add(env, "must be zero or a positive number")
+	}
+}
+
+func (v *validator) positiveDuration(env string, value time.Duration) {
+	if value <= 0 {
+		v.add(env, "must be a positive duration like 30s")
+	}
+}
+
+func (v *validator) oneOf(env, value string, allowed ...string) {
+	for _, option := range allowed {
+		if value == option {
+			return
+		}
+	}
+	v.add(env, "must be one of "+strings.Join(allowed, ", ")+", not "+value)
+}
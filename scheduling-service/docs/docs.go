@@ -24,14 +24,14 @@ const docTemplate = `{
     "host": "{{.Host}}",
     "basePath": "{{.BasePath}}",
     "paths": {
-        "/appointments": {
+        "/admin/dead-letters": {
             "get": {
                 "security": [
                     {
                         "OAuth2Application": []
                     }
                 ],
-                "description": "get all appointments from db.",
+                "description": "get a page of the messages parked at the dead letter queue after they couldn't be handled, the oldest first unless requested otherwise.",
                 "consumes": [
                     "application/json"
                 ],
@@ -39,17 +39,54 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Appointments"
+                    "Admin"
+                ],
+                "summary": "List dead letters",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Page, starting at 1",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Items per page, 20 by default and up to 100",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "id",
+                            "-id",
+                            "queue",
+                            "-queue",
+                            "routingKey",
+                            "-routingKey"
+                        ],
+                        "type": "string",
+                        "description": "Sort field, prefixed with - for descending order",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Queue the messages were dead-lettered from",
+                        "name": "queue",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Routing key the messages were published with, e.g. appointment.created",
+                        "name": "routingKey",
+                        "in": "query"
+                    }
                 ],
-                "summary": "List all appointments",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/domain.Appointment"
-                            }
+                            "$ref": "#/definitions/domain.DeadLetterPage"
                         }
                     },
                     "400": {
@@ -64,21 +101,23 @@ const docTemplate = `{
                             "$ref": "#/definitions/web.errorResponse"
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
+                    "403": {
+                        "description": "Forbidden",
                         "schema": {
                             "$ref": "#/definitions/web.errorResponse"
                         }
                     }
                 }
-            },
-            "post": {
+            }
+        },
+        "/admin/dead-letters/{id}": {
+            "get": {
                 "security": [
                     {
                         "OAuth2Application": []
                     }
                 ],
-                "description": "Create a new appointment by request body",
+                "description": "get a dead letter by a provided ID with its headers, body and the reason it was parked.",
                 "consumes": [
                     "application/json"
                 ],
@@ -86,25 +125,23 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Appointments"
+                    "Admin"
                 ],
-                "summary": "Create a new appointment",
+                "summary": "Inspect a dead letter",
                 "parameters": [
                     {
-                        "description": "Body",
-                        "name": "body",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/domain.Appointment"
-                        }
+                        "type": "integer",
+                        "description": "Dead letter ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Created",
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/domain.Appointment"
+                            "$ref": "#/definitions/domain.DeadLetter"
                         }
                     },
                     "400": {
@@ -118,18 +155,34 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/web.errorResponse"
                         }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/web.errorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/web.errorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/web.errorResponse"
+                        }
                     }
                 }
-            }
-        },
-        "/appointments/dentist/{license_number}": {
-            "get": {
+            },
+            "delete": {
                 "security": [
                     {
                         "OAuth2Application": []
                     }
                 ],
-                "description": "Get all appointments by dentist license doc",
+                "description": "Remove a dead letter by ID, it's never delivered again.",
                 "consumes": [
                     "application/json"
                 ],
@@ -137,14 +190,14 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Appointments"
+                    "Admin"
                 ],
-                "summary": "Get all appointments by dentist license doc",
+                "summary": "Discard a dead letter",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Dentist License Number",
-                        "name": "license_number",
+                        "description": "Dead letter ID",
+                        "name": "id",
                         "in": "path",
                         "required": true
                     }
@@ -153,10 +206,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/domain.Appointment"
-                            }
+                            "$ref": "#/definitions/web.errorResponse"
                         }
                     },
                     "400": {
@@ -171,23 +221,35 @@ const docTemplate = `{
                             "$ref": "#/definitions/web.errorResponse"
                         }
                     },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/web.errorResponse"
+                        }
+                    },
                     "404": {
                         "description": "Not Found",
                         "schema": {
                             "$ref": "#/definitions/web.errorResponse"
                         }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/web.errorResponse"
+                        }
                     }
                 }
             }
         },
-        "/appointments/patient/{identity_number}": {
-            "get": {
+        "/admin/dead-letters/{id}/replay": {
+            "post": {
                 "security": [
                     {
                         "OAuth2Application": []
                     }
                 ],
-                "description": "get all appointments by patient identity doc",
+                "description": "Send a dead letter back to the queue it came from, only its consumer receives it again with the retries started over. It's removed once sent.",
                 "consumes": [
                     "application/json"
                 ],
@@ -195,14 +257,14 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Appointments"
+                    "Admin"
                 ],
-                "summary": "Get all appointments by patient identity doc",
+                "summary": "Replay a dead letter",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Patient Doc Number",
-                        "name": "identity_number",
+                        "description": "Dead letter ID",
+                        "name": "id",
                         "in": "path",
                         "required": true
                     }
@@ -211,10 +273,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/domain.Appointment"
-                            }
+                            "$ref": "#/definitions/web.errorResponse"
                         }
                     },
                     "400": {
@@ -229,23 +288,41 @@ const docTemplate = `{
                             "$ref": "#/definitions/web.errorResponse"
                         }
                     },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/web.errorResponse"
+                        }
+                    },
                     "404": {
                         "description": "Not Found",
                         "schema": {
                             "$ref": "#/definitions/web.errorResponse"
                         }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/web.errorResponse"
+                        }
+                    },
+                    "502": {
+                        "description": "Bad Gateway",
+                        "schema": {
+                            "$ref": "#/definitions/web.errorResponse"
+                        }
                     }
                 }
             }
         },
-        "/appointments/{id}": {
+        "/appointments": {
             "get": {
                 "security": [
                     {
                         "OAuth2Application": []
                     }
                 ],
-                "description": "get an appointment by a provided ID.",
+                "description": "get a page of the appointments from db matching the filters, sorted by date and time unless requested otherwise. Patients and dentists only get their own appointments.",
                 "consumes": [
                     "application/json"
                 ],
@@ -255,21 +332,82 @@ const docTemplate = `{
                 "tags": [
                     "Appointments"
                 ],
-                "summary": "Get an appointment by an ID",
+                "summary": "List appointments",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Appointment ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "description": "Page, starting at 1",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Items per page, 20 by default and up to 100",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "id",
+                            "-id",
+                            "dateAndTime",
+                            "-dateAndTime",
+                            "dentistCRO",
+                            "-dentistCRO",
+                            "patientRG",
+                            "-patientRG",
+                            "status",
+                            "-status"
+                        ],
+                        "type": "string",
+                        "description": "Sort field, prefixed with - for descending order",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Appointments starting from, in format 30/01/2023 23:59",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Appointments starting before, in format 30/01/2023 23:59",
+                        "name": "to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Dentist license number",
+                        "name": "dentistCRO",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Patient identity number",
+                        "name": "patientRG",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "scheduled",
+                            "confirmed",
+                            "checked-in",
+                            "completed",
+                            "cancelled",
+                            "no-show"
+                        ],
+                        "type": "string",
+                        "description": "Appointment status",
+                        "name": "status",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/domain.Appointment"
+                            "$ref": "#/definitions/domain.AppointmentPage"
                         }
                     },
                     "400": {
@@ -284,21 +422,21 @@ const docTemplate = `{
                             "$ref": "#/definitions/web.errorResponse"
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
+                    "403": {
+                        "description": "Forbidden",
                         "schema": {
                             "$ref": "#/definitions/web.errorResponse"
                         }
                     }
                 }
             },
-            "put": {
+            "post": {
                 "security": [
                     {
                         "OAuth2Application": []
                     }
                 ],
-                "description": "Update a entire appointment by ID and request body",
+                "description": "Create a new appointment by request body",
                 "consumes": [
                     "application/json"
                 ],
@@ -308,15 +446,8 @@ const docTemplate = `{
                 "tags": [
                     "Appointments"
                 ],
-                "summary": "Update a entire appointment by ID",
+                "summary": "Create a new appointment",
                 "parameters": [
-                    {
-                        "type": "integer",
-                        "description": "Appointment ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    },
                     {
                         "description": "Body",
                         "name": "body",
@@ -328,10 +459,10 @@ const docTemplate = `{
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK",
+                    "201": {
+                        "description": "Created",
                         "schema": {
-                            "$ref": "#/definitions/domain.Appointment"
+                            "$ref": "#/definitions/domain.AppointmentDTO"
                         }
                     },
                     "400": {
@@ -346,8 +477,8 @@ const docTemplate = `{
                             "$ref": "#/definitions/web.errorResponse"
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
+                    "403": {
+                        "description": "Forbidden",
                         "schema": {
                             "$ref": "#/definitions/web.errorResponse"
                         }
@@ -359,14 +490,16 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "delete": {
+            }
+        },
+        "/appointments/dentist/{license_number}": {
+            "get": {
                 "security": [
                     {
                         "OAuth2Application": []
                     }
                 ],
-                "description": "Delete an appointment by ID",
+                "description": "get a page of the appointments by dentist license doc, accepts the same page, sort and filters of the appointments list.",
                 "consumes": [
                     "application/json"
                 ],
@@ -376,21 +509,57 @@ const docTemplate = `{
                 "tags": [
                     "Appointments"
                 ],
-                "summary": "Delete an appointment by ID",
+                "summary": "List appointments by dentist license doc",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Appointment ID",
-                        "name": "id",
+                        "description": "Dentist License Number",
+                        "name": "license_number",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page, starting at 1",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Items per page, 20 by default and up to 100",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Sort field, prefixed with - for descending order",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Appointments starting from, in format 30/01/2023 23:59",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Appointments starting before, in format 30/01/2023 23:59",
+                        "name": "to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Appointment status",
+                        "name": "status",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/web.errorResponse"
+                            "$ref": "#/definitions/domain.AppointmentPage"
                         }
                     },
                     "400": {
@@ -405,21 +574,23 @@ const docTemplate = `{
                             "$ref": "#/definitions/web.errorResponse"
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
+                    "403": {
+                        "description": "Forbidden",
                         "schema": {
                             "$ref": "#/definitions/web.errorResponse"
                         }
                     }
                 }
-            },
-            "patch": {
+            }
+        },
+        "/appointments/patient/{identity_number}": {
+            "get": {
                 "security": [
                     {
                         "OAuth2Application": []
                     }
                 ],
-                "description": "Update fields from an appointment by ID and fields in request body",
+                "description": "get a page of the appointments by patient identity doc, accepts the same page, sort and filters of the appointments list.",
                 "consumes": [
                     "application/json"
                 ],
@@ -429,30 +600,57 @@ const docTemplate = `{
                 "tags": [
                     "Appointments"
                 ],
-                "summary": "Update fields from an appointment by ID",
+                "summary": "List appointments by patient identity doc",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Appointment ID",
-                        "name": "id",
+                        "description": "Patient Doc Number",
+                        "name": "identity_number",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "description": "Body",
-                        "name": "body",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/domain.Appointment"
-                        }
+                        "type": "integer",
+                        "description": "Page, starting at 1",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Items per page, 20 by default and up to 100",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Sort field, prefixed with - for descending order",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Appointments starting from, in format 30/01/2023 23:59",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Appointments starting before, in format 30/01/2023 23:59",
+                        "name": "to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Appointment status",
+                        "name": "status",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/domain.Appointment"
+                            "$ref": "#/definitions/domain.AppointmentPage"
                         }
                     },
                     "400": {
@@ -467,8 +665,8 @@ const docTemplate = `{
                             "$ref": "#/definitions/web.errorResponse"
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
+                    "403": {
+                        "description": "Forbidden",
                         "schema": {
                             "$ref": "#/definitions/web.errorResponse"
                         }
@@ -476,14 +674,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/dentists": {
+        "/appointments/{id}": {
             "get": {
                 "security": [
                     {
                         "OAuth2Application": []
                     }
                 ],
-                "description": "get all dentists from db.",
+                "description": "get an appointment by a provided ID.",
                 "consumes": [
                     "application/json"
                 ],
@@ -491,17 +689,23 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Dentists"
+                    "Appointments"
+                ],
+                "summary": "Get an appointment by an ID",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Appointment ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
                 ],
-                "summary": "List all dentists",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/domain.Dentist"
-                            }
+                            "$ref": "#/definitions/domain.AppointmentDTO"
                         }
                     },
                     "400": {
@@ -515,16 +719,28 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/web.errorResponse"
                         }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/web.errorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/web.errorResponse"
+                        }
                     }
                 }
             },
-            "post": {
+            "put": {
                 "security": [
                     {
                         "OAuth2Application": []
                     }
                 ],
-                "description": "Create a new dentist by request body.",
+                "description": "Update a entire appointment by ID and request body",
                 "consumes": [
                     "application/json"
                 ],
@@ -532,25 +748,32 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Dentists"
+                    "Appointments"
                 ],
-                "summary": "Create a new dentist",
+                "summary": "Update a entire appointment by ID",
                 "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Appointment ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
                     {
                         "description": "Body",
                         "name": "body",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/domain.Dentist"
+                            "$ref": "#/definitions/domain.Appointment"
                         }
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Created",
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/domain.Dentist"
+                            "$ref": "#/definitions/domain.AppointmentDTO"
                         }
                     },
                     "400": {
@@ -564,18 +787,34 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/web.errorResponse"
                         }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/web.errorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/web.errorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/web.errorResponse"
+                        }
                     }
                 }
-            }
-        },
-        "/dentists/{id}": {
-            "get": {
+            },
+            "delete": {
                 "security": [
                     {
                         "OAuth2Application": []
                     }
                 ],
-                "description": "get a dentist by a provided ID.",
+                "description": "Delete an appointment by ID",
                 "consumes": [
                     "application/json"
                 ],
@@ -583,13 +822,13 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Dentists"
+                    "Appointments"
                 ],
-                "summary": "Get a dentist by an ID",
+                "summary": "Delete an appointment by ID",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Dentist ID",
+                        "description": "Appointment ID",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -599,7 +838,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/domain.Dentist"
+                            "$ref": "#/definitions/web.errorResponse"
                         }
                     },
                     "400": {
@@ -614,6 +853,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/web.errorResponse"
                         }
                     },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/web.errorResponse"
+                        }
+                    },
                     "404": {
                         "description": "Not Found",
                         "schema": {
@@ -622,13 +867,13 @@ const docTemplate = `{
                     }
                 }
             },
-            "put": {
+            "patch": {
                 "security": [
                     {
                         "OAuth2Application": []
                     }
                 ],
-                "description": "Update an entire dentist by ID.",
+                "description": "Update fields from an appointment by ID and fields in request body",
                 "consumes": [
                     "application/json"
                 ],
@@ -636,13 +881,13 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Dentists"
+                    "Appointments"
                 ],
-                "summary": "Update an entire dentist by ID",
+                "summary": "Update fields from an appointment by ID",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Dentist ID",
+                        "description": "Appointment ID",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -653,7 +898,7 @@ const docTemplate = `{
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/domain.Dentist"
+                            "$ref": "#/definitions/domain.Appointment"
                         }
                     }
                 ],
@@ -661,7 +906,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/domain.Dentist"
+                            "$ref": "#/definitions/domain.AppointmentDTO"
                         }
                     },
                     "400": {
@@ -676,6 +921,18 @@ const docTemplate = `{
                             "$ref": "#/definitions/web.errorResponse"
                         }
                     },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/web.errorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/web.errorResponse"
+                        }
+                    },
                     "409": {
                         "description": "Conflict",
                         "schema": {
@@ -683,14 +940,16 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "delete": {
+            }
+        },
+        "/appointments/{id}/cancel": {
+            "post": {
                 "security": [
                     {
                         "OAuth2Application": []
                     }
                 ],
-                "description": "Delete a product by ID",
+                "description": "Cancel a scheduled or confirmed appointment by ID, it's kept for reporting and its interval becomes available again.",
                 "consumes": [
                     "application/json"
                 ],
@@ -698,23 +957,32 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Dentists"
+                    "Appointments"
                 ],
-                "summary": "Delete a product by ID",
+                "summary": "Cancel an appointment by ID",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Dentist ID",
+                        "description": "Appointment ID",
                         "name": "id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "description": "Body",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handler.cancelRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/web.response"
+                            "$ref": "#/definitions/domain.AppointmentDTO"
                         }
                     },
                     "400": {
@@ -729,21 +997,35 @@ const docTemplate = `{
                             "$ref": "#/definitions/web.errorResponse"
                         }
                     },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/web.errorResponse"
+                        }
+                    },
                     "404": {
                         "description": "Not Found",
                         "schema": {
                             "$ref": "#/definitions/web.errorResponse"
                         }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/web.errorResponse"
+                        }
                     }
                 }
-            },
-            "patch": {
+            }
+        },
+        "/appointments/{id}/check-in": {
+            "post": {
                 "security": [
                     {
                         "OAuth2Application": []
                     }
                 ],
-                "description": "Update fields from a dentist",
+                "description": "Check the patient in for a scheduled or confirmed appointment by ID",
                 "consumes": [
                     "application/json"
                 ],
@@ -751,32 +1033,23 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Dentists"
+                    "Appointments"
                 ],
-                "summary": "Update fields from a dentist",
+                "summary": "Check the patient in for an appointment by ID",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Dentist ID",
+                        "description": "Appointment ID",
                         "name": "id",
                         "in": "path",
                         "required": true
-                    },
-                    {
-                        "description": "Body",
-                        "name": "body",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/domain.Dentist"
-                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/domain.Dentist"
+                            "$ref": "#/definitions/domain.AppointmentDTO"
                         }
                     },
                     "400": {
@@ -790,59 +1063,36 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/web.errorResponse"
                         }
-                    }
-                }
-            }
-        },
-        "/patients": {
-            "get": {
-                "security": [
-                    {
-                        "OAuth2Application": []
-                    }
-                ],
-                "description": "get all patients from db.",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "Patients"
-                ],
-                "summary": "List all patients",
-                "responses": {
-                    "200": {
-                        "description": "OK",
+                    },
+                    "403": {
+                        "description": "Forbidden",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/domain.Patient"
-                            }
+                            "$ref": "#/definitions/web.errorResponse"
                         }
                     },
-                    "400": {
-                        "description": "Bad Request",
+                    "404": {
+                        "description": "Not Found",
                         "schema": {
                             "$ref": "#/definitions/web.errorResponse"
                         }
                     },
-                    "401": {
-                        "description": "Unauthorized",
+                    "409": {
+                        "description": "Conflict",
                         "schema": {
                             "$ref": "#/definitions/web.errorResponse"
                         }
                     }
                 }
-            },
-            "put": {
+            }
+        },
+        "/appointments/{id}/complete": {
+            "post": {
                 "security": [
                     {
                         "OAuth2Application": []
                     }
                 ],
-                "description": "Update an entire patient by ID.",
+                "description": "Complete an appointment whose patient is checked in by ID",
                 "consumes": [
                     "application/json"
                 ],
@@ -850,32 +1100,23 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Patients"
+                    "Appointments"
                 ],
-                "summary": "Update an entire patient by ID",
+                "summary": "Complete an appointment by ID",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Patient ID",
+                        "description": "Appointment ID",
                         "name": "id",
                         "in": "path",
                         "required": true
-                    },
-                    {
-                        "description": "Body",
-                        "name": "body",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/domain.Patient"
-                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/domain.Patient"
+                            "$ref": "#/definitions/domain.AppointmentDTO"
                         }
                     },
                     "400": {
@@ -890,6 +1131,18 @@ const docTemplate = `{
                             "$ref": "#/definitions/web.errorResponse"
                         }
                     },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/web.errorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/web.errorResponse"
+                        }
+                    },
                     "409": {
                         "description": "Conflict",
                         "schema": {
@@ -897,14 +1150,16 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
+            }
+        },
+        "/appointments/{id}/confirm": {
             "post": {
                 "security": [
                     {
                         "OAuth2Application": []
                     }
                 ],
-                "description": "Create a new patient by request body.",
+                "description": "Confirm a scheduled appointment by ID",
                 "consumes": [
                     "application/json"
                 ],
@@ -912,25 +1167,23 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Patients"
+                    "Appointments"
                 ],
-                "summary": "Create a new patient",
+                "summary": "Confirm an appointment by ID",
                 "parameters": [
                     {
-                        "description": "Body",
-                        "name": "body",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/domain.Patient"
-                        }
+                        "type": "integer",
+                        "description": "Appointment ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Created",
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/domain.Patient"
+                            "$ref": "#/definitions/domain.AppointmentDTO"
                         }
                     },
                     "400": {
@@ -944,18 +1197,36 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/web.errorResponse"
                         }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/web.errorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/web.errorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/web.errorResponse"
+                        }
                     }
                 }
             }
         },
-        "/patients/{id}": {
-            "get": {
+        "/appointments/{id}/no-show": {
+            "post": {
                 "security": [
                     {
                         "OAuth2Application": []
                     }
                 ],
-                "description": "get a patient by a provided ID.",
+                "description": "Mark the patient didn't attend a scheduled or confirmed appointment by ID, only after it starts. Its interval becomes available again.",
                 "consumes": [
                     "application/json"
                 ],
@@ -963,13 +1234,13 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Patients"
+                    "Appointments"
                 ],
-                "summary": "Get a patient by an ID",
+                "summary": "Mark an appointment as no-show by ID",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Patient ID",
+                        "description": "Appointment ID",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -979,7 +1250,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/domain.Patient"
+                            "$ref": "#/definitions/domain.AppointmentDTO"
                         }
                     },
                     "400": {
@@ -994,21 +1265,35 @@ const docTemplate = `{
                             "$ref": "#/definitions/web.errorResponse"
                         }
                     },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/web.errorResponse"
+                        }
+                    },
                     "404": {
                         "description": "Not Found",
                         "schema": {
                             "$ref": "#/definitions/web.errorResponse"
                         }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/web.errorResponse"
+                        }
                     }
                 }
-            },
-            "delete": {
+            }
+        },
+        "/availability": {
+            "get": {
                 "security": [
                     {
                         "OAuth2Application": []
                     }
                 ],
-                "description": "Delete a patient by ID",
+                "description": "Search the free slots to book an appointment between from and to, considering the dentists working hours, breaks, exceptions, appointments and clinic closures. Without dentistCRO every dentist is searched, with patientRG the periods the patient is booked are excluded, patients always search around their own appointments and can't ask for other patients. Dates are in format 30/01/2023 23:59 and the search covers up to 31 days.",
                 "consumes": [
                     "application/json"
                 ],
@@ -1016,23 +1301,51 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Patients"
+                    "Availability"
                 ],
-                "summary": "Delete a patient by ID",
+                "summary": "Search free slots",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "Patient ID",
-                        "name": "id",
-                        "in": "path",
+                        "type": "string",
+                        "description": "Dentist license number",
+                        "name": "dentistCRO",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Patient identity number",
+                        "name": "patientRG",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Search start",
+                        "name": "from",
+                        "in": "query",
                         "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Search end",
+                        "name": "to",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Appointment duration in minutes, 60 by default",
+                        "name": "duration",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/web.response"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/domain.Slot"
+                            }
                         }
                     },
                     "400": {
@@ -1047,21 +1360,70 @@ const docTemplate = `{
                             "$ref": "#/definitions/web.errorResponse"
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/web.errorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/closures": {
+            "get": {
+                "security": [
+                    {
+                        "OAuth2Application": []
+                    }
+                ],
+                "description": "get all periods the clinic is closed, like holidays, ordered by start.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Closures"
+                ],
+                "summary": "List all clinic closures",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/domain.ClinicClosure"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/web.errorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/web.errorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
                         "schema": {
                             "$ref": "#/definitions/web.errorResponse"
                         }
                     }
                 }
             },
-            "patch": {
+            "post": {
                 "security": [
                     {
                         "OAuth2Application": []
                     }
                 ],
-                "description": "Update fields from a patient",
+                "description": "Add a period the whole clinic is closed, no appointment can be booked during it. Dates are in format 30/01/2023 23:59.",
                 "consumes": [
                     "application/json"
                 ],
@@ -1069,32 +1431,229 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Patients"
+                    "Closures"
                 ],
-                "summary": "Update fields from a patient",
+                "summary": "Add a clinic closure",
+                "parameters": [
+                    {
+                        "description": "Body",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/domain.ClinicClosure"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ClinicClosure"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/web.errorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/web.errorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/web.errorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/closures/{id}": {
+            "delete": {
+                "security": [
+                    {
+                        "OAuth2Application": []
+                    }
+                ],
+                "description": "Remove a clinic closure by ID",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Closures"
+                ],
+                "summary": "Remove a clinic closure",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Patient ID",
+                        "description": "Closure ID",
                         "name": "id",
                         "in": "path",
                         "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/web.errorResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/web.errorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/web.errorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/web.errorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/web.errorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/dentists": {
+            "get": {
+                "security": [
+                    {
+                        "OAuth2Application": []
+                    }
+                ],
+                "description": "get a page of the dentists from db matching the filters, sorted by id unless requested otherwise.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Dentists"
+                ],
+                "summary": "List dentists",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Page, starting at 1",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Items per page, 20 by default and up to 100",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "id",
+                            "-id",
+                            "name",
+                            "-name",
+                            "lastName",
+                            "-lastName",
+                            "cro",
+                            "-cro"
+                        ],
+                        "type": "string",
+                        "description": "Sort field, prefixed with - for descending order",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Start of the name or the last name",
+                        "name": "name",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Dentist license number",
+                        "name": "cro",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domain.DentistPage"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/web.errorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/web.errorResponse"
+                        }
                     },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/web.errorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "OAuth2Application": []
+                    }
+                ],
+                "description": "Create a new dentist by request body.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Dentists"
+                ],
+                "summary": "Create a new dentist",
+                "parameters": [
                     {
                         "description": "Body",
                         "name": "body",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/domain.Patient"
+                            "$ref": "#/definitions/domain.Dentist"
                         }
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK",
+                    "201": {
+                        "description": "Created",
                         "schema": {
-                            "$ref": "#/definitions/domain.Patient"
+                            "$ref": "#/definitions/domain.Dentist"
                         }
                     },
                     "400": {
@@ -1108,35 +1667,1207 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/web.errorResponse"
                         }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/web.errorResponse"
+                        }
                     }
                 }
             }
-        }
-    },
-    "definitions": {
-        "domain.Appointment": {
-            "type": "object",
-            "required": [
-                "dateAndTime",
-                "dentistCRO",
-                "description",
-                "patientRG"
-            ],
-            "properties": {
-                "dateAndTime": {
-                    "type": "string"
-                },
-                "dentistCRO": {
-                    "type": "string"
-                },
-                "description": {
-                    "type": "string"
-                },
-                "id": {
-                    "type": "integer"
-                },
-                "patientRG": {
-                    "type": "string"
+        },
+        "/dentists/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "OAuth2Application": []
+                    }
+                ],
+                "description": "get a dentist by a provided ID.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Dentists"
+                ],
+                "summary": "Get a dentist by an ID",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Dentist ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domain.Dentist"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/web.errorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/web.errorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/web.errorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/web.errorResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "OAuth2Application": []
+                    }
+                ],
+                "description": "Update an entire dentist by ID.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Dentists"
+                ],
+                "summary": "Update an entire dentist by ID",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Dentist ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Body",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/domain.Dentist"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domain.Dentist"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/web.errorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/web.errorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/web.errorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/web.errorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "OAuth2Application": []
+                    }
+                ],
+                "description": "Delete a product by ID",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Dentists"
+                ],
+                "summary": "Delete a product by ID",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Dentist ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/web.response"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/web.errorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/web.errorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/web.errorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/web.errorResponse"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "security": [
+                    {
+                        "OAuth2Application": []
+                    }
+                ],
+                "description": "Update fields from a dentist",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Dentists"
+                ],
+                "summary": "Update fields from a dentist",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Dentist ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Body",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/domain.Dentist"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domain.Dentist"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/web.errorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/web.errorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/web.errorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/dentists/{id}/schedule": {
+            "get": {
+                "security": [
+                    {
+                        "OAuth2Application": []
+                    }
+                ],
+                "description": "get the working hours, breaks and exceptions of a dentist by ID, without working hours the dentist is available all day, every day.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Dentists"
+                ],
+                "summary": "Get the schedule of a dentist",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Dentist ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domain.DentistSchedule"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/web.errorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/web.errorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/web.errorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/web.errorResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "OAuth2Application": []
+                    }
+                ],
+                "description": "Replace the working hours and breaks of a dentist by ID, exceptions are kept. Weekdays go from 0 (sunday) to 6 (saturday) and times are in format 23:59.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Dentists"
+                ],
+                "summary": "Replace the weekly schedule of a dentist",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Dentist ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Body",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/domain.DentistSchedule"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domain.DentistSchedule"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/web.errorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/web.errorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/web.errorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/web.errorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/dentists/{id}/schedule/exceptions": {
+            "post": {
+                "security": [
+                    {
+                        "OAuth2Application": []
+                    }
+                ],
+                "description": "Add a period the dentist doesn't attend, like vacations or a day off.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Dentists"
+                ],
+                "summary": "Add an exception to the schedule of a dentist",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Dentist ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Body",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/domain.ScheduleException"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ScheduleException"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/web.errorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/web.errorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/web.errorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/web.errorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/dentists/{id}/schedule/exceptions/{exception_id}": {
+            "delete": {
+                "security": [
+                    {
+                        "OAuth2Application": []
+                    }
+                ],
+                "description": "Remove an exception from the schedule of a dentist by ID",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Dentists"
+                ],
+                "summary": "Remove an exception from the schedule of a dentist",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Dentist ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Exception ID",
+                        "name": "exception_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/web.errorResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/web.errorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/web.errorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/web.errorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/web.errorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/patients": {
+            "get": {
+                "security": [
+                    {
+                        "OAuth2Application": []
+                    }
+                ],
+                "description": "get a page of the patients from db matching the filters, sorted by id unless requested otherwise.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Patients"
+                ],
+                "summary": "List patients",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Page, starting at 1",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Items per page, 20 by default and up to 100",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "id",
+                            "-id",
+                            "name",
+                            "-name",
+                            "lastName",
+                            "-lastName",
+                            "rg",
+                            "-rg",
+                            "createdAt",
+                            "-createdAt"
+                        ],
+                        "type": "string",
+                        "description": "Sort field, prefixed with - for descending order",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Start of the name or the last name",
+                        "name": "name",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Patient identity number",
+                        "name": "rg",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domain.PatientPage"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/web.errorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/web.errorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/web.errorResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "OAuth2Application": []
+                    }
+                ],
+                "description": "Update an entire patient by ID.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Patients"
+                ],
+                "summary": "Update an entire patient by ID",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Patient ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Body",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/domain.Patient"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domain.Patient"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/web.errorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/web.errorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/web.errorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/web.errorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "OAuth2Application": []
+                    }
+                ],
+                "description": "Create a new patient by request body.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Patients"
+                ],
+                "summary": "Create a new patient",
+                "parameters": [
+                    {
+                        "description": "Body",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/domain.Patient"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/domain.Patient"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/web.errorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/web.errorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/web.errorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/patients/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "OAuth2Application": []
+                    }
+                ],
+                "description": "get a patient by a provided ID.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Patients"
+                ],
+                "summary": "Get a patient by an ID",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Patient ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domain.Patient"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/web.errorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/web.errorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/web.errorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/web.errorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "OAuth2Application": []
+                    }
+                ],
+                "description": "Delete a patient by ID",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Patients"
+                ],
+                "summary": "Delete a patient by ID",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Patient ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/web.response"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/web.errorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/web.errorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/web.errorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/web.errorResponse"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "security": [
+                    {
+                        "OAuth2Application": []
+                    }
+                ],
+                "description": "Update fields from a patient",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Patients"
+                ],
+                "summary": "Update fields from a patient",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Patient ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Body",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/domain.Patient"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domain.Patient"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/web.errorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/web.errorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/web.errorResponse"
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "domain.Appointment": {
+            "type": "object",
+            "required": [
+                "dateAndTime",
+                "dentistCRO",
+                "description",
+                "patientRG"
+            ],
+            "properties": {
+                "cancellationReason": {
+                    "type": "string"
+                },
+                "cancelledAt": {
+                    "type": "string"
+                },
+                "checkedInAt": {
+                    "type": "string"
+                },
+                "completedAt": {
+                    "type": "string"
+                },
+                "confirmedAt": {
+                    "type": "string"
+                },
+                "dateAndTime": {
+                    "type": "string"
+                },
+                "dentistCRO": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "duration": {
+                    "type": "integer"
+                },
+                "endDateAndTime": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "noShowAt": {
+                    "type": "string"
+                },
+                "patientRG": {
+                    "type": "string"
+                },
+                "procedure": {
+                    "type": "string"
+                },
+                "status": {
+                    "description": "Status and the fields below are only changed through the status transitions, they're ignored on create and update",
+                    "type": "string",
+                    "enum": [
+                        "scheduled",
+                        "confirmed",
+                        "checked-in",
+                        "completed",
+                        "cancelled",
+                        "no-show"
+                    ]
+                }
+            }
+        },
+        "domain.AppointmentDTO": {
+            "type": "object",
+            "required": [
+                "dateAndTime",
+                "dentist",
+                "dentistCRO",
+                "description",
+                "patient",
+                "patientRG"
+            ],
+            "properties": {
+                "billing": {
+                    "description": "Billing is the invoice reported by the invoice service, it's ignored on create and update",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/domain.Billing"
+                        }
+                    ]
+                },
+                "cancellationReason": {
+                    "type": "string"
+                },
+                "cancelledAt": {
+                    "type": "string"
+                },
+                "checkedInAt": {
+                    "type": "string"
+                },
+                "completedAt": {
+                    "type": "string"
+                },
+                "confirmedAt": {
+                    "type": "string"
+                },
+                "dateAndTime": {
+                    "type": "string"
+                },
+                "dentist": {
+                    "$ref": "#/definitions/domain.Dentist"
+                },
+                "dentistCRO": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "duration": {
+                    "type": "integer"
+                },
+                "endDateAndTime": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "noShowAt": {
+                    "type": "string"
+                },
+                "patient": {
+                    "$ref": "#/definitions/domain.Patient"
+                },
+                "patientRG": {
+                    "type": "string"
+                },
+                "procedure": {
+                    "type": "string"
+                },
+                "status": {
+                    "description": "Status and the fields below are only changed through the status transitions, they're ignored on create and update",
+                    "type": "string",
+                    "enum": [
+                        "scheduled",
+                        "confirmed",
+                        "checked-in",
+                        "completed",
+                        "cancelled",
+                        "no-show"
+                    ]
+                }
+            }
+        },
+        "domain.AppointmentPage": {
+            "type": "object",
+            "properties": {
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.AppointmentDTO"
+                    }
+                },
+                "limit": {
+                    "type": "integer"
+                },
+                "next": {
+                    "type": "string"
+                },
+                "page": {
+                    "type": "integer"
+                },
+                "total": {
+                    "type": "integer"
+                },
+                "totalPages": {
+                    "type": "integer"
+                }
+            }
+        },
+        "domain.Billing": {
+            "type": "object",
+            "properties": {
+                "invoiceId": {
+                    "type": "string"
+                },
+                "paymentStatus": {
+                    "type": "string",
+                    "enum": [
+                        "unpaid",
+                        "paid",
+                        "cancelled"
+                    ]
+                },
+                "updatedAt": {
+                    "type": "string"
+                }
+            }
+        },
+        "domain.ClinicClosure": {
+            "type": "object",
+            "required": [
+                "dateAndTime",
+                "endDateAndTime"
+            ],
+            "properties": {
+                "dateAndTime": {
+                    "type": "string"
+                },
+                "endDateAndTime": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "reason": {
+                    "type": "string"
+                }
+            }
+        },
+        "domain.DeadLetter": {
+            "type": "object",
+            "properties": {
+                "body": {
+                    "type": "string"
+                },
+                "contentType": {
+                    "type": "string"
+                },
+                "deadLetteredAt": {
+                    "type": "string"
+                },
+                "exchange": {
+                    "type": "string"
+                },
+                "headers": {
+                    "type": "object",
+                    "additionalProperties": true
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "messageId": {
+                    "description": "MessageID is the id of the event the message carried, when it was one of ours",
+                    "type": "string"
+                },
+                "queue": {
+                    "description": "Queue is where the message was dead-lettered from, a replay sends it back there",
+                    "type": "string"
+                },
+                "reason": {
+                    "description": "Reason is the error that parked the message, or rejected, expired or maxlen when the broker dead-lettered it",
+                    "type": "string"
+                },
+                "retryCount": {
+                    "type": "integer"
+                },
+                "routingKey": {
+                    "type": "string"
+                }
+            }
+        },
+        "domain.DeadLetterPage": {
+            "type": "object",
+            "properties": {
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.DeadLetter"
+                    }
+                },
+                "limit": {
+                    "type": "integer"
+                },
+                "next": {
+                    "type": "string"
+                },
+                "page": {
+                    "type": "integer"
+                },
+                "total": {
+                    "type": "integer"
+                },
+                "totalPages": {
+                    "type": "integer"
                 }
             }
         },
@@ -1162,6 +2893,58 @@ const docTemplate = `{
                 }
             }
         },
+        "domain.DentistPage": {
+            "type": "object",
+            "properties": {
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.Dentist"
+                    }
+                },
+                "limit": {
+                    "type": "integer"
+                },
+                "next": {
+                    "type": "string"
+                },
+                "page": {
+                    "type": "integer"
+                },
+                "total": {
+                    "type": "integer"
+                },
+                "totalPages": {
+                    "type": "integer"
+                }
+            }
+        },
+        "domain.DentistSchedule": {
+            "type": "object",
+            "properties": {
+                "breaks": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.ScheduleBreak"
+                    }
+                },
+                "dentistId": {
+                    "type": "integer"
+                },
+                "exceptions": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.ScheduleException"
+                    }
+                },
+                "workingHours": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.WorkingHours"
+                    }
+                }
+            }
+        },
         "domain.Patient": {
             "type": "object",
             "required": [
@@ -1188,6 +2971,114 @@ const docTemplate = `{
                 }
             }
         },
+        "domain.PatientPage": {
+            "type": "object",
+            "properties": {
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.Patient"
+                    }
+                },
+                "limit": {
+                    "type": "integer"
+                },
+                "next": {
+                    "type": "string"
+                },
+                "page": {
+                    "type": "integer"
+                },
+                "total": {
+                    "type": "integer"
+                },
+                "totalPages": {
+                    "type": "integer"
+                }
+            }
+        },
+        "domain.ScheduleBreak": {
+            "type": "object",
+            "required": [
+                "endTime",
+                "startTime"
+            ],
+            "properties": {
+                "endTime": {
+                    "type": "string"
+                },
+                "startTime": {
+                    "type": "string"
+                },
+                "weekday": {
+                    "type": "integer"
+                }
+            }
+        },
+        "domain.ScheduleException": {
+            "type": "object",
+            "required": [
+                "dateAndTime",
+                "endDateAndTime"
+            ],
+            "properties": {
+                "dateAndTime": {
+                    "type": "string"
+                },
+                "endDateAndTime": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "reason": {
+                    "type": "string"
+                }
+            }
+        },
+        "domain.Slot": {
+            "type": "object",
+            "properties": {
+                "dateAndTime": {
+                    "type": "string"
+                },
+                "dentistCRO": {
+                    "type": "string"
+                },
+                "endDateAndTime": {
+                    "type": "string"
+                }
+            }
+        },
+        "domain.WorkingHours": {
+            "type": "object",
+            "required": [
+                "endTime",
+                "startTime"
+            ],
+            "properties": {
+                "endTime": {
+                    "type": "string"
+                },
+                "startTime": {
+                    "type": "string"
+                },
+                "weekday": {
+                    "type": "integer"
+                }
+            }
+        },
+        "handler.cancelRequest": {
+            "type": "object",
+            "required": [
+                "reason"
+            ],
+            "properties": {
+                "reason": {
+                    "type": "string"
+                }
+            }
+        },
         "web.errorResponse": {
             "type": "object",
             "properties": {
@@ -1,23 +1,39 @@
 package appointment
 
 import (
+	"context"
 	"errors"
+	"fmt"
+	"github.com/go-kit/log/level"
 	"github.com/ronilsonalves/GoLang-in-a-spring-cloud-architecture/scheduling-service/internal/domain"
+	"github.com/ronilsonalves/GoLang-in-a-spring-cloud-architecture/scheduling-service/pkg/logging"
 	"github.com/ronilsonalves/GoLang-in-a-spring-cloud-architecture/scheduling-service/pkg/store"
-	"log"
 	"time"
 )
 
-var table = store.AP
+// ErrNotFound - returned when there's no appointment with the id provided.
+var ErrNotFound = errors.New("appointment not found")
+
+// ErrScheduleConflict - returned when the appointment overlaps another one of the same dentist or patient.
+var ErrScheduleConflict = errors.New("the date and time select aren't available for dentist or patient")
+
+// ErrOutsideWorkingHours - returned when the appointment is outside the dentist working hours, breaks and exceptions.
+var ErrOutsideWorkingHours = errors.New("the date and time select are outside the dentist working hours")
+
+// ErrClinicClosed - returned when the appointment overlaps a clinic closure.
+var ErrClinicClosed = errors.New("the clinic is closed at the date and time select")
+
+// ErrInvalidAppointment - returned when the date and time or the duration of the appointment are invalid, it's wrapped
+// along with the reason.
+var ErrInvalidAppointment = errors.New("invalid appointment")
 
 type Repository interface {
-	GetAll() (interface{}, error)
-	GetByID(entityId int) (interface{}, error)
-	GetAllByIdentityNumber(identityNumber string) (interface{}, error)
-	GetAllByLicenseNumber(licenseNumber string) (interface{}, error)
-	Create(a domain.Appointment) (interface{}, error)
-	Update(entityId int, a domain.Appointment) (interface{}, error)
-	Delete(entityId int) error
+	List(ctx context.Context, q domain.ListQuery[domain.AppointmentFilter]) (domain.Page[domain.AppointmentDTO], error)
+	GetByID(ctx context.Context, entityId int) (domain.AppointmentDTO, error)
+	Create(ctx context.Context, a domain.Appointment) (domain.AppointmentDTO, error)
+	Update(ctx context.Context, entityId int, a domain.Appointment) (domain.AppointmentDTO, error)
+	ChangeStatus(ctx context.Context, entityId int, status domain.AppointmentStatus, reason string) (domain.AppointmentDTO, error)
+	Delete(ctx context.Context, entityId int) error
 }
 
 type repository struct {
@@ -28,131 +44,76 @@ func NewRepository(store store.ApStore) Repository {
 	return &repository{store}
 }
 
-func (r *repository) GetAll() (interface{}, error) {
-	return r.store.GetAll(table)
-}
-
-func (r *repository) GetByID(entityId int) (interface{}, error) {
-	return r.store.GetByID(entityId, table)
+func (r *repository) List(ctx context.Context, q domain.ListQuery[domain.AppointmentFilter]) (domain.Page[domain.AppointmentDTO], error) {
+	return r.store.List(ctx, q.Normalize())
 }
 
-func (r *repository) GetAllByIdentityNumber(identityNumber string) (interface{}, error) {
-	return r.store.GetAllAppointmentsByPatientIdentify(identityNumber)
+func (r *repository) GetByID(ctx context.Context, entityId int) (domain.AppointmentDTO, error) {
+	return r.store.GetByID(ctx, entityId)
 }
 
-func (r *repository) GetAllByLicenseNumber(licenseNumber string) (interface{}, error) {
-	return r.store.GetAllAppointmentsByDentistsLicense(licenseNumber)
-}
-
-func (r *repository) Create(a domain.Appointment) (interface{}, error) {
-	if !r.isValidDate(a) {
-		return nil, errors.New("some data is invalid")
-	}
-	if !r.isADateTimeAvailable(a.DateAndTime, a.DentistCRO, a.PatientRG) {
-		return nil, errors.New("the date and time select aren't available for dentist or patient")
+func (r *repository) Create(ctx context.Context, a domain.Appointment) (domain.AppointmentDTO, error) {
+	if err := r.validate(ctx, a); err != nil {
+		return domain.AppointmentDTO{}, err
 	}
-	return r.store.Save(a, table)
+	saved, err := r.store.Save(ctx, domain.AppointmentDTO{Appointment: a})
+	return saved, scheduleError(err)
 }
 
-func (r *repository) Update(entityId int, a domain.Appointment) (interface{}, error) {
-	aInterface, err := r.GetAll()
-	if err != nil {
-		return nil, err
-	}
-	appointments, ok := aInterface.([]domain.AppointmentDTO)
-	if !ok {
-		return nil, err
+func (r *repository) Update(ctx context.Context, entityId int, a domain.Appointment) (domain.AppointmentDTO, error) {
+	if err := r.validate(ctx, a); err != nil {
+		return domain.AppointmentDTO{}, err
 	}
-
-	for _, appointment := range appointments {
-		if appointment.Id == entityId {
-			if !r.isValidDate(a) {
-				return nil, errors.New("some data is invalid")
-			}
-			if !r.isADateTimeAvailable(a.DateAndTime, a.DentistCRO, a.PatientRG) {
-				return nil, errors.New("the date and time select aren't available for dentist or patient")
-			}
-			return r.store.Update(entityId, a, table)
-		}
+	updated, err := r.store.Update(ctx, entityId, domain.AppointmentDTO{Appointment: a})
+	if errors.Is(err, store.ErrNotFound) {
+		return domain.AppointmentDTO{}, ErrNotFound
 	}
-	return nil, errors.New("appointment not found")
+	return updated, scheduleError(err)
 }
 
-func (r *repository) Delete(entityId int) error {
-	return r.store.Delete(entityId, table)
+func (r *repository) ChangeStatus(ctx context.Context, entityId int, status domain.AppointmentStatus, reason string) (domain.AppointmentDTO, error) {
+	changed, err := r.store.UpdateStatus(ctx, entityId, status, reason)
+	if errors.Is(err, store.ErrNotFound) {
+		return domain.AppointmentDTO{}, ErrNotFound
+	}
+	return changed, err
 }
 
-// isValidDate validate the fields provided to verify if everything is ok
-func (r *repository) isValidDate(a domain.Appointment) bool {
-	var appointments []domain.AppointmentDTO
-	aDateTimeToValidate, err := time.Parse("02/01/2006 15:04", a.DateAndTime)
-	if err != nil {
-		log.Fatalln("error while trying to validate date and time provided from request body ->", err.Error())
-		return false
+func (r *repository) Delete(ctx context.Context, entityId int) error {
+	err := r.store.Delete(ctx, entityId)
+	if errors.Is(err, store.ErrNotFound) {
+		return ErrNotFound
 	}
+	return err
+}
 
-	appointmentsInterface, err := r.GetAll()
+// validate - validate the fields provided to verify if everything is ok, the schedule and conflicts are checked by the
+// store while saving, so two concurrent requests can't book the same interval.
+func (r *repository) validate(ctx context.Context, a domain.Appointment) error {
+	aDateTimeToValidate, err := domain.ParseDateTime(a.DateAndTime)
 	if err != nil {
-		log.Fatalln("error: ", err.Error())
-		return false
+		level.Debug(logging.FromContext(ctx)).Log("msg", "invalid appointment date and time", "err", err)
+		return fmt.Errorf("%w: the date and time must be in format 30/01/2023 23:59", ErrInvalidAppointment)
 	}
-	appointments, ok := appointmentsInterface.([]domain.AppointmentDTO)
-	if !ok {
-		log.Fatalln("error parsing interface data fetched from db")
-		return false
+	if a.Duration < 0 {
+		return fmt.Errorf("%w: the duration can't be negative", ErrInvalidAppointment)
 	}
-
-	for _, appointment := range appointments {
-		if err != nil {
-			log.Println("error while trying to parse date and time when validating date_and_time provided")
-			return false
-		}
-		if a.Id == appointment.Id {
-			return aDateTimeToValidate.After(time.Now().Add(time.Hour))
-		}
+	if !aDateTimeToValidate.After(time.Now().Add(time.Hour)) {
+		return fmt.Errorf("%w: the date and time must be at least one hour ahead", ErrInvalidAppointment)
 	}
-	return aDateTimeToValidate.After(time.Now().Add(time.Hour))
+	return nil
 }
 
-// isADateTimeAvailable - verify if the date and time provided at request are available for both: patient and dentist
-func (r *repository) isADateTimeAvailable(dateTime string, d string, p string) bool {
-	dateTimeToVerify, err := time.Parse("02/01/2006 15:04", dateTime)
-	if err != nil {
-		log.Println("error while trying to parse datetime provided at request body")
-		return false
-	}
-	dateTimeEnd := dateTimeToVerify.Add(time.Hour * 1)
-	appointmentsByDateTime, err := r.store.GetAllAppointmentsByDateTimeInterval(dateTimeToVerify.Local().Add(time.Hour*3).String(), dateTimeEnd.Local().Add(time.Hour*3).String())
-	if err != nil {
-		log.Println("an error occurred while trying to get appointments with same date to validation")
-		return false
-	}
-
-	if len(appointmentsByDateTime) == 0 {
-		return true
-	}
-	for _, appointment := range appointmentsByDateTime {
-		appointmentDateTime, err := time.Parse("2006-01-02 15:04:05", appointment.DateAndTime)
-		if err != nil {
-			return false
-		}
-
-		if appointmentDateTime.Local().Add(time.Hour*3).String() == dateTimeToVerify.Local().Add(time.Hour*3).String() {
-			if d != appointment.DentistCRO && p != appointment.PatientRG {
-				return true
-			}
-			if d != appointment.DentistCRO {
-				if p == appointment.PatientRG {
-					return true
-				}
-			}
-			if d == appointment.DentistCRO {
-				if p != appointment.PatientRG {
-					return true
-				}
-			}
-		}
-		return true
+// scheduleError - the error of a booking the store refused, the dentist schedule, the clinic closures and the other
+// appointments are checked by the store while holding the dentist and patient locked.
+func scheduleError(err error) error {
+	switch {
+	case errors.Is(err, store.ErrScheduleConflict):
+		return ErrScheduleConflict
+	case errors.Is(err, store.ErrOutsideWorkingHours):
+		return fmt.Errorf("%w: %v", ErrOutsideWorkingHours, err)
+	case errors.Is(err, store.ErrClinicClosed):
+		return fmt.Errorf("%w: %v", ErrClinicClosed, err)
 	}
-	return false
+	return err
 }
@@ -1,19 +1,21 @@
 package appointment
 
 import (
+	"context"
 	"errors"
+	"fmt"
 	"github.com/ronilsonalves/GoLang-in-a-spring-cloud-architecture/scheduling-service/internal/domain"
-	"github.com/ronilsonalves/GoLang-in-a-spring-cloud-architecture/scheduling-service/pkg/amqp"
+	"github.com/ronilsonalves/GoLang-in-a-spring-cloud-architecture/scheduling-service/pkg/metrics"
+	"github.com/ronilsonalves/GoLang-in-a-spring-cloud-architecture/scheduling-service/pkg/store"
 )
 
 type Service interface {
-	GetAll() ([]domain.AppointmentDTO, error)
-	GetByID(id int) (domain.AppointmentDTO, error)
-	GetAllByIdentityNumber(identityNumber string) ([]domain.AppointmentDTO, error)
-	GetAllByLicenseNumber(licenseNumber string) ([]domain.AppointmentDTO, error)
-	Create(a domain.Appointment) (domain.AppointmentDTO, error)
-	Update(id int, a domain.Appointment) (domain.AppointmentDTO, error)
-	Delete(id int) error
+	List(ctx context.Context, q domain.ListQuery[domain.AppointmentFilter]) (domain.Page[domain.AppointmentDTO], error)
+	GetByID(ctx context.Context, id int) (domain.AppointmentDTO, error)
+	Create(ctx context.Context, a domain.Appointment) (domain.AppointmentDTO, error)
+	Update(ctx context.Context, id int, a domain.Appointment) (domain.AppointmentDTO, error)
+	ChangeStatus(ctx context.Context, id int, status domain.AppointmentStatus, reason string) (domain.AppointmentDTO, error)
+	Delete(ctx context.Context, id int) error
 }
 
 type service struct {
@@ -24,70 +26,46 @@ func NewService(r Repository) Service {
 	return &service{r}
 }
 
-func (s *service) GetAll() ([]domain.AppointmentDTO, error) {
-	list, err := s.r.GetAll()
-	if err != nil {
-		return nil, err
-	}
-	appointments, ok := list.([]domain.AppointmentDTO)
-	if !ok {
-		return nil, errors.New("an error occurred while trying to fetch data from database")
-	}
-	return appointments, nil
+// List - list the appointments, patients and dentists only see their own ones.
+func (s *service) List(ctx context.Context, q domain.ListQuery[domain.AppointmentFilter]) (domain.Page[domain.AppointmentDTO], error) {
+	if principal, ok := domain.PrincipalFromContext(ctx); ok {
+		filter, err := principal.ScopeAppointments(q.Filter)
+		if err != nil {
+			return domain.Page[domain.AppointmentDTO]{}, fmt.Errorf("%w: the appointments of other patients or dentists can't be listed", err)
+		}
+		q.Filter = filter
+	}
+	return s.r.List(ctx, q)
 }
 
-func (s *service) GetByID(id int) (domain.AppointmentDTO, error) {
-	aInterface, err := s.r.GetByID(id)
+func (s *service) GetByID(ctx context.Context, id int) (domain.AppointmentDTO, error) {
+	appointment, err := s.r.GetByID(ctx, id)
+	if errors.Is(err, store.ErrNotFound) {
+		return domain.AppointmentDTO{}, ErrNotFound
+	}
 	if err != nil {
 		return domain.AppointmentDTO{}, err
 	}
-	appointment, ok := aInterface.(domain.AppointmentDTO)
-	if !ok {
-		return appointment, errors.New("not found an appointment with id provided")
+	if err := checkAccess(ctx, appointment.Appointment); err != nil {
+		return domain.AppointmentDTO{}, err
 	}
 	return appointment, nil
 }
 
-func (s *service) GetAllByIdentityNumber(identityNumber string) ([]domain.AppointmentDTO, error) {
-	list, err := s.r.GetAllByIdentityNumber(identityNumber)
-	if err != nil {
-		return nil, err
-	}
-	appointments, ok := list.([]domain.AppointmentDTO)
-	if !ok {
-		return nil, errors.New("an error occurred while trying to fetch data from database")
-	}
-	return appointments, nil
-}
-
-func (s *service) GetAllByLicenseNumber(licenseNumber string) ([]domain.AppointmentDTO, error) {
-	list, err := s.r.GetAllByLicenseNumber(licenseNumber)
-	if err != nil {
-		return nil, err
-	}
-	appointments, ok := list.([]domain.AppointmentDTO)
-	if !ok {
-		return nil, errors.New("an error occurred while trying to fetch data from database")
+func (s *service) Create(ctx context.Context, a domain.Appointment) (domain.AppointmentDTO, error) {
+	if err := checkAccess(ctx, a); err != nil {
+		return domain.AppointmentDTO{}, err
 	}
-	return appointments, nil
-}
-
-func (s *service) Create(a domain.Appointment) (domain.AppointmentDTO, error) {
-	aSavedInterface, err := s.r.Create(a)
+	created, err := s.r.Create(ctx, a)
 	if err != nil {
 		return domain.AppointmentDTO{}, err
 	}
-	apSaved, ok := aSavedInterface.(domain.AppointmentDTO)
-	if ok {
-		amqp.PublishMessage(apSaved)
-		return apSaved, nil
-	}
-
-	return domain.AppointmentDTO{}, errors.New("failed to save a new appointment")
+	metrics.AppointmentCreated(created.DentistCRO)
+	return created, nil
 }
 
-func (s *service) Update(id int, a domain.Appointment) (domain.AppointmentDTO, error) {
-	aUpdate, err := s.GetByID(id)
+func (s *service) Update(ctx context.Context, id int, a domain.Appointment) (domain.AppointmentDTO, error) {
+	aUpdate, err := s.GetByID(ctx, id)
 	if err != nil {
 		return domain.AppointmentDTO{}, err
 	}
@@ -104,21 +82,51 @@ func (s *service) Update(id int, a domain.Appointment) (domain.AppointmentDTO, e
 	if a.PatientRG == "" {
 		a.PatientRG = aUpdate.PatientRG
 	}
+	if a.Procedure == "" {
+		a.Procedure = aUpdate.Procedure
+		if a.Duration == 0 {
+			a.Duration = aUpdate.Duration
+		}
+	}
 	a.Id = aUpdate.Id
+	if err := checkAccess(ctx, a); err != nil {
+		return domain.AppointmentDTO{}, err
+	}
+
+	return s.r.Update(ctx, id, a)
+}
 
-	updated, err := s.r.Update(id, a)
+// ChangeStatus - move an appointment through its lifecycle, every transition is published through the outbox so other
+// services can react.
+func (s *service) ChangeStatus(ctx context.Context, id int, status domain.AppointmentStatus, reason string) (domain.AppointmentDTO, error) {
+	if _, err := s.GetByID(ctx, id); err != nil {
+		return domain.AppointmentDTO{}, err
+	}
+	changed, err := s.r.ChangeStatus(ctx, id, status, reason)
 	if err != nil {
 		return domain.AppointmentDTO{}, err
 	}
-	response, ok := updated.(domain.AppointmentDTO)
-	if !ok {
-		return domain.AppointmentDTO{}, errors.New("failed to update appointment")
+	switch status {
+	case domain.StatusCancelled:
+		metrics.AppointmentCancelled(changed.DentistCRO)
+	case domain.StatusNoShow:
+		metrics.AppointmentNoShow(changed.DentistCRO)
 	}
+	return changed, nil
+}
 
-	amqp.PublishMessage(response)
-	return response, nil
+func (s *service) Delete(ctx context.Context, id int) error {
+	if _, err := s.GetByID(ctx, id); err != nil {
+		return err
+	}
+	return s.r.Delete(ctx, id)
 }
 
-func (s *service) Delete(id int) error {
-	return s.r.Delete(id)
-}
\ No newline at end of file
+// checkAccess - verify if the principal of the context, when there is one, can access the appointment provided.
+func checkAccess(ctx context.Context, a domain.Appointment) error {
+	principal, ok := domain.PrincipalFromContext(ctx)
+	if ok && !principal.CanAccess(a) {
+		return fmt.Errorf("%w: the appointment belongs to another patient or dentist", domain.ErrForbidden)
+	}
+	return nil
+}
@@ -0,0 +1,293 @@
+package appointment
+
+import (
+	"context"
+	"errors"
+	"github.com/ronilsonalves/GoLang-in-a-spring-cloud-architecture/scheduling-service/internal/domain"
+	"github.com/ronilsonalves/GoLang-in-a-spring-cloud-architecture/scheduling-service/internal/outbox"
+	"github.com/ronilsonalves/GoLang-in-a-spring-cloud-architecture/scheduling-service/pkg/broker"
+	"github.com/ronilsonalves/GoLang-in-a-spring-cloud-architecture/scheduling-service/pkg/store"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+// newTestService - a service on the memory stores with the dentists CRO-1 and CRO-2 and the patients RG-1 and RG-2,
+// along with the outbox of the appointment events.
+func newTestService(t *testing.T) (Service, store.OutboxStore) {
+	t.Helper()
+	ctx := context.Background()
+	people := store.NewMemoryOutbox()
+	dentists := store.NewMemoryDentist(people)
+	patients := store.NewMemoryPatient(people)
+	for _, cro := range []string{"CRO-1", "CRO-2"} {
+		if _, err := dentists.Save(ctx, domain.Dentist{Name: "Ana", LastName: "Souza", CRO: cro}); err != nil {
+			t.Fatalf("dentists.Save() error = %v", err)
+		}
+	}
+	for _, rg := range []string{"RG-1", "RG-2"} {
+		if _, err := patients.Save(ctx, domain.Patient{Name: "João", LastName: "Silva", RG: rg, CreatedAt: "30/01/2023 10:00:00"}); err != nil {
+			t.Fatalf("patients.Save() error = %v", err)
+		}
+	}
+	messages := store.NewMemoryOutbox()
+	appointments := store.NewMemoryAp(dentists, patients, store.NewMemorySchedule(dentists), store.NewMemoryClosure(), messages)
+	return NewService(NewRepository(appointments)), messages
+}
+
+// nextWeekAt - the date and time of next week at the hour and minute provided, so the appointments are always ahead.
+func nextWeekAt(hour, minute int) string {
+	year, month, day := time.Now().AddDate(0, 0, 7).Date()
+	return time.Date(year, month, day, hour, minute, 0, 0, time.Local).Format(domain.DateTimeLayout)
+}
+
+func newTestAppointment(cro, rg, dateAndTime string) domain.Appointment {
+	return domain.Appointment{Description: "Check-up", DentistCRO: cro, PatientRG: rg, DateAndTime: dateAndTime, Duration: 60}
+}
+
+func withPrincipal(principal domain.Principal) context.Context {
+	return domain.ContextWithPrincipal(context.Background(), principal)
+}
+
+// publishedEvents - the types of the events the relay publishes from the outbox provided.
+func publishedEvents(t *testing.T, messages store.OutboxStore) []domain.EventType {
+	t.Helper()
+	publisher := broker.NewMemoryPublisher()
+	relay := outbox.NewRelay(messages, publisher, outbox.RelayConfig{BatchSize: 100, ClaimTimeout: time.Minute, MaxAttempts: 3})
+	if err := relay.RelayPending(context.Background()); err != nil {
+		t.Fatalf("RelayPending() error = %v", err)
+	}
+	var types []domain.EventType
+	for _, event := range publisher.Events() {
+		types = append(types, event.Type)
+	}
+	return types
+}
+
+func TestServiceCreate(t *testing.T) {
+	receptionist := withPrincipal(domain.Principal{Roles: []string{domain.RoleReceptionist}})
+	patient := withPrincipal(domain.Principal{Roles: []string{domain.RolePatient}, RG: "RG-2"})
+
+	tests := []struct {
+		name        string
+		ctx         context.Context
+		appointment domain.Appointment
+		wantErr     error
+	}{
+		{"another dentist and patient at the same time", receptionist, newTestAppointment("CRO-2", "RG-2", nextWeekAt(10, 0)), nil},
+		{"right after the booked one", receptionist, newTestAppointment("CRO-1", "RG-2", nextWeekAt(11, 0)), nil},
+		{"patient booking for themselves", patient, newTestAppointment("CRO-2", "RG-2", nextWeekAt(14, 0)), nil},
+		{"patient booking for another patient", patient, newTestAppointment("CRO-2", "RG-1", nextWeekAt(14, 0)), domain.ErrForbidden},
+		{"dentist already booked", receptionist, newTestAppointment("CRO-1", "RG-2", nextWeekAt(10, 30)), ErrScheduleConflict},
+		{"patient already booked", receptionist, newTestAppointment("CRO-2", "RG-1", nextWeekAt(9, 30)), ErrScheduleConflict},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, _ := newTestService(t)
+			if _, err := s.Create(context.Background(), newTestAppointment("CRO-1", "RG-1", nextWeekAt(10, 0))); err != nil {
+				t.Fatalf("Create() of the booked appointment error = %v", err)
+			}
+
+			created, err := s.Create(tt.ctx, tt.appointment)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Create() error = %v, want %v", err, tt.wantErr)
+			}
+			if tt.wantErr != nil {
+				return
+			}
+			if created.Id == 0 || created.Status != domain.StatusScheduled {
+				t.Errorf("Create() = id %d, status %q, want a scheduled appointment", created.Id, created.Status)
+			}
+			if created.Dentist.CRO != tt.appointment.DentistCRO || created.Patient.RG != tt.appointment.PatientRG {
+				t.Errorf("Create() = dentist %q, patient %q, want them loaded", created.Dentist.CRO, created.Patient.RG)
+			}
+		})
+	}
+}
+
+func TestServiceCreateRefusesUnknownPeople(t *testing.T) {
+	s, messages := newTestService(t)
+	if _, err := s.Create(context.Background(), newTestAppointment("CRO-9", "RG-1", nextWeekAt(10, 0))); err == nil {
+		t.Fatal("Create() error = nil, want the unknown dentist refused")
+	}
+	if events := publishedEvents(t, messages); len(events) != 0 {
+		t.Errorf("published %v for an appointment refused", events)
+	}
+}
+
+func TestServiceCreateTellsWhyTheAppointmentIsInvalid(t *testing.T) {
+	withDuration := func(duration int) domain.Appointment {
+		a := newTestAppointment("CRO-1", "RG-1", nextWeekAt(10, 0))
+		a.Duration = duration
+		return a
+	}
+	tests := []struct {
+		name        string
+		appointment domain.Appointment
+		wantReason  string
+	}{
+		{"invalid date and time", newTestAppointment("CRO-1", "RG-1", "next week"), "format"},
+		{"within the next hour", newTestAppointment("CRO-1", "RG-1", time.Now().Add(30*time.Minute).Format(domain.DateTimeLayout)), "at least one hour ahead"},
+		{"negative duration", withDuration(-30), "can't be negative"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, _ := newTestService(t)
+			_, err := s.Create(context.Background(), tt.appointment)
+			if !errors.Is(err, ErrInvalidAppointment) {
+				t.Fatalf("Create() error = %v, want ErrInvalidAppointment", err)
+			}
+			if !strings.Contains(err.Error(), tt.wantReason) {
+				t.Errorf("Create() error = %q, want the reason %q", err, tt.wantReason)
+			}
+		})
+	}
+}
+
+func TestServiceCreateRefusesTheUnavailableTimes(t *testing.T) {
+	ctx := context.Background()
+	messages := store.NewMemoryOutbox()
+	dentists := store.NewMemoryDentist(messages)
+	patients := store.NewMemoryPatient(messages)
+	dentist, err := dentists.Save(ctx, domain.Dentist{Name: "Ana", LastName: "Souza", CRO: "CRO-1"})
+	if err != nil {
+		t.Fatalf("dentists.Save() error = %v", err)
+	}
+	if _, err := patients.Save(ctx, domain.Patient{Name: "João", LastName: "Silva", RG: "RG-1", CreatedAt: "30/01/2023 10:00:00"}); err != nil {
+		t.Fatalf("patients.Save() error = %v", err)
+	}
+	schedules := store.NewMemorySchedule(dentists)
+	weekday := time.Now().AddDate(0, 0, 7).Weekday()
+	weekly := domain.DentistSchedule{DentistID: dentist.Id, WorkingHours: []domain.WorkingHours{{Weekday: weekday, StartTime: "08:00", EndTime: "12:00"}}}
+	if _, err := schedules.SaveWeekly(ctx, weekly); err != nil {
+		t.Fatalf("SaveWeekly() error = %v", err)
+	}
+	closures := store.NewMemoryClosure()
+	if _, err := closures.Save(ctx, domain.ClinicClosure{DateAndTime: nextWeekAt(10, 0), EndDateAndTime: nextWeekAt(11, 0)}); err != nil {
+		t.Fatalf("closures.Save() error = %v", err)
+	}
+	s := NewService(NewRepository(store.NewMemoryAp(dentists, patients, schedules, closures, messages)))
+
+	tests := []struct {
+		name        string
+		dateAndTime string
+		wantErr     error
+	}{
+		{"within the working hours", nextWeekAt(8, 0), nil},
+		{"outside the working hours", nextWeekAt(14, 0), ErrOutsideWorkingHours},
+		{"while the clinic is closed", nextWeekAt(10, 30), ErrClinicClosed},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a := newTestAppointment("CRO-1", "RG-1", tt.dateAndTime)
+			a.Duration = 30
+			if _, err := s.Create(ctx, a); !errors.Is(err, tt.wantErr) {
+				t.Errorf("Create() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestServiceChangeStatusPublishesTheLifecycle(t *testing.T) {
+	s, messages := newTestService(t)
+	ctx := context.Background()
+	created, err := s.Create(ctx, newTestAppointment("CRO-1", "RG-1", nextWeekAt(10, 0)))
+	if err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+	for _, status := range []domain.AppointmentStatus{domain.StatusConfirmed, domain.StatusCheckedIn, domain.StatusCompleted} {
+		changed, err := s.ChangeStatus(ctx, created.Id, status, "")
+		if err != nil {
+			t.Fatalf("ChangeStatus(%s) error = %v", status, err)
+		}
+		if changed.Status != status {
+			t.Fatalf("ChangeStatus(%s) = %s", status, changed.Status)
+		}
+	}
+
+	if _, err := s.ChangeStatus(ctx, created.Id, domain.StatusCancelled, "patient asked"); !errors.Is(err, domain.ErrInvalidStatusTransition) {
+		t.Errorf("ChangeStatus() of a completed appointment error = %v, want ErrInvalidStatusTransition", err)
+	}
+	if _, err := s.Update(ctx, created.Id, domain.Appointment{DateAndTime: nextWeekAt(15, 0)}); !errors.Is(err, domain.ErrInvalidStatusTransition) {
+		t.Errorf("Update() of a completed appointment error = %v, want ErrInvalidStatusTransition", err)
+	}
+	if _, err := s.ChangeStatus(ctx, created.Id+1, domain.StatusConfirmed, ""); !errors.Is(err, ErrNotFound) {
+		t.Errorf("ChangeStatus() of a missing appointment error = %v, want ErrNotFound", err)
+	}
+
+	want := []domain.EventType{
+		domain.EventAppointmentCreated,
+		domain.EventAppointmentConfirmed,
+		domain.EventAppointmentCheckedIn,
+		domain.EventAppointmentCompleted,
+	}
+	if events := publishedEvents(t, messages); !reflect.DeepEqual(events, want) {
+		t.Errorf("published %v, want %v", events, want)
+	}
+}
+
+func TestServiceUpdateKeepsTheFieldsNotProvided(t *testing.T) {
+	s, messages := newTestService(t)
+	ctx := context.Background()
+	created, err := s.Create(ctx, newTestAppointment("CRO-1", "RG-1", nextWeekAt(10, 0)))
+	if err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+	updated, err := s.Update(ctx, created.Id, domain.Appointment{DateAndTime: nextWeekAt(15, 0)})
+	if err != nil {
+		t.Fatalf("Update() error = %v", err)
+	}
+	if updated.DateAndTime != nextWeekAt(15, 0) || updated.Description != "Check-up" || updated.DentistCRO != "CRO-1" || updated.PatientRG != "RG-1" {
+		t.Errorf("Update() = %+v, want only the date and time changed", updated.Appointment)
+	}
+	if updated.EndDateAndTime != nextWeekAt(16, 0) {
+		t.Errorf("EndDateAndTime = %q, want the duration kept", updated.EndDateAndTime)
+	}
+	want := []domain.EventType{domain.EventAppointmentCreated, domain.EventAppointmentRescheduled}
+	if events := publishedEvents(t, messages); !reflect.DeepEqual(events, want) {
+		t.Errorf("published %v, want %v", events, want)
+	}
+}
+
+func TestServiceScopesThePrincipal(t *testing.T) {
+	s, _ := newTestService(t)
+	created, err := s.Create(context.Background(), newTestAppointment("CRO-1", "RG-1", nextWeekAt(10, 0)))
+	if err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+
+	tests := []struct {
+		name      string
+		principal domain.Principal
+		wantErr   error
+		wantItems int
+	}{
+		{"receptionist", domain.Principal{Roles: []string{domain.RoleReceptionist}}, nil, 1},
+		{"patient of the appointment", domain.Principal{Roles: []string{domain.RolePatient}, RG: "RG-1"}, nil, 1},
+		{"another patient", domain.Principal{Roles: []string{domain.RolePatient}, RG: "RG-2"}, domain.ErrForbidden, 0},
+		{"dentist of the appointment", domain.Principal{Roles: []string{domain.RoleDentist}, CRO: "CRO-1"}, nil, 1},
+		{"another dentist", domain.Principal{Roles: []string{domain.RoleDentist}, CRO: "CRO-2"}, domain.ErrForbidden, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := withPrincipal(tt.principal)
+			if _, err := s.GetByID(ctx, created.Id); !errors.Is(err, tt.wantErr) {
+				t.Errorf("GetByID() error = %v, want %v", err, tt.wantErr)
+			}
+			// the appointment is only deleted when refused, so it's left for the next cases
+			if tt.wantErr != nil {
+				if err := s.Delete(ctx, created.Id); !errors.Is(err, tt.wantErr) {
+					t.Errorf("Delete() error = %v, want %v", err, tt.wantErr)
+				}
+			}
+			page, err := s.List(ctx, domain.ListQuery[domain.AppointmentFilter]{})
+			if err != nil {
+				t.Fatalf("List() error = %v", err)
+			}
+			if len(page.Items) != tt.wantItems {
+				t.Errorf("List() = %d appointments, want %d", len(page.Items), tt.wantItems)
+			}
+		})
+	}
+}
@@ -0,0 +1,57 @@
+package availability
+
+import (
+	"context"
+	"errors"
+	"github.com/ronilsonalves/GoLang-in-a-spring-cloud-architecture/scheduling-service/internal/domain"
+	"github.com/ronilsonalves/GoLang-in-a-spring-cloud-architecture/scheduling-service/pkg/store"
+	"time"
+)
+
+type Repository interface {
+	GetDentists(ctx context.Context, licenseNumber string) ([]domain.Dentist, error)
+	GetSchedules(ctx context.Context, start, end time.Time) (map[int]domain.DentistSchedule, error)
+	GetAppointments(ctx context.Context, start, end time.Time) ([]domain.Appointment, error)
+	GetClosures(ctx context.Context, start, end time.Time) ([]domain.ClinicClosure, error)
+}
+
+type repository struct {
+	dentists     store.Store[domain.Dentist]
+	appointments store.ApStore
+	schedules    store.ScheduleStore
+	closures     store.ClosureStore
+}
+
+func NewRepository(dentists store.Store[domain.Dentist], appointments store.ApStore, schedules store.ScheduleStore, closures store.ClosureStore) Repository {
+	return &repository{dentists, appointments, schedules, closures}
+}
+
+// GetDentists - returns the dentist with the license number provided, or every dentist when it's empty
+func (r *repository) GetDentists(ctx context.Context, licenseNumber string) ([]domain.Dentist, error) {
+	dentists, err := r.dentists.GetAll(ctx)
+	if err != nil || licenseNumber == "" {
+		return dentists, err
+	}
+	for _, dentist := range dentists {
+		if dentist.CRO == licenseNumber {
+			return []domain.Dentist{dentist}, nil
+		}
+	}
+	return nil, errors.New("dentist not found")
+}
+
+// GetSchedules - returns the schedules configured keyed by dentist ID, with the exceptions overlapping the interval
+// provided. Dentists left out have no schedule and are available the whole day.
+func (r *repository) GetSchedules(ctx context.Context, start, end time.Time) (map[int]domain.DentistSchedule, error) {
+	return r.schedules.GetAllByDateTimeInterval(ctx, start, end)
+}
+
+// GetAppointments - returns every appointment overlapping the interval provided
+func (r *repository) GetAppointments(ctx context.Context, start, end time.Time) ([]domain.Appointment, error) {
+	return r.appointments.GetAllAppointmentsByDateTimeInterval(ctx, start, end)
+}
+
+// GetClosures - returns every clinic closure overlapping the interval provided
+func (r *repository) GetClosures(ctx context.Context, start, end time.Time) ([]domain.ClinicClosure, error) {
+	return r.closures.GetAllByDateTimeInterval(ctx, start, end)
+}
@@ -0,0 +1,136 @@
+package availability
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/ronilsonalves/GoLang-in-a-spring-cloud-architecture/scheduling-service/internal/domain"
+	"sort"
+	"time"
+)
+
+// MaxSearchInterval - the longest period a single free slots search may cover.
+const MaxSearchInterval = 31 * 24 * time.Hour
+
+type Service interface {
+	FindSlots(ctx context.Context, q domain.SlotQuery) ([]domain.Slot, error)
+}
+
+type service struct {
+	r Repository
+}
+
+func NewService(r Repository) Service {
+	return &service{r}
+}
+
+// FindSlots - return the free slots of the duration provided between q.From and q.To, computed from the dentists
+// working hours, their appointments and the clinic closures. Slots start one hour from now at least, as appointments do.
+// Patients only search around their own appointments.
+func (s *service) FindSlots(ctx context.Context, q domain.SlotQuery) ([]domain.Slot, error) {
+	if principal, ok := domain.PrincipalFromContext(ctx); ok {
+		scoped, err := principal.ScopeSlotQuery(q)
+		if err != nil {
+			return nil, fmt.Errorf("%w: the appointments of other patients can't be searched", err)
+		}
+		q = scoped
+	}
+	if q.Duration <= 0 {
+		q.Duration = domain.DefaultDuration
+	}
+	if !q.To.After(q.From) {
+		return nil, errors.New("the end of the search must be after its start")
+	}
+	if q.To.Sub(q.From) > MaxSearchInterval {
+		return nil, errors.New("the search must cover up to 31 days")
+	}
+	if earliest := time.Now().Add(time.Hour); q.From.Before(earliest) {
+		q.From = earliest
+	}
+	if !q.To.After(q.From) {
+		return []domain.Slot{}, nil
+	}
+	search := domain.Interval{Start: q.From, End: q.To}
+
+	dentists, err := s.r.GetDentists(ctx, q.DentistCRO)
+	if err != nil {
+		return nil, err
+	}
+	appointments, err := s.r.GetAppointments(ctx, q.From, q.To)
+	if err != nil {
+		return nil, err
+	}
+	closures, err := s.r.GetClosures(ctx, q.From, q.To)
+	if err != nil {
+		return nil, err
+	}
+	// the whole days are loaded, the exceptions of the first and last days shape the intervals clamped to the search
+	schedules, err := s.r.GetSchedules(ctx, startOfDay(q.From), startOfDay(q.To).AddDate(0, 0, 1))
+	if err != nil {
+		return nil, err
+	}
+
+	var closed []domain.Interval
+	for _, closure := range closures {
+		if interval, err := closure.Interval(); err == nil {
+			closed = append(closed, interval)
+		}
+	}
+
+	duration := time.Duration(q.Duration) * time.Minute
+	slots := []domain.Slot{}
+	for _, dentist := range dentists {
+		schedule := schedules[dentist.Id]
+		busy := append([]domain.Interval(nil), closed...)
+		for _, appointment := range appointments {
+			if !appointment.Status.BlocksSchedule() {
+				continue
+			}
+			if appointment.DentistCRO != dentist.CRO && (q.PatientRG == "" || appointment.PatientRG != q.PatientRG) {
+				continue
+			}
+			if start, end, err := appointment.Interval(); err == nil {
+				busy = append(busy, domain.Interval{Start: start, End: end})
+			}
+		}
+
+		// the days are joined first, so a dentist available around midnight has slots across it
+		var available []domain.Interval
+		for day := startOfDay(q.From); day.Before(q.To); day = day.AddDate(0, 0, 1) {
+			available = append(available, schedule.AvailableIntervals(day)...)
+		}
+		for _, interval := range domain.SubtractIntervals(domain.MergeIntervals(available), busy) {
+			interval = clamp(interval, search)
+			for start := interval.Start; !start.Add(duration).After(interval.End); start = start.Add(duration) {
+				slots = append(slots, domain.Slot{
+					DentistCRO:     dentist.CRO,
+					DateAndTime:    start.Format(domain.DateTimeLayout),
+					EndDateAndTime: start.Add(duration).Format(domain.DateTimeLayout),
+				})
+			}
+		}
+	}
+
+	sort.SliceStable(slots, func(i, j int) bool {
+		a, _ := domain.ParseDateTime(slots[i].DateAndTime)
+		b, _ := domain.ParseDateTime(slots[j].DateAndTime)
+		return a.Before(b)
+	})
+	return slots, nil
+}
+
+// clamp - restrict the interval to the limits provided, the result is empty when they don't overlap.
+func clamp(interval, limits domain.Interval) domain.Interval {
+	if interval.Start.Before(limits.Start) {
+		interval.Start = limits.Start
+	}
+	if interval.End.After(limits.End) {
+		interval.End = limits.End
+	}
+	return interval
+}
+
+func startOfDay(t time.Time) time.Time {
+	year, month, day := t.Date()
+	return time.Date(year, month, day, 0, 0, 0, 0, t.Location())
+}
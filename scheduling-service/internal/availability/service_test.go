@@ -0,0 +1,104 @@
+package availability
+
+import (
+	"context"
+	"errors"
+	"github.com/ronilsonalves/GoLang-in-a-spring-cloud-architecture/scheduling-service/internal/domain"
+	"testing"
+	"time"
+)
+
+// fakeRepository - the dentists, schedules and appointments of the tests, counting the schedules loaded.
+type fakeRepository struct {
+	dentists      []domain.Dentist
+	schedules     map[int]domain.DentistSchedule
+	appointments  []domain.Appointment
+	scheduleLoads int
+}
+
+func (r *fakeRepository) GetDentists(ctx context.Context, licenseNumber string) ([]domain.Dentist, error) {
+	return r.dentists, nil
+}
+
+func (r *fakeRepository) GetSchedules(ctx context.Context, start, end time.Time) (map[int]domain.DentistSchedule, error) {
+	r.scheduleLoads++
+	return r.schedules, nil
+}
+
+func (r *fakeRepository) GetAppointments(ctx context.Context, start, end time.Time) ([]domain.Appointment, error) {
+	return r.appointments, nil
+}
+
+func (r *fakeRepository) GetClosures(ctx context.Context, start, end time.Time) ([]domain.ClinicClosure, error) {
+	return nil, nil
+}
+
+func TestFindSlotsScopesThePatient(t *testing.T) {
+	// a week ahead, so the slots aren't cut by the one hour notice
+	year, month, day := time.Now().AddDate(0, 0, 7).Date()
+	at := func(hour int) time.Time { return time.Date(year, month, day, hour, 0, 0, 0, time.Local) }
+
+	repository := &fakeRepository{
+		dentists: []domain.Dentist{{Id: 1, CRO: "CRO-1"}, {Id: 2, CRO: "CRO-2"}},
+		// the second dentist has no schedule and is available the whole day
+		schedules: map[int]domain.DentistSchedule{
+			1: {DentistID: 1, WorkingHours: []domain.WorkingHours{{Weekday: at(0).Weekday(), StartTime: "09:00", EndTime: "10:00"}}},
+		},
+		appointments: []domain.Appointment{
+			{DentistCRO: "CRO-3", PatientRG: "RG-2", DateAndTime: at(8).Format(domain.DateTimeLayout), Duration: 60},
+		},
+	}
+	s := NewService(repository)
+
+	staff := domain.Principal{Roles: []string{domain.RoleReceptionist}}
+	patient := domain.Principal{Roles: []string{domain.RolePatient}, RG: "RG-1"}
+	bookedPatient := domain.Principal{Roles: []string{domain.RolePatient}, RG: "RG-2"}
+	dentist := domain.Principal{Roles: []string{domain.RoleDentist}, CRO: "CRO-1"}
+
+	tests := []struct {
+		name      string
+		principal *domain.Principal
+		patientRG string
+		wantSlots int
+		wantErr   error
+	}{
+		{"staff excluding the bookings of a patient", &staff, "RG-2", 2, nil},
+		{"staff without patient", &staff, "", 3, nil},
+		{"patient searching around their appointments", &patient, "", 3, nil},
+		{"booked patient searching around their appointments", &bookedPatient, "", 2, nil},
+		{"patient asking for their own appointments", &bookedPatient, "RG-2", 2, nil},
+		{"patient asking for another patient", &patient, "RG-2", 0, domain.ErrForbidden},
+		{"dentist asking for a patient", &dentist, "RG-2", 0, domain.ErrForbidden},
+		{"dentist without patient", &dentist, "", 3, nil},
+		{"service itself", nil, "RG-2", 2, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			if tt.principal != nil {
+				ctx = domain.ContextWithPrincipal(ctx, *tt.principal)
+			}
+			slots, err := s.FindSlots(ctx, domain.SlotQuery{PatientRG: tt.patientRG, From: at(8), To: at(10), Duration: 60})
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("FindSlots() error = %v, want %v", err, tt.wantErr)
+			}
+			if len(slots) != tt.wantSlots {
+				t.Errorf("FindSlots() = %v, want %d slots", slots, tt.wantSlots)
+			}
+		})
+	}
+}
+
+func TestFindSlotsLoadsTheSchedulesOnce(t *testing.T) {
+	repository := &fakeRepository{schedules: map[int]domain.DentistSchedule{}}
+	for id := 1; id <= 20; id++ {
+		repository.dentists = append(repository.dentists, domain.Dentist{Id: id})
+	}
+	from := time.Now().AddDate(0, 0, 1)
+	if _, err := NewService(repository).FindSlots(context.Background(), domain.SlotQuery{From: from, To: from.AddDate(0, 0, 7)}); err != nil {
+		t.Fatalf("FindSlots() error = %v", err)
+	}
+	if repository.scheduleLoads != 1 {
+		t.Errorf("schedules loaded %d times, want once for every dentist", repository.scheduleLoads)
+	}
+}
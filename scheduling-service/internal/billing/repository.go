@@ -0,0 +1,24 @@
+package billing
+
+import (
+	"context"
+	"github.com/ronilsonalves/GoLang-in-a-spring-cloud-architecture/scheduling-service/internal/domain"
+	"github.com/ronilsonalves/GoLang-in-a-spring-cloud-architecture/scheduling-service/pkg/store"
+	"time"
+)
+
+type Repository interface {
+	UpdateBilling(ctx context.Context, appointmentID int, billing domain.Billing, occurredAt time.Time) error
+}
+
+type repository struct {
+	appointments store.ApStore
+}
+
+func NewRepository(appointments store.ApStore) Repository {
+	return &repository{appointments}
+}
+
+func (r *repository) UpdateBilling(ctx context.Context, appointmentID int, billing domain.Billing, occurredAt time.Time) error {
+	return r.appointments.UpdateBilling(ctx, appointmentID, billing, occurredAt)
+}
@@ -0,0 +1,56 @@
+package billing
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/go-kit/log/level"
+	"github.com/ronilsonalves/GoLang-in-a-spring-cloud-architecture/scheduling-service/internal/domain"
+	"github.com/ronilsonalves/GoLang-in-a-spring-cloud-architecture/scheduling-service/pkg/logging"
+	"github.com/ronilsonalves/GoLang-in-a-spring-cloud-architecture/scheduling-service/pkg/store"
+)
+
+// RoutingKeys - the invoice events the billing of the appointments is kept from.
+var RoutingKeys = []string{
+	string(domain.EventInvoiceCreated),
+	string(domain.EventInvoicePaid),
+	string(domain.EventInvoiceCancelled),
+}
+
+type Service interface {
+	HandleInvoiceEvent(ctx context.Context, event domain.Event) error
+}
+
+type service struct {
+	r Repository
+}
+
+func NewService(r Repository) Service {
+	return &service{r}
+}
+
+// HandleInvoiceEvent - keep the invoice and payment status reported by an invoice event at its appointment, other
+// events and the ones about appointments since deleted are ignored.
+func (s *service) HandleInvoiceEvent(ctx context.Context, event domain.Event) error {
+	status, ok := domain.InvoicePaymentStatus(event.Type)
+	if !ok {
+		return nil
+	}
+	var invoice domain.InvoicePayload
+	if err := json.Unmarshal(event.Payload, &invoice); err != nil {
+		return fmt.Errorf("%w: %s %s: %s", domain.ErrInvalidEvent, event.Type, event.ID, err)
+	}
+	if invoice.ID == "" || invoice.AppointmentID == 0 {
+		return fmt.Errorf("%w: %s %s without the invoice id or appointment id", domain.ErrInvalidEvent, event.Type, event.ID)
+	}
+
+	billing := domain.Billing{InvoiceID: invoice.ID, PaymentStatus: status}
+	err := s.r.UpdateBilling(ctx, invoice.AppointmentID, billing, event.OccurredAt.Local())
+	if errors.Is(err, store.ErrNotFound) {
+		level.Warn(logging.FromContext(ctx)).Log("msg", "ignoring the invoice event, its appointment doesn't exist",
+			"event_type", event.Type, "event_id", event.ID, "appointment_id", invoice.AppointmentID)
+		return nil
+	}
+	return err
+}
@@ -0,0 +1,194 @@
+package billing
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"github.com/ronilsonalves/GoLang-in-a-spring-cloud-architecture/scheduling-service/internal/domain"
+	"github.com/ronilsonalves/GoLang-in-a-spring-cloud-architecture/scheduling-service/pkg/store"
+	"testing"
+	"time"
+)
+
+// newTestService - a service on the memory stores along with the store of its appointments and the id of the one
+// booked.
+func newTestService(t *testing.T) (Service, store.ApStore, int) {
+	t.Helper()
+	ctx := context.Background()
+	messages := store.NewMemoryOutbox()
+	dentists := store.NewMemoryDentist(messages)
+	patients := store.NewMemoryPatient(messages)
+	if _, err := dentists.Save(ctx, domain.Dentist{Name: "Ana", LastName: "Souza", CRO: "CRO-1"}); err != nil {
+		t.Fatalf("dentists.Save() error = %v", err)
+	}
+	if _, err := patients.Save(ctx, domain.Patient{Name: "João", LastName: "Silva", RG: "RG-1", CreatedAt: "30/01/2023 10:00:00"}); err != nil {
+		t.Fatalf("patients.Save() error = %v", err)
+	}
+	appointments := store.NewMemoryAp(dentists, patients, store.NewMemorySchedule(dentists), store.NewMemoryClosure(), messages)
+	year, month, day := time.Now().AddDate(0, 0, 7).Date()
+	booked, err := appointments.Save(ctx, domain.AppointmentDTO{Appointment: domain.Appointment{
+		Description: "Check-up",
+		DateAndTime: time.Date(year, month, day, 10, 0, 0, 0, time.Local).Format(domain.DateTimeLayout),
+		DentistCRO:  "CRO-1",
+		PatientRG:   "RG-1",
+		Duration:    60,
+	}})
+	if err != nil {
+		t.Fatalf("appointments.Save() error = %v", err)
+	}
+	return NewService(NewRepository(appointments)), appointments, booked.Id
+}
+
+// invoiceEvent - an invoice event with the payload provided, occurred at the time provided.
+func invoiceEvent(t *testing.T, eventType domain.EventType, payload interface{}, occurredAt time.Time) domain.Event {
+	t.Helper()
+	event, err := domain.NewEvent(eventType, payload)
+	if err != nil {
+		t.Fatalf("NewEvent() error = %v", err)
+	}
+	event.OccurredAt = occurredAt
+	return event
+}
+
+func TestHandleInvoiceEvent(t *testing.T) {
+	occurredAt := time.Date(2023, 1, 30, 10, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name        string
+		eventType   domain.EventType
+		payload     func(appointmentID int) interface{}
+		wantErr     error
+		wantBilling *domain.Billing
+	}{
+		{
+			name:        "created",
+			eventType:   domain.EventInvoiceCreated,
+			payload:     func(id int) interface{} { return domain.InvoicePayload{ID: "inv-1", AppointmentID: id} },
+			wantBilling: &domain.Billing{InvoiceID: "inv-1", PaymentStatus: domain.PaymentUnpaid},
+		},
+		{
+			name:        "paid",
+			eventType:   domain.EventInvoicePaid,
+			payload:     func(id int) interface{} { return domain.InvoicePayload{ID: "inv-1", AppointmentID: id} },
+			wantBilling: &domain.Billing{InvoiceID: "inv-1", PaymentStatus: domain.PaymentPaid},
+		},
+		{
+			name:        "cancelled",
+			eventType:   domain.EventInvoiceCancelled,
+			payload:     func(id int) interface{} { return domain.InvoicePayload{ID: "inv-1", AppointmentID: id} },
+			wantBilling: &domain.Billing{InvoiceID: "inv-1", PaymentStatus: domain.PaymentCancelled},
+		},
+		{
+			name:      "without the invoice id",
+			eventType: domain.EventInvoicePaid,
+			payload:   func(id int) interface{} { return domain.InvoicePayload{AppointmentID: id} },
+			wantErr:   domain.ErrInvalidEvent,
+		},
+		{
+			name:      "without the appointment id",
+			eventType: domain.EventInvoicePaid,
+			payload:   func(int) interface{} { return domain.InvoicePayload{ID: "inv-1"} },
+			wantErr:   domain.ErrInvalidEvent,
+		},
+		{
+			name:      "payload of another shape",
+			eventType: domain.EventInvoicePaid,
+			payload:   func(int) interface{} { return []string{"inv-1"} },
+			wantErr:   domain.ErrInvalidEvent,
+		},
+		{
+			name:      "appointment since deleted",
+			eventType: domain.EventInvoicePaid,
+			payload:   func(id int) interface{} { return domain.InvoicePayload{ID: "inv-1", AppointmentID: id + 1} },
+		},
+		{
+			name:      "not an invoice event",
+			eventType: domain.EventAppointmentCreated,
+			payload:   func(id int) interface{} { return domain.InvoicePayload{ID: "inv-1", AppointmentID: id} },
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, appointments, id := newTestService(t)
+			event := invoiceEvent(t, tt.eventType, tt.payload(id), occurredAt)
+			if err := s.HandleInvoiceEvent(context.Background(), event); !errors.Is(err, tt.wantErr) {
+				t.Fatalf("HandleInvoiceEvent() error = %v, want %v", err, tt.wantErr)
+			}
+			stored, err := appointments.GetByID(context.Background(), id)
+			if err != nil {
+				t.Fatalf("GetByID() error = %v", err)
+			}
+			if tt.wantBilling == nil {
+				if stored.Billing != nil {
+					t.Errorf("Billing = %+v, want none", *stored.Billing)
+				}
+				return
+			}
+			tt.wantBilling.UpdatedAt = occurredAt.Local().Format(domain.DateTimeLayout)
+			if stored.Billing == nil || *stored.Billing != *tt.wantBilling {
+				t.Errorf("Billing = %+v, want %+v", stored.Billing, *tt.wantBilling)
+			}
+		})
+	}
+}
+
+func TestHandleInvoiceEventIgnoresTheOlderReports(t *testing.T) {
+	s, appointments, id := newTestService(t)
+	paidAt := time.Date(2023, 1, 30, 10, 0, 0, 0, time.UTC)
+	invoice := domain.InvoicePayload{ID: "inv-1", AppointmentID: id}
+	for _, event := range []domain.Event{
+		invoiceEvent(t, domain.EventInvoicePaid, invoice, paidAt),
+		// delivered again after the payment, e.g. replayed from the dead letters
+		invoiceEvent(t, domain.EventInvoiceCreated, invoice, paidAt.Add(-time.Hour)),
+	} {
+		if err := s.HandleInvoiceEvent(context.Background(), event); err != nil {
+			t.Fatalf("HandleInvoiceEvent(%s) error = %v", event.Type, err)
+		}
+	}
+	stored, err := appointments.GetByID(context.Background(), id)
+	if err != nil {
+		t.Fatalf("GetByID() error = %v", err)
+	}
+	if stored.Billing == nil || stored.Billing.PaymentStatus != domain.PaymentPaid {
+		t.Errorf("Billing = %+v, want the payment kept", stored.Billing)
+	}
+}
+
+// TestHandleInvoiceEventReadsTheContract - the invoice.paid message of the contract documented at the README, as the
+// invoice service publishes it in the envelope format.
+func TestHandleInvoiceEventReadsTheContract(t *testing.T) {
+	s, appointments, id := newTestService(t)
+	if id != 1 {
+		t.Fatalf("booked the appointment %d, want the one of the contract example", id)
+	}
+	message := []byte(`{
+		"eventId": "7d9f3c2e-5a41-4f8b-9a0e-2c6b1d8e4f10",
+		"eventType": "invoice.paid",
+		"occurredAt": "2023-01-30T13:00:00Z",
+		"schemaVersion": 1,
+		"payload": {
+			"id": "0f8fad5b-d9cb-469f-a165-70867728950e",
+			"appointmentId": 1,
+			"price": 99.0,
+			"dueDate": "2023-02-04"
+		}
+	}`)
+	var event domain.Event
+	if err := json.Unmarshal(message, &event); err != nil {
+		t.Fatalf("json.Unmarshal() error = %v", err)
+	}
+	if err := s.HandleInvoiceEvent(context.Background(), event); err != nil {
+		t.Fatalf("HandleInvoiceEvent() error = %v", err)
+	}
+	stored, err := appointments.GetByID(context.Background(), id)
+	if err != nil {
+		t.Fatalf("GetByID() error = %v", err)
+	}
+	want := domain.Billing{
+		InvoiceID:     "0f8fad5b-d9cb-469f-a165-70867728950e",
+		PaymentStatus: domain.PaymentPaid,
+		UpdatedAt:     event.OccurredAt.Local().Format(domain.DateTimeLayout),
+	}
+	if stored.Billing == nil || *stored.Billing != want {
+		t.Errorf("Billing = %+v, want %+v", stored.Billing, want)
+	}
+}
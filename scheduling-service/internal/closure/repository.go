@@ -0,0 +1,42 @@
+package closure
+
+import (
+	"context"
+	"errors"
+	"github.com/ronilsonalves/GoLang-in-a-spring-cloud-architecture/scheduling-service/internal/domain"
+	"github.com/ronilsonalves/GoLang-in-a-spring-cloud-architecture/scheduling-service/pkg/store"
+)
+
+type Repository interface {
+	GetAll(ctx context.Context) ([]domain.ClinicClosure, error)
+	Create(ctx context.Context, c domain.ClinicClosure) (domain.ClinicClosure, error)
+	Delete(ctx context.Context, id int) error
+}
+
+type repository struct {
+	store store.ClosureStore
+}
+
+func NewRepository(store store.ClosureStore) Repository {
+	return &repository{store}
+}
+
+// GetAll - returns all clinic closures at database
+func (r *repository) GetAll(ctx context.Context) ([]domain.ClinicClosure, error) {
+	return r.store.GetAll(ctx)
+}
+
+func (r *repository) Create(ctx context.Context, c domain.ClinicClosure) (domain.ClinicClosure, error) {
+	if _, err := c.Interval(); err != nil {
+		return domain.ClinicClosure{}, err
+	}
+	return r.store.Save(ctx, c)
+}
+
+func (r *repository) Delete(ctx context.Context, id int) error {
+	err := r.store.Delete(ctx, id)
+	if errors.Is(err, store.ErrNotFound) {
+		return errors.New("clinic closure not found")
+	}
+	return err
+}
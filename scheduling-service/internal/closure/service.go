@@ -0,0 +1,32 @@
+package closure
+
+import (
+	"context"
+	"github.com/ronilsonalves/GoLang-in-a-spring-cloud-architecture/scheduling-service/internal/domain"
+)
+
+type Service interface {
+	GetAll(ctx context.Context) ([]domain.ClinicClosure, error)
+	Create(ctx context.Context, c domain.ClinicClosure) (domain.ClinicClosure, error)
+	Delete(ctx context.Context, id int) error
+}
+
+type service struct {
+	r Repository
+}
+
+func NewService(r Repository) Service {
+	return &service{r}
+}
+
+func (s *service) GetAll(ctx context.Context) ([]domain.ClinicClosure, error) {
+	return s.r.GetAll(ctx)
+}
+
+func (s *service) Create(ctx context.Context, c domain.ClinicClosure) (domain.ClinicClosure, error) {
+	return s.r.Create(ctx, c)
+}
+
+func (s *service) Delete(ctx context.Context, id int) error {
+	return s.r.Delete(ctx, id)
+}
@@ -0,0 +1,50 @@
+package deadletter
+
+import (
+	"context"
+	"errors"
+	"github.com/ronilsonalves/GoLang-in-a-spring-cloud-architecture/scheduling-service/internal/domain"
+	"github.com/ronilsonalves/GoLang-in-a-spring-cloud-architecture/scheduling-service/pkg/store"
+)
+
+// ErrNotFound - there's no dead letter with the ID provided.
+var ErrNotFound = errors.New("dead letter not found")
+
+type Repository interface {
+	List(ctx context.Context, q domain.ListQuery[domain.DeadLetterFilter]) (domain.Page[domain.DeadLetter], error)
+	GetByID(ctx context.Context, id int64) (domain.DeadLetter, error)
+	Create(ctx context.Context, letter domain.DeadLetter) (domain.DeadLetter, error)
+	Delete(ctx context.Context, id int64) error
+}
+
+type repository struct {
+	store store.DeadLetterStore
+}
+
+func NewRepository(store store.DeadLetterStore) Repository {
+	return &repository{store}
+}
+
+func (r *repository) List(ctx context.Context, q domain.ListQuery[domain.DeadLetterFilter]) (domain.Page[domain.DeadLetter], error) {
+	return r.store.List(ctx, q.Normalize())
+}
+
+func (r *repository) GetByID(ctx context.Context, id int64) (domain.DeadLetter, error) {
+	letter, err := r.store.GetByID(ctx, id)
+	if errors.Is(err, store.ErrNotFound) {
+		return domain.DeadLetter{}, ErrNotFound
+	}
+	return letter, err
+}
+
+func (r *repository) Create(ctx context.Context, letter domain.DeadLetter) (domain.DeadLetter, error) {
+	return r.store.Add(ctx, letter)
+}
+
+func (r *repository) Delete(ctx context.Context, id int64) error {
+	err := r.store.Delete(ctx, id)
+	if errors.Is(err, store.ErrNotFound) {
+		return ErrNotFound
+	}
+	return err
+}
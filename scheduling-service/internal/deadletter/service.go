@@ -0,0 +1,64 @@
+package deadletter
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/ronilsonalves/GoLang-in-a-spring-cloud-architecture/scheduling-service/internal/domain"
+)
+
+// ErrReplayFailed - the broker couldn't take the dead letter back, it's kept to be replayed later.
+var ErrReplayFailed = errors.New("failed to replay the dead letter")
+
+// Replayer - sends a dead letter back to the queue it came from.
+type Replayer interface {
+	Replay(ctx context.Context, letter domain.DeadLetter) error
+}
+
+type Service interface {
+	List(ctx context.Context, q domain.ListQuery[domain.DeadLetterFilter]) (domain.Page[domain.DeadLetter], error)
+	GetByID(ctx context.Context, id int64) (domain.DeadLetter, error)
+	Collect(ctx context.Context, letter domain.DeadLetter) error
+	Replay(ctx context.Context, id int64) error
+	Discard(ctx context.Context, id int64) error
+}
+
+type service struct {
+	r        Repository
+	replayer Replayer
+}
+
+func NewService(r Repository, replayer Replayer) Service {
+	return &service{r, replayer}
+}
+
+func (s *service) List(ctx context.Context, q domain.ListQuery[domain.DeadLetterFilter]) (domain.Page[domain.DeadLetter], error) {
+	return s.r.List(ctx, q)
+}
+
+func (s *service) GetByID(ctx context.Context, id int64) (domain.DeadLetter, error) {
+	return s.r.GetByID(ctx, id)
+}
+
+// Collect - keep a message taken from the dead letter queue until it's replayed or discarded.
+func (s *service) Collect(ctx context.Context, letter domain.DeadLetter) error {
+	_, err := s.r.Create(ctx, letter)
+	return err
+}
+
+// Replay - send a dead letter back to the queue it came from and forget it, it's kept when it couldn't be sent.
+func (s *service) Replay(ctx context.Context, id int64) error {
+	letter, err := s.r.GetByID(ctx, id)
+	if err != nil {
+		return err
+	}
+	if err := s.replayer.Replay(ctx, letter); err != nil {
+		return fmt.Errorf("%w: %v", ErrReplayFailed, err)
+	}
+	return s.r.Delete(ctx, id)
+}
+
+// Discard - forget a dead letter, it's never delivered again.
+func (s *service) Discard(ctx context.Context, id int64) error {
+	return s.r.Delete(ctx, id)
+}
@@ -1,83 +1,74 @@
 package dentist
 
 import (
+	"context"
 	"errors"
 	"github.com/ronilsonalves/GoLang-in-a-spring-cloud-architecture/scheduling-service/internal/domain"
 	"github.com/ronilsonalves/GoLang-in-a-spring-cloud-architecture/scheduling-service/pkg/store"
-	"log"
 )
 
-var table = "dentists"
-
 type Repository interface {
-	GetAll() (interface{}, error)
-	GetByID(id int) (interface{}, error)
-	Create(d domain.Dentist) (interface{}, error)
-	Update(id int, d domain.Dentist) (interface{}, error)
-	Delete(id int) error
+	GetAll(ctx context.Context) ([]domain.Dentist, error)
+	List(ctx context.Context, q domain.ListQuery[domain.DentistFilter]) (domain.Page[domain.Dentist], error)
+	GetByID(ctx context.Context, id int) (domain.Dentist, error)
+	Create(ctx context.Context, d domain.Dentist) (domain.Dentist, error)
+	Update(ctx context.Context, id int, d domain.Dentist) (domain.Dentist, error)
+	Delete(ctx context.Context, id int) error
 }
 
 type repository struct {
-	store store.Store
+	store store.DentistStore
 }
 
-func NewRepository(store store.Store) Repository {
+func NewRepository(store store.DentistStore) Repository {
 	return &repository{store}
 }
 
 // GetAll - returns all dentists at database
-func (r *repository) GetAll() (interface{}, error) {
-	return r.store.GetAll(table)
+func (r *repository) GetAll(ctx context.Context) ([]domain.Dentist, error) {
+	return r.store.GetAll(ctx)
+}
+
+// List - returns a page of the dentists matching the filter
+func (r *repository) List(ctx context.Context, q domain.ListQuery[domain.DentistFilter]) (domain.Page[domain.Dentist], error) {
+	return r.store.List(ctx, q.Normalize())
 }
 
-func (r *repository) GetByID(id int) (interface{}, error) {
-	return r.store.GetByID(id, table)
+func (r *repository) GetByID(ctx context.Context, id int) (domain.Dentist, error) {
+	return r.store.GetByID(ctx, id)
 }
 
-func (r *repository) Create(d domain.Dentist) (interface{}, error) {
-	if !r.validateLicenseNumber(d.CRO) {
-		return nil, errors.New("license number already exists at database")
+func (r *repository) Create(ctx context.Context, d domain.Dentist) (domain.Dentist, error) {
+	if !r.validateLicenseNumber(ctx, d.CRO) {
+		return domain.Dentist{}, errors.New("license number already exists at database")
 	}
-	return r.store.Save(d, table)
+	return r.store.Save(ctx, d)
 }
 
-func (r *repository) Update(id int, d domain.Dentist) (interface{}, error) {
-	var dentists []domain.Dentist
-	dentistsInterface, err := r.GetAll()
-	if err != nil {
-		log.Fatalln("erro while trying to fetch data from db")
-		return nil, err
+func (r *repository) Update(ctx context.Context, id int, d domain.Dentist) (domain.Dentist, error) {
+	dentist, err := r.store.GetByID(ctx, id)
+	if errors.Is(err, store.ErrNotFound) {
+		return domain.Dentist{}, errors.New("dentist not found")
 	}
-	dentists, ok := dentistsInterface.([]domain.Dentist)
-	if !ok {
-		return nil, err
+	if err != nil {
+		return domain.Dentist{}, err
 	}
 
-	for _, dentist := range dentists {
-		if dentist.Id == id {
-			if !r.validateLicenseNumber(d.CRO) && d.CRO != dentist.CRO {
-				return nil, errors.New("license number already exists")
-			}
-			return r.store.Update(id, d, table)
-		}
+	if !r.validateLicenseNumber(ctx, d.CRO) && d.CRO != dentist.CRO {
+		return domain.Dentist{}, errors.New("license number already exists")
 	}
-	return nil, errors.New("dentist not found")
+	return r.store.Update(ctx, id, d)
 }
 
-func (r *repository) Delete(id int) error {
-	return r.store.Delete(id, table)
+func (r *repository) Delete(ctx context.Context, id int) error {
+	return r.store.Delete(ctx, id)
 }
 
-func (r *repository) validateLicenseNumber(licenseNumber string) bool {
-	var dentists []domain.Dentist
-	dentistsInterface, err := r.GetAll()
+func (r *repository) validateLicenseNumber(ctx context.Context, licenseNumber string) bool {
+	dentists, err := r.GetAll(ctx)
 	if err != nil {
 		return false
 	}
-	dentists, ok := dentistsInterface.([]domain.Dentist)
-	if !ok {
-		return false
-	}
 
 	for _, dentist := range dentists {
 		if dentist.CRO == licenseNumber {
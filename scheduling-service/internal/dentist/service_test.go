@@ -0,0 +1,148 @@
+package dentist
+
+import (
+	"context"
+	"errors"
+	"github.com/ronilsonalves/GoLang-in-a-spring-cloud-architecture/scheduling-service/internal/domain"
+	"github.com/ronilsonalves/GoLang-in-a-spring-cloud-architecture/scheduling-service/internal/outbox"
+	"github.com/ronilsonalves/GoLang-in-a-spring-cloud-architecture/scheduling-service/pkg/broker"
+	"github.com/ronilsonalves/GoLang-in-a-spring-cloud-architecture/scheduling-service/pkg/store"
+	"reflect"
+	"testing"
+	"time"
+)
+
+// newTestService - a service on the memory store with the dentists CRO-1 and CRO-2, along with the outbox of the
+// dentist events and the publisher the relay sends them to.
+func newTestService(t *testing.T) (Service, *outbox.Relay, *broker.MemoryPublisher) {
+	t.Helper()
+	messages := store.NewMemoryOutbox()
+	s := NewService(NewRepository(store.NewMemoryDentist(messages)))
+	for _, cro := range []string{"CRO-1", "CRO-2"} {
+		if _, err := s.Create(context.Background(), domain.Dentist{Name: "Ana", LastName: "Souza", CRO: cro}); err != nil {
+			t.Fatalf("Create() error = %v", err)
+		}
+	}
+	publisher := broker.NewMemoryPublisher()
+	return s, outbox.NewRelay(messages, publisher, outbox.RelayConfig{BatchSize: 100, ClaimTimeout: time.Minute, MaxAttempts: 3}), publisher
+}
+
+func TestServiceCreate(t *testing.T) {
+	tests := []struct {
+		name    string
+		cro     string
+		wantErr bool
+	}{
+		{"new license number", "CRO-3", false},
+		{"license number of another dentist", "CRO-1", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, _, _ := newTestService(t)
+			created, err := s.Create(context.Background(), domain.Dentist{Name: "Bruno", LastName: "Lima", CRO: tt.cro})
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Create() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if created.Id != 3 {
+				t.Errorf("Create() id = %d, want 3", created.Id)
+			}
+			stored, err := s.GetByID(context.Background(), created.Id)
+			if err != nil || stored != created {
+				t.Errorf("GetByID() = %+v, %v, want %+v", stored, err, created)
+			}
+		})
+	}
+}
+
+func TestServiceUpdate(t *testing.T) {
+	tests := []struct {
+		name    string
+		id      int
+		cro     string
+		wantErr bool
+	}{
+		{"keeping its license number", 1, "CRO-1", false},
+		{"to a new license number", 1, "CRO-3", false},
+		{"to the license number of another dentist", 1, "CRO-2", true},
+		{"missing dentist", 9, "CRO-9", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, _, _ := newTestService(t)
+			updated, err := s.Update(context.Background(), tt.id, domain.Dentist{Name: "Ana", LastName: "Costa", CRO: tt.cro})
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Update() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && (updated.Id != tt.id || updated.LastName != "Costa" || updated.CRO != tt.cro) {
+				t.Errorf("Update() = %+v", updated)
+			}
+		})
+	}
+}
+
+func TestServicePublishesTheChanges(t *testing.T) {
+	s, relay, publisher := newTestService(t)
+	ctx := context.Background()
+	if _, err := s.Update(ctx, 1, domain.Dentist{Name: "Ana", LastName: "Costa", CRO: "CRO-1"}); err != nil {
+		t.Fatalf("Update() error = %v", err)
+	}
+	if err := s.Delete(ctx, 2); err != nil {
+		t.Fatalf("Delete() error = %v", err)
+	}
+	if _, err := s.GetByID(ctx, 2); !errors.Is(err, store.ErrNotFound) {
+		t.Errorf("GetByID() of the deleted dentist error = %v, want ErrNotFound", err)
+	}
+	if err := s.Delete(ctx, 2); !errors.Is(err, store.ErrNotFound) {
+		t.Errorf("Delete() of the deleted dentist error = %v, want ErrNotFound", err)
+	}
+
+	if err := relay.RelayPending(ctx); err != nil {
+		t.Fatalf("RelayPending() error = %v", err)
+	}
+	var types []domain.EventType
+	for _, event := range publisher.Events() {
+		types = append(types, event.Type)
+	}
+	want := []domain.EventType{domain.EventDentistCreated, domain.EventDentistCreated, domain.EventDentistUpdated, domain.EventDentistDeleted}
+	if !reflect.DeepEqual(types, want) {
+		t.Errorf("published %v, want %v", types, want)
+	}
+}
+
+func TestServiceList(t *testing.T) {
+	s, _, _ := newTestService(t)
+	if _, err := s.Create(context.Background(), domain.Dentist{Name: "Bruno", LastName: "Lima", CRO: "CRO-3"}); err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+	tests := []struct {
+		name    string
+		query   domain.ListQuery[domain.DentistFilter]
+		wantIDs []int
+		wantErr error
+	}{
+		{"every dentist", domain.ListQuery[domain.DentistFilter]{}, []int{1, 2, 3}, nil},
+		{"by name prefix", domain.ListQuery[domain.DentistFilter]{Filter: domain.DentistFilter{Name: "bru"}}, []int{3}, nil},
+		{"by license number", domain.ListQuery[domain.DentistFilter]{Filter: domain.DentistFilter{CRO: "CRO-2"}}, []int{2}, nil},
+		{"second page", domain.ListQuery[domain.DentistFilter]{Page: 2, Limit: 2}, []int{3}, nil},
+		{"sorted descending", domain.ListQuery[domain.DentistFilter]{Sort: "-cro"}, []int{3, 2, 1}, nil},
+		{"unknown sort", domain.ListQuery[domain.DentistFilter]{Sort: "email"}, nil, domain.ErrInvalidSort},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			page, err := s.List(context.Background(), tt.query)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("List() error = %v, want %v", err, tt.wantErr)
+			}
+			var ids []int
+			for _, dentist := range page.Items {
+				ids = append(ids, dentist.Id)
+			}
+			if !reflect.DeepEqual(ids, tt.wantIDs) {
+				t.Errorf("List() = %v, want %v", ids, tt.wantIDs)
+			}
+		})
+	}
+}
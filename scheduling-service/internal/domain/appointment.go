@@ -1,9 +1,91 @@
 package domain
 
+import "time"
+
+// DateTimeLayout - the format of every date and time received and returned by the API, e.g. 30/01/2023 23:59.
+const DateTimeLayout = "02/01/2006 15:04"
+
+// DefaultDuration - the duration in minutes of an appointment whose procedure has no known duration.
+const DefaultDuration = 60
+
+// ProcedureDurations - the default duration in minutes of each procedure, used when an appointment has no duration.
+var ProcedureDurations = map[string]int{
+	"consultation": 30,
+	"cleaning":     45,
+	"filling":      60,
+	"extraction":   60,
+	"root-canal":   90,
+	"orthodontics": 30,
+}
+
 type Appointment struct {
-	Id          int    `json:"id"`
-	Description string `json:"description" binding:"required"`
-	DateAndTime string `json:"dateAndTime" binding:"required"`
-	DentistCRO  string `json:"dentistCRO" binding:"required"`
-	PatientRG   string `json:"patientRG" binding:"required"`
+	Id             int    `json:"id"`
+	Description    string `json:"description" binding:"required"`
+	DateAndTime    string `json:"dateAndTime" binding:"required"`
+	DentistCRO     string `json:"dentistCRO" binding:"required"`
+	PatientRG      string `json:"patientRG" binding:"required"`
+	Procedure      string `json:"procedure,omitempty"`
+	Duration       int    `json:"duration,omitempty"`
+	EndDateAndTime string `json:"endDateAndTime,omitempty"`
+	// Status and the fields below are only changed through the status transitions, they're ignored on create and update
+	Status             AppointmentStatus `json:"status,omitempty" swaggertype:"string" enums:"scheduled,confirmed,checked-in,completed,cancelled,no-show"`
+	ConfirmedAt        string            `json:"confirmedAt,omitempty"`
+	CheckedInAt        string            `json:"checkedInAt,omitempty"`
+	CompletedAt        string            `json:"completedAt,omitempty"`
+	CancelledAt        string            `json:"cancelledAt,omitempty"`
+	CancellationReason string            `json:"cancellationReason,omitempty"`
+	NoShowAt           string            `json:"noShowAt,omitempty"`
+}
+
+// ParseDateTime - parse a date and time in DateTimeLayout at the local time zone.
+func ParseDateTime(dateTime string) (time.Time, error) {
+	return time.ParseInLocation(DateTimeLayout, dateTime, time.Local)
+}
+
+// DurationOrDefault - return the appointment duration in minutes, defaulting by its procedure when not provided.
+func (a Appointment) DurationOrDefault() int {
+	if a.Duration > 0 {
+		return a.Duration
+	}
+	if duration, ok := ProcedureDurations[a.Procedure]; ok {
+		return duration
+	}
+	return DefaultDuration
+}
+
+// Interval - return when the appointment starts and ends.
+func (a Appointment) Interval() (time.Time, time.Time, error) {
+	start, err := ParseDateTime(a.DateAndTime)
+	if err != nil {
+		return time.Time{}, time.Time{}, err
+	}
+	return start, start.Add(time.Duration(a.DurationOrDefault()) * time.Minute), nil
+}
+
+// ConflictsWith - verify if both appointments share the dentist or the patient and their intervals overlap, cancelled
+// and no-show appointments don't conflict.
+func (a Appointment) ConflictsWith(other Appointment) bool {
+	if a.Id != 0 && a.Id == other.Id {
+		return false
+	}
+	if !a.Status.BlocksSchedule() || !other.Status.BlocksSchedule() {
+		return false
+	}
+	if a.DentistCRO != other.DentistCRO && a.PatientRG != other.PatientRG {
+		return false
+	}
+	start, end, err := a.Interval()
+	if err != nil {
+		return false
+	}
+	otherStart, otherEnd, err := other.Interval()
+	if err != nil {
+		return false
+	}
+	return Overlaps(start, end, otherStart, otherEnd)
+}
+
+// Overlaps - verify if the half-open intervals [start, end) and [otherStart, otherEnd) have any instant in common.
+func Overlaps(start, end, otherStart, otherEnd time.Time) bool {
+	return start.Before(otherEnd) && otherStart.Before(end)
 }
@@ -4,4 +4,6 @@ type AppointmentDTO struct {
 	Appointment
 	Dentist Dentist `json:"dentist" binding:"required"`
 	Patient Patient `json:"patient" binding:"required"`
+	// Billing is the invoice reported by the invoice service, it's ignored on create and update
+	Billing *Billing `json:"billing,omitempty"`
 }
@@ -0,0 +1,100 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// AppointmentStatus - the stage of an appointment lifecycle.
+type AppointmentStatus string
+
+const (
+	StatusScheduled AppointmentStatus = "scheduled"
+	StatusConfirmed AppointmentStatus = "confirmed"
+	StatusCheckedIn AppointmentStatus = "checked-in"
+	StatusCompleted AppointmentStatus = "completed"
+	StatusCancelled AppointmentStatus = "cancelled"
+	StatusNoShow    AppointmentStatus = "no-show"
+)
+
+// ErrInvalidStatusTransition - returned when an appointment can't move from its status to the one requested.
+var ErrInvalidStatusTransition = errors.New("invalid appointment status transition")
+
+// appointmentTransitions - the statuses each status can move to, completed, cancelled and no-show are final.
+var appointmentTransitions = map[AppointmentStatus][]AppointmentStatus{
+	StatusScheduled: {StatusConfirmed, StatusCheckedIn, StatusCancelled, StatusNoShow},
+	StatusConfirmed: {StatusCheckedIn, StatusCancelled, StatusNoShow},
+	StatusCheckedIn: {StatusCompleted},
+}
+
+// IsValid - verify if the status is one of the appointment lifecycle.
+func (s AppointmentStatus) IsValid() bool {
+	switch s {
+	case StatusScheduled, StatusConfirmed, StatusCheckedIn, StatusCompleted, StatusCancelled, StatusNoShow:
+		return true
+	}
+	return false
+}
+
+// CanTransitionTo - verify if an appointment at this status can move to the status provided.
+func (s AppointmentStatus) CanTransitionTo(next AppointmentStatus) bool {
+	for _, allowed := range appointmentTransitions[s.orDefault()] {
+		if allowed == next {
+			return true
+		}
+	}
+	return false
+}
+
+// IsOpen - verify if the appointment didn't happen yet, so it can still be rescheduled.
+func (s AppointmentStatus) IsOpen() bool {
+	return s.orDefault() == StatusScheduled || s == StatusConfirmed
+}
+
+// BlocksSchedule - verify if the appointment holds its interval, cancelled and no-show ones free it for new bookings.
+func (s AppointmentStatus) BlocksSchedule() bool {
+	return s != StatusCancelled && s != StatusNoShow
+}
+
+func (s AppointmentStatus) orDefault() AppointmentStatus {
+	if s == "" {
+		return StatusScheduled
+	}
+	return s
+}
+
+// TransitionTo - move the appointment to the status provided, recording when it happened and, for cancellations, why.
+// No-show can only be marked once the appointment started.
+func (a *Appointment) TransitionTo(next AppointmentStatus, at time.Time, reason string) error {
+	current := a.Status.orDefault()
+	if !current.CanTransitionTo(next) {
+		return fmt.Errorf("%w: an appointment %s can't be %s", ErrInvalidStatusTransition, current, next)
+	}
+	if next == StatusNoShow {
+		start, err := ParseDateTime(a.DateAndTime)
+		if err != nil {
+			return err
+		}
+		if at.Before(start) {
+			return fmt.Errorf("%w: an appointment can't be marked as no-show before it starts", ErrInvalidStatusTransition)
+		}
+	}
+
+	timestamp := at.Format(DateTimeLayout)
+	switch next {
+	case StatusConfirmed:
+		a.ConfirmedAt = timestamp
+	case StatusCheckedIn:
+		a.CheckedInAt = timestamp
+	case StatusCompleted:
+		a.CompletedAt = timestamp
+	case StatusCancelled:
+		a.CancelledAt = timestamp
+		a.CancellationReason = reason
+	case StatusNoShow:
+		a.NoShowAt = timestamp
+	}
+	a.Status = next
+	return nil
+}
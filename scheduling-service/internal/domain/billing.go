@@ -0,0 +1,44 @@
+package domain
+
+// PaymentStatus - the payment stage of the invoice of an appointment.
+type PaymentStatus string
+
+const (
+	PaymentUnpaid    PaymentStatus = "unpaid"
+	PaymentPaid      PaymentStatus = "paid"
+	PaymentCancelled PaymentStatus = "cancelled"
+)
+
+// The events published by the invoice service, consumed to keep the billing of the appointments.
+const (
+	EventInvoiceCreated   EventType = "invoice.created"
+	EventInvoicePaid      EventType = "invoice.paid"
+	EventInvoiceCancelled EventType = "invoice.cancelled"
+)
+
+// invoicePaymentStatuses - the payment status each invoice event moves an appointment to.
+var invoicePaymentStatuses = map[EventType]PaymentStatus{
+	EventInvoiceCreated:   PaymentUnpaid,
+	EventInvoicePaid:      PaymentPaid,
+	EventInvoiceCancelled: PaymentCancelled,
+}
+
+// Billing - the invoice of an appointment and its payment status, as last reported by the invoice service.
+type Billing struct {
+	InvoiceID     string        `json:"invoiceId"`
+	PaymentStatus PaymentStatus `json:"paymentStatus" swaggertype:"string" enums:"unpaid,paid,cancelled"`
+	UpdatedAt     string        `json:"updatedAt"`
+}
+
+// InvoicePayload - the fields of the invoice at the payload of the invoice events that matter here, the contract of
+// the invoice events is documented at the README.
+type InvoicePayload struct {
+	ID            string `json:"id"`
+	AppointmentID int    `json:"appointmentId"`
+}
+
+// InvoicePaymentStatus - the payment status an invoice event moves an appointment to, false for the other events.
+func InvoicePaymentStatus(eventType EventType) (PaymentStatus, bool) {
+	status, ok := invoicePaymentStatuses[eventType]
+	return status, ok
+}
@@ -0,0 +1,27 @@
+package domain
+
+import "errors"
+
+// ClinicClosure - a period the whole clinic is closed, like holidays, no dentist attends.
+type ClinicClosure struct {
+	Id             int    `json:"id"`
+	DateAndTime    string `json:"dateAndTime" binding:"required"`
+	EndDateAndTime string `json:"endDateAndTime" binding:"required"`
+	Reason         string `json:"reason,omitempty"`
+}
+
+// Interval - return when the closure starts and ends.
+func (c ClinicClosure) Interval() (Interval, error) {
+	start, err := ParseDateTime(c.DateAndTime)
+	if err != nil {
+		return Interval{}, errors.New("invalid closure, dates must be in format: 30/01/2023 23:59")
+	}
+	end, err := ParseDateTime(c.EndDateAndTime)
+	if err != nil {
+		return Interval{}, errors.New("invalid closure, dates must be in format: 30/01/2023 23:59")
+	}
+	if !end.After(start) {
+		return Interval{}, errors.New("invalid closure, it must end after it starts")
+	}
+	return Interval{Start: start, End: end}, nil
+}
@@ -0,0 +1,26 @@
+package domain
+
+// DeadLetter - a message parked at the dead letter queue after it couldn't be handled, kept until it's replayed to the
+// queue it came from or discarded.
+type DeadLetter struct {
+	Id int64 `json:"id"`
+	// MessageID is the id of the event the message carried, when it was one of ours
+	MessageID string `json:"messageId"`
+	// Queue is where the message was dead-lettered from, a replay sends it back there
+	Queue      string `json:"queue"`
+	Exchange   string `json:"exchange"`
+	RoutingKey string `json:"routingKey"`
+	// Reason is the error that parked the message, or rejected, expired or maxlen when the broker dead-lettered it
+	Reason         string                 `json:"reason"`
+	RetryCount     int                    `json:"retryCount"`
+	ContentType    string                 `json:"contentType"`
+	Headers        map[string]interface{} `json:"headers"`
+	Body           string                 `json:"body"`
+	DeadLetteredAt string                 `json:"deadLetteredAt"`
+}
+
+// DeadLetterFilter - the filters of the dead letters list.
+type DeadLetterFilter struct {
+	Queue      string
+	RoutingKey string
+}
@@ -0,0 +1,126 @@
+package domain
+
+import (
+	"crypto/rand"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// EventType - what happened to an entity, it's also the routing key the event is published with, so consumers bind
+// to the events they care about, e.g. appointment.* or patient.updated.
+type EventType string
+
+const (
+	EventAppointmentCreated     EventType = "appointment.created"
+	EventAppointmentUpdated     EventType = "appointment.updated"
+	EventAppointmentRescheduled EventType = "appointment.rescheduled"
+	EventAppointmentConfirmed   EventType = "appointment.confirmed"
+	EventAppointmentCheckedIn   EventType = "appointment.checked-in"
+	EventAppointmentCompleted   EventType = "appointment.completed"
+	EventAppointmentCancelled   EventType = "appointment.cancelled"
+	EventAppointmentNoShow      EventType = "appointment.no-show"
+	EventAppointmentDeleted     EventType = "appointment.deleted"
+	EventDentistCreated         EventType = "dentist.created"
+	EventDentistUpdated         EventType = "dentist.updated"
+	EventDentistDeleted         EventType = "dentist.deleted"
+	EventPatientCreated         EventType = "patient.created"
+	EventPatientUpdated         EventType = "patient.updated"
+	EventPatientDeleted         EventType = "patient.deleted"
+)
+
+// ErrInvalidEvent - an event received can't be processed as it is, so it's never retried.
+var ErrInvalidEvent = errors.New("invalid event")
+
+// EventSchemaVersion - the version of the event payloads, increased on changes consumers can't ignore.
+const EventSchemaVersion = 1
+
+// appointmentStatusEvents - the event of an appointment moving to each status.
+var appointmentStatusEvents = map[AppointmentStatus]EventType{
+	StatusConfirmed: EventAppointmentConfirmed,
+	StatusCheckedIn: EventAppointmentCheckedIn,
+	StatusCompleted: EventAppointmentCompleted,
+	StatusCancelled: EventAppointmentCancelled,
+	StatusNoShow:    EventAppointmentNoShow,
+}
+
+// Event - the envelope every event is published in, the payload is the entity as it is after the change, or as it was
+// before being deleted.
+type Event struct {
+	ID            string          `json:"eventId"`
+	Type          EventType       `json:"eventType"`
+	OccurredAt    time.Time       `json:"occurredAt"`
+	SchemaVersion int             `json:"schemaVersion"`
+	Payload       json.RawMessage `json:"payload"`
+}
+
+// NewEvent - an event of the type provided that just occurred, identified by a random UUID.
+func NewEvent(eventType EventType, payload interface{}) (Event, error) {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return Event{}, err
+	}
+	id, err := newEventID()
+	if err != nil {
+		return Event{}, err
+	}
+	return Event{
+		ID:            id,
+		Type:          eventType,
+		OccurredAt:    time.Now().Truncate(time.Second),
+		SchemaVersion: EventSchemaVersion,
+		Payload:       data,
+	}, nil
+}
+
+// AggregateKey - the entity the event tells about, e.g. appointment:12, read from the id of its payload. Events without
+// one are keyed by their own ID.
+func (e Event) AggregateKey() string {
+	var entity struct {
+		Id int `json:"id"`
+	}
+	if err := json.Unmarshal(e.Payload, &entity); err != nil || entity.Id == 0 {
+		return e.ID
+	}
+	kind := string(e.Type)
+	if i := strings.Index(kind, "."); i >= 0 {
+		kind = kind[:i]
+	}
+	return kind + ":" + strconv.Itoa(entity.Id)
+}
+
+// AppointmentStatusEvent - the event of an appointment moving to the status provided.
+func AppointmentStatusEvent(status AppointmentStatus) EventType {
+	return appointmentStatusEvents[status]
+}
+
+// AppointmentChangeEvent - the event of an appointment change, rescheduled when the dentist or the interval change and
+// updated otherwise.
+func AppointmentChangeEvent(before, after Appointment) EventType {
+	beforeStart, beforeEnd, err := before.Interval()
+	if err != nil {
+		return EventAppointmentRescheduled
+	}
+	afterStart, afterEnd, err := after.Interval()
+	if err != nil {
+		return EventAppointmentRescheduled
+	}
+	if before.DentistCRO != after.DentistCRO || !beforeStart.Equal(afterStart) || !beforeEnd.Equal(afterEnd) {
+		return EventAppointmentRescheduled
+	}
+	return EventAppointmentUpdated
+}
+
+// newEventID - a random (version 4) UUID.
+func newEventID() (string, error) {
+	var b [16]byte
+	if _, err := rand.Read(b[:]); err != nil {
+		return "", err
+	}
+	b[6] = b[6]&0x0f | 0x40
+	b[8] = b[8]&0x3f | 0x80
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:]), nil
+}
@@ -0,0 +1,13 @@
+package domain
+
+// OutboxMessage - an event recorded in the same transaction as the change it tells about and published afterwards by
+// the outbox relay. An event may be published more than once, consumers tell the repeated ones apart by its ID.
+type OutboxMessage struct {
+	Id    int64
+	Event Event
+	// AggregateKey - the entity the event tells about, the events of an entity are published in the order recorded.
+	AggregateKey string
+	Attempts     int
+	// TraceParent - the W3C traceparent of the request that recorded the event, so it's published within its trace.
+	TraceParent string
+}
@@ -0,0 +1,112 @@
+package domain
+
+import (
+	"errors"
+	"strings"
+	"time"
+)
+
+const (
+	// DefaultPageLimit - the items of a page when no limit is requested.
+	DefaultPageLimit = 20
+	// MaxPageLimit - the most items a single page may have.
+	MaxPageLimit = 100
+)
+
+// ErrInvalidSort - returned when a list is sorted by a field it doesn't support.
+var ErrInvalidSort = errors.New("invalid sort field")
+
+// ListQuery - the page, sort and filters of a list. Pages start at 1 and Sort is a field name, prefixed with - for
+// descending order, e.g. -dateAndTime.
+type ListQuery[F any] struct {
+	Page   int
+	Limit  int
+	Sort   string
+	Filter F
+}
+
+// Normalize - fill the page and limit with their defaults when they're missing or out of range.
+func (q ListQuery[F]) Normalize() ListQuery[F] {
+	if q.Page < 1 {
+		q.Page = 1
+	}
+	if q.Limit < 1 {
+		q.Limit = DefaultPageLimit
+	}
+	if q.Limit > MaxPageLimit {
+		q.Limit = MaxPageLimit
+	}
+	return q
+}
+
+// Offset - the items before the page requested.
+func (q ListQuery[F]) Offset() int {
+	return (q.Page - 1) * q.Limit
+}
+
+// SortBy - split Sort into the field name and its direction, the default field is returned when no sort was requested.
+func (q ListQuery[F]) SortBy(defaultField string) (field string, descending bool) {
+	if q.Sort == "" {
+		return defaultField, false
+	}
+	return strings.TrimPrefix(q.Sort, "-"), strings.HasPrefix(q.Sort, "-")
+}
+
+// Page - a page of a list with the total of items matching its filters and the link to the next page, when any.
+type Page[T any] struct {
+	Items      []T    `json:"items"`
+	Page       int    `json:"page"`
+	Limit      int    `json:"limit"`
+	Total      int    `json:"total"`
+	TotalPages int    `json:"totalPages"`
+	Next       string `json:"next,omitempty"`
+}
+
+// NewPage - build the page of the query provided, an empty list is returned as no items instead of null.
+func NewPage[T, F any](items []T, q ListQuery[F], total int) Page[T] {
+	if items == nil {
+		items = []T{}
+	}
+	return Page[T]{
+		Items:      items,
+		Page:       q.Page,
+		Limit:      q.Limit,
+		Total:      total,
+		TotalPages: (total + q.Limit - 1) / q.Limit,
+	}
+}
+
+// HasNext - verify if there are items after this page.
+func (p Page[T]) HasNext() bool {
+	return p.Page < p.TotalPages
+}
+
+// AppointmentFilter - the filters of the appointments list, the date range matches appointments starting in [From, To).
+type AppointmentFilter struct {
+	From       time.Time
+	To         time.Time
+	DentistCRO string
+	PatientRG  string
+	Status     AppointmentStatus
+}
+
+// DentistFilter - the filters of the dentists list, Name matches the start of the name or the last name.
+type DentistFilter struct {
+	Name string
+	CRO  string
+}
+
+// PatientFilter - the filters of the patients list, Name matches the start of the name or the last name.
+type PatientFilter struct {
+	Name string
+	RG   string
+}
+
+// AppointmentPage, DentistPage, PatientPage and DeadLetterPage - the pages of each list, named for the API
+// documentation since it can't refer to generic types.
+type (
+	AppointmentPage Page[AppointmentDTO]
+	DentistPage     Page[Dentist]
+	PatientPage     Page[Patient]
+	DeadLetterPage  Page[DeadLetter]
+)
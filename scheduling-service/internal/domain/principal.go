@@ -0,0 +1,103 @@
+package domain
+
+import (
+	"context"
+	"errors"
+)
+
+// The roles granted at the realm to the users of the API.
+const (
+	RoleAdmin        = "ADMIN"
+	RoleReceptionist = "RECEPTIONIST"
+	RoleDentist      = "DENTIST"
+	RolePatient      = "PATIENT"
+)
+
+// ErrForbidden - the principal isn't allowed to access the resource provided.
+var ErrForbidden = errors.New("access to the resource provided isn't allowed")
+
+// Principal - the authenticated user of a request, patients and dentists are identified through the rg and cro claims
+// of their tokens.
+type Principal struct {
+	Roles []string
+	RG    string
+	CRO   string
+}
+
+type principalKey struct{}
+
+// ContextWithPrincipal - return a copy of the context carrying the principal provided.
+func ContextWithPrincipal(ctx context.Context, p Principal) context.Context {
+	return context.WithValue(ctx, principalKey{}, p)
+}
+
+// PrincipalFromContext - return the principal of the context, calls made by the service itself have none.
+func PrincipalFromContext(ctx context.Context) (Principal, bool) {
+	p, ok := ctx.Value(principalKey{}).(Principal)
+	return p, ok
+}
+
+// HasRole - verify if the principal was granted the role provided.
+func (p Principal) HasRole(role string) bool {
+	for _, granted := range p.Roles {
+		if granted == role {
+			return true
+		}
+	}
+	return false
+}
+
+// IsStaff - admins and receptionists manage the appointments of every patient and dentist.
+func (p Principal) IsStaff() bool {
+	return p.HasRole(RoleAdmin) || p.HasRole(RoleReceptionist)
+}
+
+// ScopeAppointments - restrict the filter to the appointments of the principal, dentists see their agenda and patients
+// their own appointments. ErrForbidden is returned when the filter asks for someone else's appointments.
+func (p Principal) ScopeAppointments(filter AppointmentFilter) (AppointmentFilter, error) {
+	switch {
+	case p.IsStaff():
+		return filter, nil
+	case p.HasRole(RoleDentist) && p.CRO != "":
+		if filter.DentistCRO != "" && filter.DentistCRO != p.CRO {
+			return filter, ErrForbidden
+		}
+		filter.DentistCRO = p.CRO
+		return filter, nil
+	case p.HasRole(RolePatient) && p.RG != "":
+		if filter.PatientRG != "" && filter.PatientRG != p.RG {
+			return filter, ErrForbidden
+		}
+		filter.PatientRG = p.RG
+		return filter, nil
+	}
+	return filter, ErrForbidden
+}
+
+// CanAccess - verify if the appointment is at the scope of the principal, following the rules of ScopeAppointments.
+func (p Principal) CanAccess(a Appointment) bool {
+	switch {
+	case p.IsStaff():
+		return true
+	case p.HasRole(RoleDentist) && p.CRO != "":
+		return a.DentistCRO == p.CRO
+	case p.HasRole(RolePatient) && p.RG != "":
+		return a.PatientRG == p.RG
+	}
+	return false
+}
+
+// ScopeSlotQuery - restrict the free slots search to the bookings of the principal, patients search around their own
+// appointments and only staff exclude the appointments of any patient. ErrForbidden is returned when the query asks for
+// someone else's appointments.
+func (p Principal) ScopeSlotQuery(q SlotQuery) (SlotQuery, error) {
+	switch {
+	case p.IsStaff():
+		return q, nil
+	case q.PatientRG != "" && q.PatientRG != p.RG:
+		return q, ErrForbidden
+	case p.HasRole(RolePatient) && p.RG != "":
+		q.PatientRG = p.RG
+	}
+	return q, nil
+}
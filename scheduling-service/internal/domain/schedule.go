@@ -0,0 +1,245 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+)
+
+// TimeOfDayLayout - the format of the times of a dentist schedule, e.g. 08:30.
+const TimeOfDayLayout = "15:04"
+
+// EndOfDay - the end time of working hours and breaks lasting until midnight, so a shift goes on at 00:00 of the
+// next day.
+const EndOfDay = "24:00"
+
+// WorkingHours - a period of a weekday the dentist works, weekday goes from 0 (sunday) to 6 (saturday).
+type WorkingHours struct {
+	Weekday   time.Weekday `json:"weekday" swaggertype:"integer"`
+	StartTime string       `json:"startTime" binding:"required"`
+	EndTime   string       `json:"endTime" binding:"required"`
+}
+
+// ScheduleBreak - a recurring period of a weekday the dentist doesn't attend, like lunch.
+type ScheduleBreak struct {
+	Weekday   time.Weekday `json:"weekday" swaggertype:"integer"`
+	StartTime string       `json:"startTime" binding:"required"`
+	EndTime   string       `json:"endTime" binding:"required"`
+}
+
+// ScheduleException - a date specific period the dentist doesn't attend, like vacations or a day off.
+type ScheduleException struct {
+	Id             int    `json:"id"`
+	DateAndTime    string `json:"dateAndTime" binding:"required"`
+	EndDateAndTime string `json:"endDateAndTime" binding:"required"`
+	Reason         string `json:"reason,omitempty"`
+}
+
+// DentistSchedule - when a dentist works, without working hours the dentist is available all day long, every day, but
+// at the breaks and exceptions.
+type DentistSchedule struct {
+	DentistID    int                 `json:"dentistId"`
+	WorkingHours []WorkingHours      `json:"workingHours"`
+	Breaks       []ScheduleBreak     `json:"breaks"`
+	Exceptions   []ScheduleException `json:"exceptions"`
+}
+
+// Interval - a period of time, the start is inclusive and the end exclusive.
+type Interval struct {
+	Start time.Time
+	End   time.Time
+}
+
+// Contains - verify if the period from start to end is entirely inside the interval.
+func (i Interval) Contains(start, end time.Time) bool {
+	return !start.Before(i.Start) && !end.After(i.End)
+}
+
+// Validate - verify if every time provided is valid and every period ends after it starts.
+func (s DentistSchedule) Validate() error {
+	for _, hours := range s.WorkingHours {
+		if err := validatePeriod(hours.Weekday, hours.StartTime, hours.EndTime); err != nil {
+			return fmt.Errorf("invalid working hours: %w", err)
+		}
+	}
+	for _, pause := range s.Breaks {
+		if err := validatePeriod(pause.Weekday, pause.StartTime, pause.EndTime); err != nil {
+			return fmt.Errorf("invalid break: %w", err)
+		}
+	}
+	for _, exception := range s.Exceptions {
+		if err := exception.Validate(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Validate - verify if the exception dates are valid and it ends after it starts.
+func (e ScheduleException) Validate() error {
+	start, err := ParseDateTime(e.DateAndTime)
+	if err != nil {
+		return errors.New("invalid exception, dates must be in format: 30/01/2023 23:59")
+	}
+	end, err := ParseDateTime(e.EndDateAndTime)
+	if err != nil {
+		return errors.New("invalid exception, dates must be in format: 30/01/2023 23:59")
+	}
+	if !end.After(start) {
+		return errors.New("invalid exception, it must end after it starts")
+	}
+	return nil
+}
+
+// IsAvailable - verify if the dentist works during the whole period from start to end, a period past midnight is
+// available when the dentist works until the end of a day and from the start of the next one.
+func (s DentistSchedule) IsAvailable(start, end time.Time) bool {
+	var intervals []Interval
+	for day := start; ; {
+		intervals = append(intervals, s.AvailableIntervals(day)...)
+		if day = nextDay(day); !day.Before(end) {
+			break
+		}
+	}
+	for _, interval := range MergeIntervals(intervals) {
+		if interval.Contains(start, end) {
+			return true
+		}
+	}
+	return false
+}
+
+// AvailableIntervals - return the periods the dentist works at the day provided, ordered by start, without breaks and
+// exceptions. Without working hours the whole day is available.
+func (s DentistSchedule) AvailableIntervals(day time.Time) []Interval {
+	var intervals []Interval
+	if len(s.WorkingHours) == 0 {
+		intervals = append(intervals, Interval{Start: startOfDay(day), End: nextDay(day)})
+	}
+	for _, hours := range s.WorkingHours {
+		if hours.Weekday != day.Weekday() {
+			continue
+		}
+		if interval, err := intervalAt(day, hours.StartTime, hours.EndTime); err == nil {
+			intervals = append(intervals, interval)
+		}
+	}
+
+	var unavailable []Interval
+	for _, pause := range s.Breaks {
+		if pause.Weekday != day.Weekday() {
+			continue
+		}
+		if interval, err := intervalAt(day, pause.StartTime, pause.EndTime); err == nil {
+			unavailable = append(unavailable, interval)
+		}
+	}
+	for _, exception := range s.Exceptions {
+		start, errStart := ParseDateTime(exception.DateAndTime)
+		end, errEnd := ParseDateTime(exception.EndDateAndTime)
+		if errStart == nil && errEnd == nil {
+			unavailable = append(unavailable, Interval{Start: start, End: end})
+		}
+	}
+
+	return SubtractIntervals(intervals, unavailable)
+}
+
+// SubtractIntervals - remove every period of the unavailable intervals from the intervals provided, ordered by start.
+func SubtractIntervals(intervals, unavailable []Interval) []Interval {
+	result := append([]Interval(nil), intervals...)
+	for _, remove := range unavailable {
+		var next []Interval
+		for _, interval := range result {
+			if !Overlaps(interval.Start, interval.End, remove.Start, remove.End) {
+				next = append(next, interval)
+				continue
+			}
+			if interval.Start.Before(remove.Start) {
+				next = append(next, Interval{Start: interval.Start, End: remove.Start})
+			}
+			if remove.End.Before(interval.End) {
+				next = append(next, Interval{Start: remove.End, End: interval.End})
+			}
+		}
+		result = next
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].Start.Before(result[j].Start) })
+	return result
+}
+
+// MergeIntervals - join the intervals that overlap or touch each other, e.g. the end of a day and the start of the next
+// one, the intervals must be ordered by start.
+func MergeIntervals(intervals []Interval) []Interval {
+	var merged []Interval
+	for _, interval := range intervals {
+		if last := len(merged) - 1; last >= 0 && !interval.Start.After(merged[last].End) {
+			if interval.End.After(merged[last].End) {
+				merged[last].End = interval.End
+			}
+			continue
+		}
+		merged = append(merged, interval)
+	}
+	return merged
+}
+
+// startOfDay - the midnight starting the day of the time provided.
+func startOfDay(t time.Time) time.Time {
+	year, month, day := t.Date()
+	return time.Date(year, month, day, 0, 0, 0, 0, t.Location())
+}
+
+// nextDay - the midnight starting the day after the time provided.
+func nextDay(t time.Time) time.Time {
+	year, month, day := t.Date()
+	return time.Date(year, month, day+1, 0, 0, 0, 0, t.Location())
+}
+
+// intervalAt - build the interval between two times of day at the day provided.
+func intervalAt(day time.Time, startTime, endTime string) (Interval, error) {
+	start, err := parseTimeOfDay(startTime)
+	if err != nil {
+		return Interval{}, err
+	}
+	end, err := parseTimeOfDay(endTime)
+	if err != nil {
+		return Interval{}, err
+	}
+	year, month, date := day.Date()
+	return Interval{
+		Start: time.Date(year, month, date, 0, start, 0, 0, day.Location()),
+		End:   time.Date(year, month, date, 0, end, 0, 0, day.Location()),
+	}, nil
+}
+
+// parseTimeOfDay - the minutes from midnight to a time of day, EndOfDay included.
+func parseTimeOfDay(value string) (int, error) {
+	if value == EndOfDay {
+		return 24 * 60, nil
+	}
+	parsed, err := time.Parse(TimeOfDayLayout, value)
+	if err != nil {
+		return 0, err
+	}
+	return parsed.Hour()*60 + parsed.Minute(), nil
+}
+
+func validatePeriod(weekday time.Weekday, startTime, endTime string) error {
+	if weekday < time.Sunday || weekday > time.Saturday {
+		return errors.New("weekday must be between 0 (sunday) and 6 (saturday)")
+	}
+	start, err := parseTimeOfDay(startTime)
+	if err != nil {
+		return errors.New("times must be in format: 23:59, or 24:00 for the end of the day")
+	}
+	end, err := parseTimeOfDay(endTime)
+	if err != nil {
+		return errors.New("times must be in format: 23:59, or 24:00 for the end of the day")
+	}
+	if end <= start {
+		return errors.New("it must end after it starts")
+	}
+	return nil
+}
@@ -0,0 +1,150 @@
+package domain
+
+import (
+	"testing"
+	"time"
+)
+
+// mustParse - parse a date and time of the tests, 30/01/2023 is a monday.
+func mustParse(t *testing.T, dateTime string) time.Time {
+	t.Helper()
+	parsed, err := ParseDateTime(dateTime)
+	if err != nil {
+		t.Fatalf("ParseDateTime(%q) error = %v", dateTime, err)
+	}
+	return parsed
+}
+
+func TestDentistScheduleIsAvailable(t *testing.T) {
+	weekdays := DentistSchedule{
+		WorkingHours: []WorkingHours{
+			{Weekday: time.Monday, StartTime: "08:00", EndTime: "12:00"},
+			{Weekday: time.Monday, StartTime: "13:00", EndTime: "18:00"},
+			{Weekday: time.Tuesday, StartTime: "08:00", EndTime: "18:00"},
+		},
+		Breaks: []ScheduleBreak{
+			{Weekday: time.Monday, StartTime: "10:00", EndTime: "10:30"},
+		},
+		Exceptions: []ScheduleException{
+			{DateAndTime: "30/01/2023 15:00", EndDateAndTime: "31/01/2023 09:00"},
+		},
+	}
+	overnight := DentistSchedule{
+		WorkingHours: []WorkingHours{
+			{Weekday: time.Monday, StartTime: "20:00", EndTime: EndOfDay},
+			{Weekday: time.Tuesday, StartTime: "00:00", EndTime: "06:00"},
+		},
+	}
+	overnightWithException := overnight
+	overnightWithException.Exceptions = []ScheduleException{
+		{DateAndTime: "30/01/2023 23:45", EndDateAndTime: "31/01/2023 00:15"},
+	}
+	unrestricted := DentistSchedule{
+		Breaks: []ScheduleBreak{
+			{Weekday: time.Monday, StartTime: "12:00", EndTime: "13:00"},
+		},
+	}
+
+	tests := []struct {
+		name     string
+		schedule DentistSchedule
+		start    string
+		end      string
+		want     bool
+	}{
+		{"inside working hours", weekdays, "30/01/2023 08:00", "30/01/2023 09:00", true},
+		{"ending at the end of working hours", weekdays, "30/01/2023 13:00", "30/01/2023 14:00", true},
+		{"starting before working hours", weekdays, "30/01/2023 07:30", "30/01/2023 08:30", false},
+		{"across the gap between working hours", weekdays, "30/01/2023 11:30", "30/01/2023 12:30", false},
+		{"weekday without working hours", weekdays, "01/02/2023 09:00", "01/02/2023 10:00", false},
+		{"ending when the break starts", weekdays, "30/01/2023 09:30", "30/01/2023 10:00", true},
+		{"starting when the break ends", weekdays, "30/01/2023 10:30", "30/01/2023 11:00", true},
+		{"overlapping the break", weekdays, "30/01/2023 10:15", "30/01/2023 10:45", false},
+		{"ending when the exception starts", weekdays, "30/01/2023 14:00", "30/01/2023 15:00", true},
+		{"during the exception", weekdays, "30/01/2023 16:00", "30/01/2023 17:00", false},
+		{"exception going on at the next day", weekdays, "31/01/2023 08:00", "31/01/2023 09:00", false},
+		{"after the exception at the next day", weekdays, "31/01/2023 09:00", "31/01/2023 10:00", true},
+		{"overnight across midnight", overnight, "30/01/2023 23:30", "31/01/2023 00:30", true},
+		{"overnight until the end of the shift", overnight, "31/01/2023 05:00", "31/01/2023 06:00", true},
+		{"overnight past the end of the shift", overnight, "31/01/2023 05:30", "31/01/2023 06:30", false},
+		{"overnight from a day without working hours", overnight, "29/01/2023 23:30", "30/01/2023 00:30", false},
+		{"overnight with an exception at midnight", overnightWithException, "30/01/2023 23:30", "31/01/2023 00:30", false},
+		{"without working hours at a weekend", unrestricted, "29/01/2023 03:00", "29/01/2023 04:00", true},
+		{"without working hours across midnight", unrestricted, "29/01/2023 23:30", "30/01/2023 00:30", true},
+		{"without working hours at a break", unrestricted, "30/01/2023 12:30", "30/01/2023 13:30", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.schedule.IsAvailable(mustParse(t, tt.start), mustParse(t, tt.end))
+			if got != tt.want {
+				t.Errorf("IsAvailable(%s, %s) = %v, want %v", tt.start, tt.end, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDentistScheduleAvailableIntervals(t *testing.T) {
+	schedule := DentistSchedule{
+		WorkingHours: []WorkingHours{{Weekday: time.Monday, StartTime: "08:00", EndTime: "18:00"}},
+		Breaks:       []ScheduleBreak{{Weekday: time.Monday, StartTime: "12:00", EndTime: "13:00"}},
+	}
+	got := schedule.AvailableIntervals(mustParse(t, "30/01/2023 00:00"))
+	want := []Interval{
+		{Start: mustParse(t, "30/01/2023 08:00"), End: mustParse(t, "30/01/2023 12:00")},
+		{Start: mustParse(t, "30/01/2023 13:00"), End: mustParse(t, "30/01/2023 18:00")},
+	}
+	assertIntervals(t, got, want)
+
+	got = DentistSchedule{}.AvailableIntervals(mustParse(t, "29/01/2023 10:00"))
+	want = []Interval{{Start: mustParse(t, "29/01/2023 00:00"), End: mustParse(t, "30/01/2023 00:00")}}
+	assertIntervals(t, got, want)
+}
+
+func TestMergeIntervals(t *testing.T) {
+	got := MergeIntervals([]Interval{
+		{Start: mustParse(t, "30/01/2023 20:00"), End: mustParse(t, "31/01/2023 00:00")},
+		{Start: mustParse(t, "31/01/2023 00:00"), End: mustParse(t, "31/01/2023 06:00")},
+		{Start: mustParse(t, "31/01/2023 05:00"), End: mustParse(t, "31/01/2023 05:30")},
+		{Start: mustParse(t, "31/01/2023 08:00"), End: mustParse(t, "31/01/2023 09:00")},
+	})
+	want := []Interval{
+		{Start: mustParse(t, "30/01/2023 20:00"), End: mustParse(t, "31/01/2023 06:00")},
+		{Start: mustParse(t, "31/01/2023 08:00"), End: mustParse(t, "31/01/2023 09:00")},
+	}
+	assertIntervals(t, got, want)
+}
+
+func TestDentistScheduleValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		hours   WorkingHours
+		wantErr bool
+	}{
+		{"valid", WorkingHours{Weekday: time.Monday, StartTime: "08:00", EndTime: "18:00"}, false},
+		{"until the end of the day", WorkingHours{Weekday: time.Monday, StartTime: "20:00", EndTime: EndOfDay}, false},
+		{"ending before starting", WorkingHours{Weekday: time.Monday, StartTime: "18:00", EndTime: "08:00"}, true},
+		{"starting at the end of the day", WorkingHours{Weekday: time.Monday, StartTime: EndOfDay, EndTime: EndOfDay}, true},
+		{"invalid time", WorkingHours{Weekday: time.Monday, StartTime: "8h", EndTime: "18:00"}, true},
+		{"invalid weekday", WorkingHours{Weekday: 7, StartTime: "08:00", EndTime: "18:00"}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := DentistSchedule{WorkingHours: []WorkingHours{tt.hours}}.Validate()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func assertIntervals(t *testing.T, got, want []Interval) {
+	t.Helper()
+	if len(got) != len(want) {
+		t.Fatalf("got %d intervals %v, want %d %v", len(got), got, len(want), want)
+	}
+	for i := range want {
+		if !got[i].Start.Equal(want[i].Start) || !got[i].End.Equal(want[i].End) {
+			t.Errorf("interval %d = %v - %v, want %v - %v", i, got[i].Start, got[i].End, want[i].Start, want[i].End)
+		}
+	}
+}
@@ -0,0 +1,20 @@
+package domain
+
+import "time"
+
+// Slot - a free period a dentist can attend an appointment.
+type Slot struct {
+	DentistCRO     string `json:"dentistCRO"`
+	DateAndTime    string `json:"dateAndTime"`
+	EndDateAndTime string `json:"endDateAndTime"`
+}
+
+// SlotQuery - the filters of a free slots search, without DentistCRO every dentist is searched and with PatientRG the
+// periods the patient is already booked are excluded.
+type SlotQuery struct {
+	DentistCRO string
+	PatientRG  string
+	From       time.Time
+	To         time.Time
+	Duration   int
+}
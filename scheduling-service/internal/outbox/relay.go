@@ -0,0 +1,113 @@
+package outbox
+
+import (
+	"context"
+	"fmt"
+	"github.com/go-kit/log"
+	"github.com/go-kit/log/level"
+	"github.com/ronilsonalves/GoLang-in-a-spring-cloud-architecture/scheduling-service/internal/domain"
+	"github.com/ronilsonalves/GoLang-in-a-spring-cloud-architecture/scheduling-service/pkg/logging"
+	"github.com/ronilsonalves/GoLang-in-a-spring-cloud-architecture/scheduling-service/pkg/store"
+	"github.com/ronilsonalves/GoLang-in-a-spring-cloud-architecture/scheduling-service/pkg/tracing"
+	"time"
+)
+
+// Publisher - publish a message of the outbox, returning only once the broker has it.
+type Publisher interface {
+	PublishMessage(ctx context.Context, message domain.OutboxMessage) error
+}
+
+// Relay - publish the pending messages of the outbox and mark them sent, the messages of an aggregate in the order
+// they were recorded. Delivery is at least once: a message is published again when the process stops between
+// publishing and marking it, or when its claim expires first. A message failing MaxAttempts times is parked, so it
+// doesn't hold the newer messages of its aggregate forever.
+type Relay struct {
+	store     store.OutboxStore
+	publisher Publisher
+	config    RelayConfig
+}
+
+// RelayConfig - how often and how much the relay reads from the outbox.
+type RelayConfig struct {
+	// Interval - how often the outbox is polled.
+	Interval time.Duration
+	// BatchSize - the messages claimed at a time.
+	BatchSize int
+	// ClaimTimeout - how long the messages claimed are held from other relays, a message that failed is retried once
+	// its claim expires.
+	ClaimTimeout time.Duration
+	// MaxAttempts - the attempts to publish a message before it's parked.
+	MaxAttempts int
+}
+
+// NewRelay - Initialize a Relay publishing the messages of the store provided.
+func NewRelay(store store.OutboxStore, publisher Publisher, config RelayConfig) *Relay {
+	return &Relay{
+		store:     store,
+		publisher: publisher,
+		config:    config,
+	}
+}
+
+// Run - relay the pending messages every interval until the context is done, logging through the logger of ctx.
+func (r *Relay) Run(ctx context.Context) {
+	ticker := time.NewTicker(r.config.Interval)
+	defer ticker.Stop()
+	for {
+		if err := r.RelayPending(ctx); err != nil && ctx.Err() == nil {
+			level.Error(logging.FromContext(ctx)).Log("msg", "failed to relay the outbox messages", "err", err)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// RelayPending - publish every message that can be claimed. When a message fails, the newer ones of its aggregate are
+// left for the next runs and the other aggregates go on, the first failure is returned at the end. Each message is
+// published within the trace of the request that recorded it.
+func (r *Relay) RelayPending(ctx context.Context) error {
+	var failure error
+	for {
+		messages, err := r.store.ClaimPending(ctx, r.config.BatchSize, r.config.ClaimTimeout)
+		if err != nil {
+			return err
+		}
+		// the aggregates with a failed message at this batch, their newer messages stay claimed until it's retried
+		failed := map[string]bool{}
+		for _, message := range messages {
+			if failed[message.AggregateKey] {
+				continue
+			}
+			logger := log.With(logging.FromContext(ctx), "outbox_id", message.Id, "event_id", message.Event.ID)
+			publishCtx := logging.NewContext(tracing.ContextWithTraceParent(ctx, message.TraceParent), logger)
+			err := r.publisher.PublishMessage(publishCtx, message)
+			if err == nil {
+				if err := r.store.MarkSent(ctx, message.Id); err != nil {
+					return err
+				}
+				continue
+			}
+
+			if message.Attempts+1 >= r.config.MaxAttempts {
+				level.Error(logger).Log("msg", "parking the outbox message, it won't be published again", "attempts", message.Attempts+1, "err", err)
+				if markErr := r.store.MarkDead(ctx, message.Id, err.Error()); markErr != nil {
+					return markErr
+				}
+				continue
+			}
+			failed[message.AggregateKey] = true
+			if markErr := r.store.MarkFailed(ctx, message.Id, err.Error()); markErr != nil {
+				level.Error(logger).Log("msg", "failed to record the failure of the outbox message", "err", markErr)
+			}
+			if failure == nil {
+				failure = fmt.Errorf("failed to publish the outbox message %d: %w", message.Id, err)
+			}
+		}
+		if len(messages) < r.config.BatchSize {
+			return failure
+		}
+	}
+}
@@ -0,0 +1,140 @@
+package outbox
+
+import (
+	"context"
+	"errors"
+	"github.com/ronilsonalves/GoLang-in-a-spring-cloud-architecture/scheduling-service/internal/domain"
+	"github.com/ronilsonalves/GoLang-in-a-spring-cloud-architecture/scheduling-service/pkg/broker"
+	"github.com/ronilsonalves/GoLang-in-a-spring-cloud-architecture/scheduling-service/pkg/store"
+	"reflect"
+	"testing"
+	"time"
+)
+
+// failingPublisher - a memory publisher failing the events of the IDs provided.
+type failingPublisher struct {
+	*broker.MemoryPublisher
+	failing map[string]bool
+}
+
+func (p *failingPublisher) PublishMessage(ctx context.Context, message domain.OutboxMessage) error {
+	if p.failing[message.Event.ID] {
+		return errors.New("broker unavailable")
+	}
+	return p.MemoryPublisher.PublishMessage(ctx, message)
+}
+
+// addEvent - record at the outbox an event of the appointment provided, returning its ID.
+func addEvent(t *testing.T, outbox store.OutboxStore, eventType domain.EventType, appointmentID int) string {
+	t.Helper()
+	event, err := domain.NewEvent(eventType, domain.Appointment{Id: appointmentID})
+	if err != nil {
+		t.Fatalf("NewEvent() error = %v", err)
+	}
+	if _, err := outbox.Add(context.Background(), domain.OutboxMessage{Event: event}); err != nil {
+		t.Fatalf("Add() error = %v", err)
+	}
+	return event.ID
+}
+
+func published(publisher *failingPublisher) []string {
+	var ids []string
+	for _, event := range publisher.Events() {
+		ids = append(ids, event.ID)
+	}
+	return ids
+}
+
+func TestRelayPendingHoldsOnlyTheAggregateThatFailed(t *testing.T) {
+	outbox := store.NewMemoryOutbox()
+	firstCreated := addEvent(t, outbox, domain.EventAppointmentCreated, 1)
+	secondCreated := addEvent(t, outbox, domain.EventAppointmentCreated, 2)
+	addEvent(t, outbox, domain.EventAppointmentConfirmed, 1)
+	secondConfirmed := addEvent(t, outbox, domain.EventAppointmentConfirmed, 2)
+
+	publisher := &failingPublisher{MemoryPublisher: broker.NewMemoryPublisher(), failing: map[string]bool{firstCreated: true}}
+	relay := NewRelay(outbox, publisher, RelayConfig{BatchSize: 10, ClaimTimeout: time.Hour, MaxAttempts: 3})
+
+	if err := relay.RelayPending(context.Background()); err == nil {
+		t.Fatal("RelayPending() error = nil, want the failure of the first appointment")
+	}
+	if want := []string{secondCreated, secondConfirmed}; !reflect.DeepEqual(published(publisher), want) {
+		t.Fatalf("published %v, want only the second appointment %v", published(publisher), want)
+	}
+
+	// the failed message stays claimed, nothing of its appointment is published until the claim expires
+	if err := relay.RelayPending(context.Background()); err != nil {
+		t.Fatalf("RelayPending() while claimed error = %v", err)
+	}
+	if len(publisher.Events()) != 2 {
+		t.Fatalf("published %v while the failed message was claimed", published(publisher))
+	}
+}
+
+func TestRelayPendingParksAfterMaxAttempts(t *testing.T) {
+	outbox := store.NewMemoryOutbox()
+	created := addEvent(t, outbox, domain.EventAppointmentCreated, 1)
+	confirmed := addEvent(t, outbox, domain.EventAppointmentConfirmed, 1)
+
+	publisher := &failingPublisher{MemoryPublisher: broker.NewMemoryPublisher(), failing: map[string]bool{created: true}}
+	// claims expire at once, so each run retries the failed message
+	relay := NewRelay(outbox, publisher, RelayConfig{BatchSize: 10, ClaimTimeout: 0, MaxAttempts: 3})
+
+	for run := 1; run < 3; run++ {
+		if err := relay.RelayPending(context.Background()); err == nil {
+			t.Fatalf("RelayPending() run %d error = nil, want the failure", run)
+		}
+		if len(publisher.Events()) != 0 {
+			t.Fatalf("run %d published %v before the failed message of the same appointment", run, published(publisher))
+		}
+	}
+	if err := relay.RelayPending(context.Background()); err != nil {
+		t.Fatalf("RelayPending() parking run error = %v", err)
+	}
+	if want := []string{confirmed}; !reflect.DeepEqual(published(publisher), want) {
+		t.Fatalf("published %v, want the newer message once the failed one was parked", published(publisher))
+	}
+
+	delete(publisher.failing, created)
+	if err := relay.RelayPending(context.Background()); err != nil {
+		t.Fatalf("RelayPending() after parking error = %v", err)
+	}
+	if len(publisher.Events()) != 1 {
+		t.Errorf("published %v, the parked message must not be published again", published(publisher))
+	}
+}
+
+func TestClaimPendingSplitsTheMessagesBetweenRelays(t *testing.T) {
+	outbox := store.NewMemoryOutbox()
+	firstCreated := addEvent(t, outbox, domain.EventAppointmentCreated, 1)
+	secondCreated := addEvent(t, outbox, domain.EventAppointmentCreated, 2)
+	addEvent(t, outbox, domain.EventAppointmentConfirmed, 1)
+	thirdCreated := addEvent(t, outbox, domain.EventAppointmentCreated, 3)
+
+	ids := func(messages []domain.OutboxMessage) []string {
+		var ids []string
+		for _, message := range messages {
+			ids = append(ids, message.Event.ID)
+		}
+		return ids
+	}
+	first, err := outbox.ClaimPending(context.Background(), 2, time.Hour)
+	if err != nil {
+		t.Fatalf("ClaimPending() error = %v", err)
+	}
+	if want := []string{firstCreated, secondCreated}; !reflect.DeepEqual(ids(first), want) {
+		t.Fatalf("first relay claimed %v, want %v", ids(first), want)
+	}
+	if first[0].AggregateKey != "appointment:1" {
+		t.Errorf("AggregateKey = %q, want appointment:1", first[0].AggregateKey)
+	}
+
+	// the confirmation of the first appointment waits for its creation, claimed by the first relay
+	second, err := outbox.ClaimPending(context.Background(), 2, time.Hour)
+	if err != nil {
+		t.Fatalf("ClaimPending() error = %v", err)
+	}
+	if want := []string{thirdCreated}; !reflect.DeepEqual(ids(second), want) {
+		t.Fatalf("second relay claimed %v, want %v", ids(second), want)
+	}
+}
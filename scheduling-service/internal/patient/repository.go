@@ -1,84 +1,75 @@
 package patient
 
 import (
+	"context"
 	"errors"
+	"github.com/go-kit/log/level"
 	"github.com/ronilsonalves/GoLang-in-a-spring-cloud-architecture/scheduling-service/internal/domain"
+	"github.com/ronilsonalves/GoLang-in-a-spring-cloud-architecture/scheduling-service/pkg/logging"
 	"github.com/ronilsonalves/GoLang-in-a-spring-cloud-architecture/scheduling-service/pkg/store"
-	"log"
 )
 
-var table = "patients"
-
 type Repository interface {
-	GetAll() (interface{}, error)
-	GetByID(id int) (interface{}, error)
-	Create(p domain.Patient) (interface{}, error)
-	Update(id int, p domain.Patient) (interface{}, error)
-	Delete(id int) error
+	GetAll(ctx context.Context) ([]domain.Patient, error)
+	List(ctx context.Context, q domain.ListQuery[domain.PatientFilter]) (domain.Page[domain.Patient], error)
+	GetByID(ctx context.Context, id int) (domain.Patient, error)
+	Create(ctx context.Context, p domain.Patient) (domain.Patient, error)
+	Update(ctx context.Context, id int, p domain.Patient) (domain.Patient, error)
+	Delete(ctx context.Context, id int) error
 }
 
 type repository struct {
-	store store.Store
+	store store.PatientStore
 }
 
-func NewRepository(store store.Store) Repository {
+func NewRepository(store store.PatientStore) Repository {
 	return &repository{store}
 }
 
 // GetAll - returns all patients at database
-func (r *repository) GetAll() (interface{}, error) {
-	return r.store.GetAll(table)
+func (r *repository) GetAll(ctx context.Context) ([]domain.Patient, error) {
+	return r.store.GetAll(ctx)
 }
 
-func (r *repository) GetByID(id int) (interface{}, error) {
-	return r.store.GetByID(id, table)
+// List - returns a page of the patients matching the filter
+func (r *repository) List(ctx context.Context, q domain.ListQuery[domain.PatientFilter]) (domain.Page[domain.Patient], error) {
+	return r.store.List(ctx, q.Normalize())
 }
 
-func (r *repository) Create(p domain.Patient) (interface{}, error) {
-	if !r.validateIdentificationNumber(p.RG) {
-		return nil, errors.New("license number already exists at database")
-	}
-	return r.store.Save(p, table)
+func (r *repository) GetByID(ctx context.Context, id int) (domain.Patient, error) {
+	return r.store.GetByID(ctx, id)
 }
 
-func (r *repository) Update(id int, p domain.Patient) (interface{}, error) {
+func (r *repository) Create(ctx context.Context, p domain.Patient) (domain.Patient, error) {
+	if !r.validateIdentificationNumber(ctx, p.RG) {
+		return domain.Patient{}, errors.New("license number already exists at database")
+	}
+	return r.store.Save(ctx, p)
+}
 
-	pInterface, err := r.GetAll()
-	if err != nil {
-		log.Fatalln("error while trying to fetch data from db while update a patient")
-		return nil, err
+func (r *repository) Update(ctx context.Context, id int, p domain.Patient) (domain.Patient, error) {
+	patient, err := r.store.GetByID(ctx, id)
+	if errors.Is(err, store.ErrNotFound) {
+		return domain.Patient{}, errors.New("patient not found")
 	}
-	patients, ok := pInterface.([]domain.Patient)
-	if !ok {
-		log.Fatalln("error while trying to fetch data from db while updating a patient")
-		return nil, err
+	if err != nil {
+		return domain.Patient{}, err
 	}
 
-	for _, patient := range patients {
-		if patient.Id == id {
-			if !r.validateIdentificationNumber(p.RG) && p.RG != p.RG {
-				return nil, errors.New("there's a patient with same identity number")
-			}
-			return r.store.Update(id, p, table)
-		}
+	if !r.validateIdentificationNumber(ctx, p.RG) && p.RG != patient.RG {
+		return domain.Patient{}, errors.New("there's a patient with same identity number")
 	}
-	return nil, errors.New("patient not found")
+	return r.store.Update(ctx, id, p)
 }
 
-func (r *repository) Delete(id int) error {
-	return r.store.Delete(id, table)
+func (r *repository) Delete(ctx context.Context, id int) error {
+	return r.store.Delete(ctx, id)
 }
 
-func (r *repository) validateIdentificationNumber(identityNumber string) bool {
-	var patients []domain.Patient
-	patientsInterface, err := r.GetAll()
+func (r *repository) validateIdentificationNumber(ctx context.Context, identityNumber string) bool {
+	patients, err := r.GetAll(ctx)
 	if err != nil {
-		log.Fatalln("erro while trying to fetch data from db")
-		return false
-	}
-	patients, ok := patientsInterface.([]domain.Patient)
-	if !ok {
-		log.Fatalln("error while trying to fetch data from db")
+		level.Error(logging.FromContext(ctx)).Log("msg", "failed to load the patients to check the identity number", "err", err)
 		return false
 	}
 
@@ -1,16 +1,16 @@
 package patient
 
 import (
-	"errors"
+	"context"
 	"github.com/ronilsonalves/GoLang-in-a-spring-cloud-architecture/scheduling-service/internal/domain"
 )
 
 type Service interface {
-	GetAll() ([]domain.Patient, error)
-	GetByID(id int) (domain.Patient, error)
-	Create(p domain.Patient) (domain.Patient, error)
-	Update(id int, p domain.Patient) (domain.Patient, error)
-	Delete(id int) error
+	List(ctx context.Context, q domain.ListQuery[domain.PatientFilter]) (domain.Page[domain.Patient], error)
+	GetByID(ctx context.Context, id int) (domain.Patient, error)
+	Create(ctx context.Context, p domain.Patient) (domain.Patient, error)
+	Update(ctx context.Context, id int, p domain.Patient) (domain.Patient, error)
+	Delete(ctx context.Context, id int) error
 }
 
 type service struct {
@@ -21,46 +21,20 @@ func NewService(r Repository) Service {
 	return &service{r}
 }
 
-func (s *service) GetAll() ([]domain.Patient, error) {
-	list, err := s.r.GetAll()
-	if err != nil {
-		return nil, err
-	}
-	patients, ok := list.([]domain.Patient)
-	if !ok {
-		return nil, errors.New("an error occurred while trying to fetch data from db")
-	}
-	return patients, nil
+func (s *service) List(ctx context.Context, q domain.ListQuery[domain.PatientFilter]) (domain.Page[domain.Patient], error) {
+	return s.r.List(ctx, q)
 }
 
-func (s *service) GetByID(id int) (domain.Patient, error) {
-	pInterface, err := s.r.GetByID(id)
-	if err != nil {
-		return domain.Patient{}, err
-	}
-	patient, ok := pInterface.(domain.Patient)
-	if !ok {
-		return patient, errors.New("not found a patient with provided id")
-	}
-	return patient, nil
+func (s *service) GetByID(ctx context.Context, id int) (domain.Patient, error) {
+	return s.r.GetByID(ctx, id)
 }
 
-func (s *service) Create(p domain.Patient) (domain.Patient, error) {
-	pSavedInterface, err := s.r.Create(p)
-	if err != nil {
-		return domain.Patient{}, err
-	}
-
-	patientSaved, ok := pSavedInterface.(domain.Patient)
-	if ok {
-		return patientSaved, nil
-	}
-
-	return domain.Patient{}, errors.New("failed to save a new patient at db")
+func (s *service) Create(ctx context.Context, p domain.Patient) (domain.Patient, error) {
+	return s.r.Create(ctx, p)
 }
 
-func (s *service) Update(id int, p domain.Patient) (domain.Patient, error) {
-	pdb, err := s.GetByID(id)
+func (s *service) Update(ctx context.Context, id int, p domain.Patient) (domain.Patient, error) {
+	pdb, err := s.GetByID(ctx, id)
 	if err != nil {
 		return domain.Patient{}, err
 	}
@@ -78,18 +52,9 @@ func (s *service) Update(id int, p domain.Patient) (domain.Patient, error) {
 		p.CreatedAt = pdb.CreatedAt
 	}
 	p.Id = pdb.Id
-	pUpdated, err := s.r.Update(id, p)
-	if err != nil {
-		return domain.Patient{}, err
-	}
-	patientUpdated, ok := pUpdated.(domain.Patient)
-	if ok {
-		return patientUpdated, nil
-	}
-
-	return domain.Patient{}, errors.New("failed to update the patient")
+	return s.r.Update(ctx, id, p)
 }
 
-func (s *service) Delete(id int) error {
-	return s.r.Delete(id)
+func (s *service) Delete(ctx context.Context, id int) error {
+	return s.r.Delete(ctx, id)
 }
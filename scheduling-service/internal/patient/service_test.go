@@ -0,0 +1,151 @@
+package patient
+
+import (
+	"context"
+	"errors"
+	"github.com/ronilsonalves/GoLang-in-a-spring-cloud-architecture/scheduling-service/internal/domain"
+	"github.com/ronilsonalves/GoLang-in-a-spring-cloud-architecture/scheduling-service/internal/outbox"
+	"github.com/ronilsonalves/GoLang-in-a-spring-cloud-architecture/scheduling-service/pkg/broker"
+	"github.com/ronilsonalves/GoLang-in-a-spring-cloud-architecture/scheduling-service/pkg/store"
+	"reflect"
+	"testing"
+	"time"
+)
+
+// newTestService - a service on the memory store with the patients RG-1 and RG-2, along with the outbox of the
+// patient events and the publisher the relay sends them to.
+func newTestService(t *testing.T) (Service, *outbox.Relay, *broker.MemoryPublisher) {
+	t.Helper()
+	messages := store.NewMemoryOutbox()
+	s := NewService(NewRepository(store.NewMemoryPatient(messages)))
+	for _, rg := range []string{"RG-1", "RG-2"} {
+		if _, err := s.Create(context.Background(), domain.Patient{Name: "João", LastName: "Silva", RG: rg, CreatedAt: "30/01/2023 10:00:00"}); err != nil {
+			t.Fatalf("Create() error = %v", err)
+		}
+	}
+	publisher := broker.NewMemoryPublisher()
+	return s, outbox.NewRelay(messages, publisher, outbox.RelayConfig{BatchSize: 100, ClaimTimeout: time.Minute, MaxAttempts: 3}), publisher
+}
+
+func TestServiceCreate(t *testing.T) {
+	tests := []struct {
+		name      string
+		patient   domain.Patient
+		wantErr   bool
+		createdAt string
+	}{
+		{"new identity number", domain.Patient{Name: "Maria", LastName: "Lima", RG: "RG-3", CreatedAt: "01/02/2023 08:30:00"}, false, "01/02/2023 08:30"},
+		{"identity number of another patient", domain.Patient{Name: "Maria", LastName: "Lima", RG: "RG-1", CreatedAt: "01/02/2023 08:30:00"}, true, ""},
+		{"invalid created at", domain.Patient{Name: "Maria", LastName: "Lima", RG: "RG-3", CreatedAt: "2023-02-01"}, true, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, _, _ := newTestService(t)
+			created, err := s.Create(context.Background(), tt.patient)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Create() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if created.Id != 3 || created.CreatedAt != tt.createdAt {
+				t.Errorf("Create() = id %d, created at %q, want 3, %q", created.Id, created.CreatedAt, tt.createdAt)
+			}
+		})
+	}
+}
+
+func TestServiceUpdateKeepsTheFieldsNotProvided(t *testing.T) {
+	tests := []struct {
+		name    string
+		id      int
+		update  domain.Patient
+		want    domain.Patient
+		wantErr bool
+	}{
+		{
+			name:   "last name only",
+			id:     1,
+			update: domain.Patient{LastName: "Costa"},
+			want:   domain.Patient{Id: 1, Name: "João", LastName: "Costa", RG: "RG-1", CreatedAt: "30/01/2023 10:00"},
+		},
+		{
+			name:   "new identity number",
+			id:     1,
+			update: domain.Patient{RG: "RG-3"},
+			want:   domain.Patient{Id: 1, Name: "João", LastName: "Silva", RG: "RG-3", CreatedAt: "30/01/2023 10:00"},
+		},
+		{name: "identity number of another patient", id: 1, update: domain.Patient{RG: "RG-2"}, wantErr: true},
+		{name: "missing patient", id: 9, update: domain.Patient{LastName: "Costa"}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, _, _ := newTestService(t)
+			updated, err := s.Update(context.Background(), tt.id, tt.update)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Update() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && updated != tt.want {
+				t.Errorf("Update() = %+v, want %+v", updated, tt.want)
+			}
+		})
+	}
+}
+
+func TestServicePublishesTheChanges(t *testing.T) {
+	s, relay, publisher := newTestService(t)
+	ctx := context.Background()
+	if _, err := s.Update(ctx, 1, domain.Patient{LastName: "Costa"}); err != nil {
+		t.Fatalf("Update() error = %v", err)
+	}
+	if err := s.Delete(ctx, 2); err != nil {
+		t.Fatalf("Delete() error = %v", err)
+	}
+	if _, err := s.GetByID(ctx, 2); !errors.Is(err, store.ErrNotFound) {
+		t.Errorf("GetByID() of the deleted patient error = %v, want ErrNotFound", err)
+	}
+
+	if err := relay.RelayPending(ctx); err != nil {
+		t.Fatalf("RelayPending() error = %v", err)
+	}
+	var types []domain.EventType
+	for _, event := range publisher.Events() {
+		types = append(types, event.Type)
+	}
+	want := []domain.EventType{domain.EventPatientCreated, domain.EventPatientCreated, domain.EventPatientUpdated, domain.EventPatientDeleted}
+	if !reflect.DeepEqual(types, want) {
+		t.Errorf("published %v, want %v", types, want)
+	}
+}
+
+func TestServiceList(t *testing.T) {
+	s, _, _ := newTestService(t)
+	if _, err := s.Create(context.Background(), domain.Patient{Name: "Maria", LastName: "Lima", RG: "RG-3", CreatedAt: "01/02/2023 08:30:00"}); err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+	tests := []struct {
+		name    string
+		query   domain.ListQuery[domain.PatientFilter]
+		wantIDs []int
+	}{
+		{"every patient", domain.ListQuery[domain.PatientFilter]{}, []int{1, 2, 3}},
+		{"by name prefix", domain.ListQuery[domain.PatientFilter]{Filter: domain.PatientFilter{Name: "mar"}}, []int{3}},
+		{"by identity number", domain.ListQuery[domain.PatientFilter]{Filter: domain.PatientFilter{RG: "RG-2"}}, []int{2}},
+		{"newest first", domain.ListQuery[domain.PatientFilter]{Sort: "-createdAt"}, []int{3, 2, 1}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			page, err := s.List(context.Background(), tt.query)
+			if err != nil {
+				t.Fatalf("List() error = %v", err)
+			}
+			var ids []int
+			for _, patient := range page.Items {
+				ids = append(ids, patient.Id)
+			}
+			if !reflect.DeepEqual(ids, tt.wantIDs) {
+				t.Errorf("List() = %v, want %v", ids, tt.wantIDs)
+			}
+		})
+	}
+}
@@ -0,0 +1,64 @@
+package schedule
+
+import (
+	"context"
+	"errors"
+	"github.com/ronilsonalves/GoLang-in-a-spring-cloud-architecture/scheduling-service/internal/domain"
+	"github.com/ronilsonalves/GoLang-in-a-spring-cloud-architecture/scheduling-service/pkg/store"
+)
+
+type Repository interface {
+	GetByDentistID(ctx context.Context, dentistID int) (domain.DentistSchedule, error)
+	UpdateWeekly(ctx context.Context, dentistID int, s domain.DentistSchedule) (domain.DentistSchedule, error)
+	CreateException(ctx context.Context, dentistID int, e domain.ScheduleException) (domain.ScheduleException, error)
+	DeleteException(ctx context.Context, dentistID, exceptionID int) error
+}
+
+type repository struct {
+	store store.ScheduleStore
+}
+
+func NewRepository(store store.ScheduleStore) Repository {
+	return &repository{store}
+}
+
+func (r *repository) GetByDentistID(ctx context.Context, dentistID int) (domain.DentistSchedule, error) {
+	schedule, err := r.store.GetByDentistID(ctx, dentistID)
+	if errors.Is(err, store.ErrNotFound) {
+		return domain.DentistSchedule{}, errors.New("dentist not found")
+	}
+	return schedule, err
+}
+
+// UpdateWeekly - replace the working hours and breaks of a dentist, exceptions are managed one by one.
+func (r *repository) UpdateWeekly(ctx context.Context, dentistID int, s domain.DentistSchedule) (domain.DentistSchedule, error) {
+	s.DentistID = dentistID
+	s.Exceptions = nil
+	if err := s.Validate(); err != nil {
+		return domain.DentistSchedule{}, err
+	}
+	schedule, err := r.store.SaveWeekly(ctx, s)
+	if errors.Is(err, store.ErrNotFound) {
+		return domain.DentistSchedule{}, errors.New("dentist not found")
+	}
+	return schedule, err
+}
+
+func (r *repository) CreateException(ctx context.Context, dentistID int, e domain.ScheduleException) (domain.ScheduleException, error) {
+	if err := e.Validate(); err != nil {
+		return domain.ScheduleException{}, err
+	}
+	exception, err := r.store.SaveException(ctx, dentistID, e)
+	if errors.Is(err, store.ErrNotFound) {
+		return domain.ScheduleException{}, errors.New("dentist not found")
+	}
+	return exception, err
+}
+
+func (r *repository) DeleteException(ctx context.Context, dentistID, exceptionID int) error {
+	err := r.store.DeleteException(ctx, dentistID, exceptionID)
+	if errors.Is(err, store.ErrNotFound) {
+		return errors.New("schedule exception not found")
+	}
+	return err
+}
@@ -0,0 +1,37 @@
+package schedule
+
+import (
+	"context"
+	"github.com/ronilsonalves/GoLang-in-a-spring-cloud-architecture/scheduling-service/internal/domain"
+)
+
+type Service interface {
+	GetByDentistID(ctx context.Context, dentistID int) (domain.DentistSchedule, error)
+	UpdateWeekly(ctx context.Context, dentistID int, s domain.DentistSchedule) (domain.DentistSchedule, error)
+	CreateException(ctx context.Context, dentistID int, e domain.ScheduleException) (domain.ScheduleException, error)
+	DeleteException(ctx context.Context, dentistID, exceptionID int) error
+}
+
+type service struct {
+	r Repository
+}
+
+func NewService(r Repository) Service {
+	return &service{r}
+}
+
+func (s *service) GetByDentistID(ctx context.Context, dentistID int) (domain.DentistSchedule, error) {
+	return s.r.GetByDentistID(ctx, dentistID)
+}
+
+func (s *service) UpdateWeekly(ctx context.Context, dentistID int, schedule domain.DentistSchedule) (domain.DentistSchedule, error) {
+	return s.r.UpdateWeekly(ctx, dentistID, schedule)
+}
+
+func (s *service) CreateException(ctx context.Context, dentistID int, e domain.ScheduleException) (domain.ScheduleException, error) {
+	return s.r.CreateException(ctx, dentistID, e)
+}
+
+func (s *service) DeleteException(ctx context.Context, dentistID, exceptionID int) error {
+	return s.r.DeleteException(ctx, dentistID, exceptionID)
+}
@@ -1,6 +1,4 @@
-CREATE SCHEMA IF NOT EXISTS dental_clinic;
-USE dental_clinic;
-CREATE TABLE dentists (
+CREATE TABLE IF NOT EXISTS dentists (
     id INT NOT NULL AUTO_INCREMENT,
     last_name VARCHAR(50) NOT NULL,
     name VARCHAR(25) NOT NULL,
@@ -9,7 +7,7 @@ CREATE TABLE dentists (
     PRIMARY KEY (id)
 )ENGINE = INNODB;
 
-CREATE TABLE patients (
+CREATE TABLE IF NOT EXISTS patients (
     id INT NOT NULL AUTO_INCREMENT,
     last_name VARCHAR(50) NOT NULL,
     name VARCHAR(25) NOT NULL,
@@ -19,7 +17,7 @@ CREATE TABLE patients (
     PRIMARY KEY (id)
 )ENGINE = INNODB;
 
-CREATE TABLE appointments (
+CREATE TABLE IF NOT EXISTS appointments (
     id INT NOT NULL AUTO_INCREMENT,
     description VARCHAR(250) NOT NULL,
     date_and_time DATETIME NOT NULL,
@@ -34,4 +32,4 @@ CREATE TABLE appointments (
     CONSTRAINT fk_patient
                           FOREIGN KEY (patient_rg)
                           REFERENCES patients(rg)
-)ENGINE = INNODB;
\ No newline at end of file
+)ENGINE = INNODB;
@@ -0,0 +1,13 @@
+-- the foreign keys may be using the interval indexes, plain ones are created before dropping them
+CREATE INDEX idx_appointments_dentist_cro ON appointments (dentist_cro);
+
+CREATE INDEX idx_appointments_patient_rg ON appointments (patient_rg);
+
+DROP INDEX idx_appointments_patient_interval ON appointments;
+
+DROP INDEX idx_appointments_dentist_interval ON appointments;
+
+ALTER TABLE appointments
+    DROP COLUMN end_date_and_time,
+    DROP COLUMN duration_minutes,
+    DROP COLUMN procedure_name;
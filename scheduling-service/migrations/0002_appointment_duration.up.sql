@@ -0,0 +1,12 @@
+ALTER TABLE appointments
+    ADD COLUMN procedure_name VARCHAR(50) NOT NULL DEFAULT '',
+    ADD COLUMN duration_minutes INT NOT NULL DEFAULT 60,
+    ADD COLUMN end_date_and_time DATETIME NULL;
+
+UPDATE appointments SET end_date_and_time = DATE_ADD(date_and_time, INTERVAL duration_minutes MINUTE);
+
+ALTER TABLE appointments MODIFY end_date_and_time DATETIME NOT NULL;
+
+CREATE INDEX idx_appointments_dentist_interval ON appointments (dentist_cro, date_and_time, end_date_and_time);
+
+CREATE INDEX idx_appointments_patient_interval ON appointments (patient_rg, date_and_time, end_date_and_time);
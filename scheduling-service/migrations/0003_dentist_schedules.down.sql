@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS dentist_schedule_exceptions;
+DROP TABLE IF EXISTS dentist_breaks;
+DROP TABLE IF EXISTS dentist_working_hours;
@@ -0,0 +1,45 @@
+CREATE TABLE dentist_working_hours (
+    id INT NOT NULL AUTO_INCREMENT,
+    dentist_id INT NOT NULL,
+    weekday TINYINT NOT NULL,
+    start_time TIME NOT NULL,
+    end_time TIME NOT NULL,
+
+    PRIMARY KEY (id),
+
+    CONSTRAINT fk_working_hours_dentist
+                          FOREIGN KEY (dentist_id)
+                          REFERENCES dentists(id)
+                          ON DELETE CASCADE
+)ENGINE = INNODB;
+
+CREATE TABLE dentist_breaks (
+    id INT NOT NULL AUTO_INCREMENT,
+    dentist_id INT NOT NULL,
+    weekday TINYINT NOT NULL,
+    start_time TIME NOT NULL,
+    end_time TIME NOT NULL,
+
+    PRIMARY KEY (id),
+
+    CONSTRAINT fk_breaks_dentist
+                          FOREIGN KEY (dentist_id)
+                          REFERENCES dentists(id)
+                          ON DELETE CASCADE
+)ENGINE = INNODB;
+
+CREATE TABLE dentist_schedule_exceptions (
+    id INT NOT NULL AUTO_INCREMENT,
+    dentist_id INT NOT NULL,
+    start_date_and_time DATETIME NOT NULL,
+    end_date_and_time DATETIME NOT NULL,
+    reason VARCHAR(250) NOT NULL DEFAULT '',
+
+    PRIMARY KEY (id),
+    INDEX idx_schedule_exceptions_interval (dentist_id, start_date_and_time, end_date_and_time),
+
+    CONSTRAINT fk_schedule_exceptions_dentist
+                          FOREIGN KEY (dentist_id)
+                          REFERENCES dentists(id)
+                          ON DELETE CASCADE
+)ENGINE = INNODB;
@@ -0,0 +1,9 @@
+CREATE TABLE clinic_closures (
+    id INT NOT NULL AUTO_INCREMENT,
+    start_date_and_time DATETIME NOT NULL,
+    end_date_and_time DATETIME NOT NULL,
+    reason VARCHAR(250) NOT NULL DEFAULT '',
+
+    PRIMARY KEY (id),
+    INDEX idx_clinic_closures_interval (start_date_and_time, end_date_and_time)
+)ENGINE = INNODB;
@@ -0,0 +1,10 @@
+DROP INDEX idx_appointments_status ON appointments;
+
+ALTER TABLE appointments
+    DROP COLUMN no_show_at,
+    DROP COLUMN cancellation_reason,
+    DROP COLUMN cancelled_at,
+    DROP COLUMN completed_at,
+    DROP COLUMN checked_in_at,
+    DROP COLUMN confirmed_at,
+    DROP COLUMN status;
@@ -0,0 +1,10 @@
+ALTER TABLE appointments
+    ADD COLUMN status VARCHAR(20) NOT NULL DEFAULT 'scheduled',
+    ADD COLUMN confirmed_at DATETIME NULL,
+    ADD COLUMN checked_in_at DATETIME NULL,
+    ADD COLUMN completed_at DATETIME NULL,
+    ADD COLUMN cancelled_at DATETIME NULL,
+    ADD COLUMN cancellation_reason VARCHAR(250) NOT NULL DEFAULT '',
+    ADD COLUMN no_show_at DATETIME NULL;
+
+CREATE INDEX idx_appointments_status ON appointments (status);
@@ -0,0 +1,9 @@
+DROP INDEX idx_patients_last_name ON patients;
+
+DROP INDEX idx_patients_name ON patients;
+
+DROP INDEX idx_dentists_last_name ON dentists;
+
+DROP INDEX idx_dentists_name ON dentists;
+
+DROP INDEX idx_appointments_date_and_time ON appointments;
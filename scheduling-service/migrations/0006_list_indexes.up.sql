@@ -0,0 +1,9 @@
+CREATE INDEX idx_appointments_date_and_time ON appointments (date_and_time);
+
+CREATE INDEX idx_dentists_name ON dentists (name);
+
+CREATE INDEX idx_dentists_last_name ON dentists (last_name);
+
+CREATE INDEX idx_patients_name ON patients (name);
+
+CREATE INDEX idx_patients_last_name ON patients (last_name);
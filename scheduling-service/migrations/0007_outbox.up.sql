@@ -0,0 +1,12 @@
+CREATE TABLE outbox (
+    id BIGINT NOT NULL AUTO_INCREMENT,
+    routing_key VARCHAR(100) NOT NULL,
+    payload MEDIUMTEXT NOT NULL,
+    created_at DATETIME NOT NULL,
+    sent_at DATETIME NULL,
+    attempts INT NOT NULL DEFAULT 0,
+    last_error VARCHAR(500) NOT NULL DEFAULT '',
+
+    PRIMARY KEY (id),
+    INDEX idx_outbox_pending (sent_at, id)
+)ENGINE = INNODB;
@@ -0,0 +1,8 @@
+DELETE FROM outbox WHERE event_type NOT LIKE 'appointment.%';
+
+UPDATE outbox SET event_type = 'appointment-service';
+
+ALTER TABLE outbox
+    DROP COLUMN schema_version,
+    DROP COLUMN event_id,
+    CHANGE COLUMN event_type routing_key VARCHAR(100) NOT NULL;
@@ -0,0 +1,8 @@
+ALTER TABLE outbox
+    CHANGE COLUMN routing_key event_type VARCHAR(100) NOT NULL,
+    ADD COLUMN event_id CHAR(36) NOT NULL DEFAULT '' AFTER id,
+    ADD COLUMN schema_version INT NOT NULL DEFAULT 1 AFTER event_type;
+
+UPDATE outbox SET event_id = UUID() WHERE event_id = '';
+
+UPDATE outbox SET event_type = 'appointment.updated' WHERE event_type = 'appointment-service';
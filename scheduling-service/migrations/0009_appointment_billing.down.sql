@@ -0,0 +1,4 @@
+ALTER TABLE appointments
+    DROP COLUMN billing_updated_at,
+    DROP COLUMN payment_status,
+    DROP COLUMN invoice_id;
@@ -0,0 +1,4 @@
+ALTER TABLE appointments
+    ADD COLUMN invoice_id VARCHAR(36) NULL,
+    ADD COLUMN payment_status VARCHAR(20) NOT NULL DEFAULT '',
+    ADD COLUMN billing_updated_at DATETIME NULL;
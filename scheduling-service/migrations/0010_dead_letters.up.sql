@@ -0,0 +1,16 @@
+CREATE TABLE dead_letters (
+    id BIGINT NOT NULL AUTO_INCREMENT,
+    message_id VARCHAR(100) NOT NULL DEFAULT '',
+    queue VARCHAR(255) NOT NULL,
+    exchange VARCHAR(255) NOT NULL DEFAULT '',
+    routing_key VARCHAR(255) NOT NULL DEFAULT '',
+    reason VARCHAR(500) NOT NULL DEFAULT '',
+    retry_count INT NOT NULL DEFAULT 0,
+    content_type VARCHAR(100) NOT NULL DEFAULT '',
+    headers MEDIUMTEXT NOT NULL,
+    body MEDIUMTEXT NOT NULL,
+    dead_lettered_at DATETIME NOT NULL,
+
+    PRIMARY KEY (id),
+    INDEX idx_dead_letters_queue (queue, id)
+)ENGINE = INNODB;
@@ -0,0 +1,5 @@
+ALTER TABLE outbox
+    DROP INDEX idx_outbox_aggregate,
+    DROP COLUMN dead_at,
+    DROP COLUMN claimed_until,
+    DROP COLUMN aggregate_key;
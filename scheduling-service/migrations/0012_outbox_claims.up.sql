@@ -0,0 +1,10 @@
+ALTER TABLE outbox
+    ADD COLUMN aggregate_key VARCHAR(100) NOT NULL DEFAULT '' AFTER event_type,
+    ADD COLUMN claimed_until DATETIME NULL AFTER sent_at,
+    ADD COLUMN dead_at DATETIME NULL AFTER claimed_until,
+    ADD INDEX idx_outbox_aggregate (aggregate_key, id);
+
+UPDATE outbox SET aggregate_key = COALESCE(
+        CONCAT(SUBSTRING_INDEX(event_type, '.', 1), ':', NULLIF(JSON_UNQUOTE(JSON_EXTRACT(payload, '$.id')), '0')),
+        event_id)
+    WHERE sent_at IS NULL;
@@ -0,0 +1,8 @@
+// Package migrations embeds the versioned SQL migrations of the scheduling-service schema. Every change to the
+// schema is a new pair of files named <version>_<name>.up.sql and <version>_<name>.down.sql.
+package migrations
+
+import "embed"
+
+//go:embed *.sql
+var FS embed.FS
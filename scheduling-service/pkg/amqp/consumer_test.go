@@ -0,0 +1,211 @@
+package amqp
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/ronilsonalves/GoLang-in-a-spring-cloud-architecture/scheduling-service/internal/domain"
+	amqpi "github.com/streadway/amqp"
+	"strings"
+	"testing"
+	"time"
+)
+
+// fakeAcknowledger - records how a delivery was settled, in place of the channel it came from.
+type fakeAcknowledger struct {
+	acked, nacked, requeued bool
+}
+
+func (a *fakeAcknowledger) Ack(tag uint64, multiple bool) error {
+	a.acked = true
+	return nil
+}
+
+func (a *fakeAcknowledger) Nack(tag uint64, multiple bool, requeue bool) error {
+	a.nacked, a.requeued = true, requeue
+	return nil
+}
+
+func (a *fakeAcknowledger) Reject(tag uint64, requeue bool) error {
+	return a.Nack(tag, false, requeue)
+}
+
+// publishedMessage - a message the consumer published through the fake broker.
+type publishedMessage struct {
+	exchange, routingKey string
+	msg                  amqpi.Publishing
+}
+
+// fakeBroker - confirms the messages published, or fails them all with err.
+type fakeBroker struct {
+	published []publishedMessage
+	err       error
+}
+
+func (b *fakeBroker) publish(exchange, routingKey string, msg amqpi.Publishing) error {
+	if b.err != nil {
+		return b.err
+	}
+	b.published = append(b.published, publishedMessage{exchange, routingKey, msg})
+	return nil
+}
+
+func newTestConsumer() *Consumer {
+	return NewConsumer(ConsumerConfig{
+		Exchange:           "scheduling.events",
+		Queue:              "scheduling-service",
+		MaxRetries:         3,
+		RetryDelay:         5 * time.Second,
+		DeadLetterExchange: "scheduling.dlx",
+	})
+}
+
+// newTestDelivery - the delivery of an event from the events exchange, retried the times provided.
+func newTestDelivery(t *testing.T, retries int) (amqpi.Delivery, *fakeAcknowledger) {
+	t.Helper()
+	msg, err := encodeEvent(newTestEvent(t), FormatEnvelope, "/invoice-service")
+	if err != nil {
+		t.Fatalf("encodeEvent() error = %v", err)
+	}
+	delivery := deliveryOf(msg)
+	delivery.Exchange = "scheduling.events"
+	delivery.RoutingKey = "appointment.confirmed"
+	if retries > 0 {
+		delivery.Headers = withOrigin(delivery, "scheduling-service")
+		delivery.Headers[HeaderRetryCount] = int32(retries)
+		// the retries come back from the retry queue through the default exchange
+		delivery.Exchange, delivery.RoutingKey = "", "scheduling-service"
+	}
+	acknowledger := &fakeAcknowledger{}
+	delivery.Acknowledger = acknowledger
+	return delivery, acknowledger
+}
+
+func TestConsumerHandle(t *testing.T) {
+	failing := func(err error) EventHandler {
+		return func(ctx context.Context, event domain.Event) error { return err }
+	}
+	tests := []struct {
+		name           string
+		retries        int
+		handle         EventHandler
+		wantExchange   string
+		wantRoutingKey string
+		wantRetryCount int
+	}{
+		{"handled", 0, failing(nil), "", "", 0},
+		{"first failure", 0, failing(errors.New("database unavailable")), "", "scheduling-service.retry", 1},
+		{"failure within the retries", 2, failing(errors.New("database unavailable")), "", "scheduling-service.retry", 3},
+		{"failure out of retries", 3, failing(errors.New("database unavailable")), "scheduling.dlx", "appointment.confirmed", 3},
+		{"invalid event", 0, failing(fmt.Errorf("%w: unknown dentist", domain.ErrInvalidEvent)), "scheduling.dlx", "appointment.confirmed", 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			broker := &fakeBroker{}
+			delivery, acknowledger := newTestDelivery(t, tt.retries)
+			if err := newTestConsumer().handle(context.Background(), tt.handle, broker.publish, delivery); err != nil {
+				t.Fatalf("handle() error = %v", err)
+			}
+			if !acknowledger.acked || acknowledger.nacked {
+				t.Errorf("acked %v, nacked %v, want the delivery acknowledged", acknowledger.acked, acknowledger.nacked)
+			}
+			if tt.wantRoutingKey == "" {
+				if len(broker.published) != 0 {
+					t.Errorf("published %v, want nothing for a message handled", broker.published)
+				}
+				return
+			}
+			if len(broker.published) != 1 {
+				t.Fatalf("published %d messages, want 1", len(broker.published))
+			}
+			published := broker.published[0]
+			if published.exchange != tt.wantExchange || published.routingKey != tt.wantRoutingKey {
+				t.Errorf("published to %q %q, want %q %q", published.exchange, published.routingKey, tt.wantExchange, tt.wantRoutingKey)
+			}
+			if got := retryCount(published.msg.Headers); got != tt.wantRetryCount {
+				t.Errorf("retry count = %d, want %d", got, tt.wantRetryCount)
+			}
+			if published.msg.MessageId != delivery.MessageId || string(published.msg.Body) != string(delivery.Body) {
+				t.Errorf("published message %q, want the content of the delivery", published.msg.MessageId)
+			}
+			if origin := toString(published.msg.Headers[headerOriginalRoutingKey]); origin != "appointment.confirmed" {
+				t.Errorf("original routing key = %q, want appointment.confirmed", origin)
+			}
+		})
+	}
+}
+
+func TestConsumerHandleDelaysTheRetryAtTheRetryQueue(t *testing.T) {
+	broker := &fakeBroker{}
+	delivery, _ := newTestDelivery(t, 0)
+	handle := func(ctx context.Context, event domain.Event) error { return errors.New("database unavailable") }
+	if err := newTestConsumer().handle(context.Background(), handle, broker.publish, delivery); err != nil {
+		t.Fatalf("handle() error = %v", err)
+	}
+	if len(broker.published) != 1 || broker.published[0].msg.Expiration != "5000" {
+		t.Fatalf("published %v, want the retry expiring after the retry delay", broker.published)
+	}
+}
+
+func TestConsumerHandleParksWithTheError(t *testing.T) {
+	tests := []struct {
+		name      string
+		delivery  func(t *testing.T) (amqpi.Delivery, *fakeAcknowledger)
+		wantError string
+	}{
+		{"out of retries", func(t *testing.T) (amqpi.Delivery, *fakeAcknowledger) { return newTestDelivery(t, 3) }, "database unavailable"},
+		{"not an event", func(t *testing.T) (amqpi.Delivery, *fakeAcknowledger) {
+			delivery, acknowledger := newTestDelivery(t, 0)
+			delivery.Body = []byte("not json")
+			return delivery, acknowledger
+		}, domain.ErrInvalidEvent.Error()},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			broker := &fakeBroker{}
+			delivery, _ := tt.delivery(t)
+			handle := func(ctx context.Context, event domain.Event) error { return errors.New("database unavailable") }
+			if err := newTestConsumer().handle(context.Background(), handle, broker.publish, delivery); err != nil {
+				t.Fatalf("handle() error = %v", err)
+			}
+			if len(broker.published) != 1 || broker.published[0].exchange != "scheduling.dlx" {
+				t.Fatalf("published %v, want the message parked", broker.published)
+			}
+			headers := broker.published[0].msg.Headers
+			if reason := toString(headers[headerError]); !strings.HasPrefix(reason, tt.wantError) {
+				t.Errorf("error header = %q, want %q", reason, tt.wantError)
+			}
+			if queue := toString(headers[headerOriginalQueue]); queue != "scheduling-service" {
+				t.Errorf("original queue = %q, want scheduling-service", queue)
+			}
+		})
+	}
+}
+
+func TestConsumerHandleNacksWhenPublishingFails(t *testing.T) {
+	tests := []struct {
+		name        string
+		retries     int
+		wantRequeue bool
+	}{
+		// the broker requeues the message, delivering it again at once
+		{"retry", 0, true},
+		// the broker dead-letters the message through the arguments of the queue
+		{"park", 3, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			broker := &fakeBroker{err: errNotConfirmed}
+			delivery, acknowledger := newTestDelivery(t, tt.retries)
+			handle := func(ctx context.Context, event domain.Event) error { return errors.New("database unavailable") }
+			err := newTestConsumer().handle(context.Background(), handle, broker.publish, delivery)
+			if !errors.Is(err, errNotConfirmed) {
+				t.Fatalf("handle() error = %v, want the publishing failure", err)
+			}
+			if acknowledger.acked || !acknowledger.nacked || acknowledger.requeued != tt.wantRequeue {
+				t.Errorf("acked %v, nacked %v, requeued %v, want nacked with requeue %v",
+					acknowledger.acked, acknowledger.nacked, acknowledger.requeued, tt.wantRequeue)
+			}
+		})
+	}
+}
@@ -0,0 +1,242 @@
+package amqp
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/go-kit/log/level"
+	"github.com/ronilsonalves/GoLang-in-a-spring-cloud-architecture/scheduling-service/internal/domain"
+	"github.com/ronilsonalves/GoLang-in-a-spring-cloud-architecture/scheduling-service/pkg/logging"
+	amqpi "github.com/streadway/amqp"
+	"time"
+)
+
+// The headers a message carries through its retries until it's parked at the dead letter queue.
+const (
+	// HeaderRetryCount - the times a message was delivered again after failing.
+	HeaderRetryCount         = "x-retry-count"
+	headerError              = "x-error"
+	headerOriginalQueue      = "x-original-queue"
+	headerOriginalExchange   = "x-original-exchange"
+	headerOriginalRoutingKey = "x-original-routing-key"
+)
+
+// deadLetterHeaders - the headers about a failed delivery, left out when a dead letter is replayed.
+var deadLetterHeaders = []string{
+	HeaderRetryCount, headerError, headerOriginalQueue, headerOriginalExchange, headerOriginalRoutingKey,
+	"x-death", "x-first-death-queue", "x-first-death-reason", "x-first-death-exchange",
+	"x-last-death-queue", "x-last-death-reason", "x-last-death-exchange",
+}
+
+// errNoQueue - a dead letter doesn't tell the queue it came from, so it can't be replayed.
+var errNoQueue = errors.New("the dead letter doesn't tell the queue it came from")
+
+// publishFunc - publish a message and wait for the broker to confirm it, the consumers park and retry messages
+// through it so their handling runs against a fake at tests.
+type publishFunc func(exchange, routingKey string, msg amqpi.Publishing) error
+
+// DeadLetterCollector - moves the messages of the dead letter queue to the store, where they're listed, replayed or
+// discarded, so the queue itself is never browsed.
+type DeadLetterCollector struct {
+	config ConsumerConfig
+}
+
+// NewDeadLetterCollector - Initialize a DeadLetterCollector of the dead letter queue of the config provided, nothing is
+// dialed until it runs.
+func NewDeadLetterCollector(config ConsumerConfig) *DeadLetterCollector {
+	if config.Prefetch < 1 {
+		config.Prefetch = 1
+	}
+	return &DeadLetterCollector{config: config}
+}
+
+// Run - hand the dead letters to save until the context is done, a message is only acknowledged once saved.
+func (c *DeadLetterCollector) Run(ctx context.Context, save func(ctx context.Context, letter domain.DeadLetter) error) {
+	runUntilDone(ctx, c.config.DeadLetterQueue, c.config.RetryDelay, func(ctx context.Context) error {
+		conn, ch, err := dialChannel(c.config.URL)
+		if err != nil {
+			return err
+		}
+		defer conn.Close()
+		defer ch.Close()
+
+		if err := declareDeadLetters(ch, c.config.DeadLetterExchange, c.config.DeadLetterQueue); err != nil {
+			return err
+		}
+		if err := ch.Qos(c.config.Prefetch, 0, false); err != nil {
+			return err
+		}
+		deliveries, err := ch.Consume(c.config.DeadLetterQueue, "", false, false, false, false, nil)
+		if err != nil {
+			return fmt.Errorf("failed to consume %s: %w", c.config.DeadLetterQueue, err)
+		}
+		for {
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case delivery, ok := <-deliveries:
+				if !ok {
+					return errors.New("the channel was closed by the broker")
+				}
+				c.collect(ctx, save, delivery)
+			}
+		}
+	})
+}
+
+// collect - save a dead letter and acknowledge it, requeueing it after the retry delay when it couldn't be saved.
+func (c *DeadLetterCollector) collect(ctx context.Context, save func(ctx context.Context, letter domain.DeadLetter) error, delivery amqpi.Delivery) {
+	if err := save(ctx, deadLetterFrom(delivery, time.Now())); err != nil {
+		level.Error(logging.FromContext(ctx)).Log("msg", "failed to save the dead letter, requeueing it", "message_id", delivery.MessageId, "err", err)
+		wait(ctx, c.config.RetryDelay)
+		delivery.Nack(false, true)
+		return
+	}
+	delivery.Ack(false)
+}
+
+// declareDeadLetters - declare the dead letter exchange and the queue collecting every message dead-lettered to it.
+func declareDeadLetters(ch *amqpi.Channel, exchange, queue string) error {
+	if err := ch.ExchangeDeclare(exchange, amqpi.ExchangeTopic, true, false, false, false, nil); err != nil {
+		return fmt.Errorf("failed to declare the dead letter exchange: %w", err)
+	}
+	if _, err := ch.QueueDeclare(queue, true, false, false, false, nil); err != nil {
+		return fmt.Errorf("failed to declare the dead letter queue: %w", err)
+	}
+	if err := ch.QueueBind(queue, "#", exchange, false, nil); err != nil {
+		return fmt.Errorf("failed to bind the dead letter queue: %w", err)
+	}
+	return nil
+}
+
+// deadLetterArgs - the arguments of a queue whose rejected and expired messages go to the dead letter exchange.
+func deadLetterArgs(exchange string) amqpi.Table {
+	return amqpi.Table{"x-dead-letter-exchange": exchange}
+}
+
+// retryCount - the times a message was delivered again after failing.
+func retryCount(headers amqpi.Table) int {
+	return toInt(headers[HeaderRetryCount])
+}
+
+// withOrigin - a copy of the headers of a delivery recording the queue, exchange and routing key it first came
+// through, the retries go through the default exchange so only the first delivery tells them.
+func withOrigin(delivery amqpi.Delivery, queue string) amqpi.Table {
+	headers := amqpi.Table{}
+	for name, value := range delivery.Headers {
+		headers[name] = value
+	}
+	if _, ok := headers[headerOriginalExchange]; !ok {
+		headers[headerOriginalQueue] = queue
+		headers[headerOriginalExchange] = delivery.Exchange
+		headers[headerOriginalRoutingKey] = delivery.RoutingKey
+	}
+	return headers
+}
+
+// republishing - a message with the content of a delivery and the headers provided.
+func republishing(delivery amqpi.Delivery, headers amqpi.Table) amqpi.Publishing {
+	return amqpi.Publishing{
+		Headers:         headers,
+		ContentType:     delivery.ContentType,
+		ContentEncoding: delivery.ContentEncoding,
+		DeliveryMode:    amqpi.Persistent,
+		CorrelationId:   delivery.CorrelationId,
+		MessageId:       delivery.MessageId,
+		Timestamp:       delivery.Timestamp,
+		Type:            delivery.Type,
+		AppId:           delivery.AppId,
+		Body:            delivery.Body,
+	}
+}
+
+// deadLetterFrom - the dead letter of a delivery from the dead letter queue. The messages parked by our consumers tell
+// where they came from and why at their headers, the ones dead-lettered by the broker at its x-death header.
+func deadLetterFrom(delivery amqpi.Delivery, deadLetteredAt time.Time) domain.DeadLetter {
+	letter := domain.DeadLetter{
+		MessageID:      delivery.MessageId,
+		Queue:          toString(delivery.Headers[headerOriginalQueue]),
+		Exchange:       toString(delivery.Headers[headerOriginalExchange]),
+		RoutingKey:     toString(delivery.Headers[headerOriginalRoutingKey]),
+		Reason:         toString(delivery.Headers[headerError]),
+		RetryCount:     retryCount(delivery.Headers),
+		ContentType:    delivery.ContentType,
+		Headers:        delivery.Headers,
+		Body:           string(delivery.Body),
+		DeadLetteredAt: deadLetteredAt.Format("02/01/2006 15:04:05"),
+	}
+	if letter.Headers == nil {
+		letter.Headers = amqpi.Table{}
+	}
+	if deaths, ok := delivery.Headers["x-death"].([]interface{}); ok && len(deaths) > 0 && letter.Queue == "" {
+		if death, ok := deaths[0].(amqpi.Table); ok {
+			letter.Queue = toString(death["queue"])
+			letter.Exchange = toString(death["exchange"])
+			letter.Reason = toString(death["reason"])
+			letter.RetryCount = toInt(death["count"])
+			if keys, ok := death["routing-keys"].([]interface{}); ok && len(keys) > 0 {
+				letter.RoutingKey = toString(keys[0])
+			}
+		}
+	}
+	if letter.RoutingKey == "" {
+		letter.RoutingKey = delivery.RoutingKey
+	}
+	return letter
+}
+
+// replayPublishing - the message replaying a dead letter and the queue it goes back to, the headers about its failure
+// are left out so it starts its retries over.
+func replayPublishing(letter domain.DeadLetter) (string, amqpi.Publishing, error) {
+	if letter.Queue == "" {
+		return "", amqpi.Publishing{}, errNoQueue
+	}
+	headers := toTable(letter.Headers)
+	for _, name := range deadLetterHeaders {
+		delete(headers, name)
+	}
+	return letter.Queue, amqpi.Publishing{
+		Headers:      headers,
+		ContentType:  letter.ContentType,
+		DeliveryMode: amqpi.Persistent,
+		MessageId:    letter.MessageID,
+		Timestamp:    time.Now(),
+		Body:         []byte(letter.Body),
+	}, nil
+}
+
+// toTable - the headers provided as an AMQP table, the nested maps read back from the store included.
+func toTable(headers map[string]interface{}) amqpi.Table {
+	table := amqpi.Table{}
+	for name, value := range headers {
+		switch v := value.(type) {
+		case map[string]interface{}:
+			table[name] = toTable(v)
+		default:
+			table[name] = v
+		}
+	}
+	return table
+}
+
+func toString(value interface{}) string {
+	s, _ := value.(string)
+	return s
+}
+
+// toInt - an integer header, whatever the width it was sent with or read back from the store as.
+func toInt(value interface{}) int {
+	switch v := value.(type) {
+	case int:
+		return v
+	case int16:
+		return int(v)
+	case int32:
+		return int(v)
+	case int64:
+		return int(v)
+	case float64:
+		return int(v)
+	}
+	return 0
+}
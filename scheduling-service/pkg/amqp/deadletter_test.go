@@ -0,0 +1,139 @@
+package amqp
+
+import (
+	"errors"
+	"github.com/ronilsonalves/GoLang-in-a-spring-cloud-architecture/scheduling-service/internal/domain"
+	amqpi "github.com/streadway/amqp"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestDeadLetterFrom(t *testing.T) {
+	deadLetteredAt := time.Date(2023, 1, 30, 10, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name     string
+		delivery amqpi.Delivery
+		want     domain.DeadLetter
+	}{
+		{
+			name: "parked by a consumer",
+			delivery: amqpi.Delivery{
+				MessageId:  "1",
+				Exchange:   "scheduling.dlx",
+				RoutingKey: "invoice.paid",
+				Headers: amqpi.Table{
+					headerOriginalQueue:      "scheduling-service",
+					headerOriginalExchange:   "invoice.events",
+					headerOriginalRoutingKey: "invoice.paid",
+					headerError:              "database unavailable",
+					HeaderRetryCount:         int32(3),
+					// the retries went through the retry queue, which doesn't tell where the message came from
+					"x-death": []interface{}{amqpi.Table{"queue": "scheduling-service.retry", "reason": "expired", "count": int64(3)}},
+				},
+				Body: []byte(`{}`),
+			},
+			want: domain.DeadLetter{
+				MessageID:  "1",
+				Queue:      "scheduling-service",
+				Exchange:   "invoice.events",
+				RoutingKey: "invoice.paid",
+				Reason:     "database unavailable",
+				RetryCount: 3,
+			},
+		},
+		{
+			name: "dead-lettered by the broker",
+			delivery: amqpi.Delivery{
+				MessageId:  "2",
+				Exchange:   "scheduling.dlx",
+				RoutingKey: "appointment.created",
+				Headers: amqpi.Table{
+					"x-death": []interface{}{amqpi.Table{
+						"queue":        "scheduling-service",
+						"exchange":     "scheduling.events",
+						"reason":       "rejected",
+						"count":        int64(1),
+						"routing-keys": []interface{}{"appointment.created"},
+					}},
+				},
+				Body: []byte(`{}`),
+			},
+			want: domain.DeadLetter{
+				MessageID:  "2",
+				Queue:      "scheduling-service",
+				Exchange:   "scheduling.events",
+				RoutingKey: "appointment.created",
+				Reason:     "rejected",
+				RetryCount: 1,
+			},
+		},
+		{
+			name:     "without headers",
+			delivery: amqpi.Delivery{MessageId: "3", RoutingKey: "invoice.paid", Body: []byte(`{}`)},
+			want:     domain.DeadLetter{MessageID: "3", RoutingKey: "invoice.paid"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := deadLetterFrom(tt.delivery, deadLetteredAt)
+			got.Headers = nil
+			tt.want.Body = "{}"
+			tt.want.DeadLetteredAt = "30/01/2023 10:00:00"
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("deadLetterFrom() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestReplayPublishing(t *testing.T) {
+	letter := domain.DeadLetter{
+		MessageID:   "1",
+		Queue:       "scheduling-service",
+		ContentType: "application/json",
+		// as read back from the store
+		Headers: map[string]interface{}{
+			"traceparent":            testTraceParent,
+			"cloudEvents:id":         "1",
+			"nested":                 map[string]interface{}{"key": "value"},
+			HeaderRetryCount:         float64(3),
+			headerError:              "database unavailable",
+			headerOriginalQueue:      "scheduling-service",
+			headerOriginalExchange:   "invoice.events",
+			headerOriginalRoutingKey: "invoice.paid",
+			"x-death":                []interface{}{map[string]interface{}{"queue": "scheduling-service.retry"}},
+			"x-first-death-queue":    "scheduling-service.retry",
+			"x-first-death-reason":   "expired",
+			"x-first-death-exchange": "",
+		},
+		Body: `{"id":1}`,
+	}
+	queue, msg, err := replayPublishing(letter)
+	if err != nil {
+		t.Fatalf("replayPublishing() error = %v", err)
+	}
+	if queue != "scheduling-service" {
+		t.Errorf("queue = %q, want scheduling-service", queue)
+	}
+	want := amqpi.Table{
+		"traceparent":    testTraceParent,
+		"cloudEvents:id": "1",
+		"nested":         amqpi.Table{"key": "value"},
+	}
+	if !reflect.DeepEqual(msg.Headers, want) {
+		t.Errorf("headers = %v, want %v", msg.Headers, want)
+	}
+	if msg.MessageId != "1" || msg.ContentType != "application/json" || string(msg.Body) != `{"id":1}` || msg.DeliveryMode != amqpi.Persistent {
+		t.Errorf("message = %+v, want the content of the dead letter", msg)
+	}
+	if _, ok := letter.Headers[headerError]; !ok {
+		t.Error("replayPublishing() changed the headers of the dead letter")
+	}
+}
+
+func TestReplayPublishingWithoutQueue(t *testing.T) {
+	if _, _, err := replayPublishing(domain.DeadLetter{MessageID: "1"}); !errors.Is(err, errNoQueue) {
+		t.Errorf("replayPublishing() error = %v, want errNoQueue", err)
+	}
+}
@@ -0,0 +1,143 @@
+package amqp
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/ronilsonalves/GoLang-in-a-spring-cloud-architecture/scheduling-service/internal/domain"
+	amqpi "github.com/streadway/amqp"
+	"time"
+)
+
+// MessageFormat - how the events are written into the AMQP messages.
+type MessageFormat string
+
+const (
+	// FormatEnvelope - the body is the event envelope as JSON, the default format.
+	FormatEnvelope MessageFormat = "envelope"
+	// FormatCloudEventsBinary - CloudEvents 1.0 binary mode, the attributes go as cloudEvents: headers and the body is
+	// the event payload.
+	FormatCloudEventsBinary MessageFormat = "cloudevents-binary"
+	// FormatCloudEventsStructured - CloudEvents 1.0 structured mode, the body is the whole CloudEvent as JSON.
+	FormatCloudEventsStructured MessageFormat = "cloudevents-structured"
+)
+
+const (
+	// cloudEventsSpecVersion - the CloudEvents version the messages comply with.
+	cloudEventsSpecVersion = "1.0"
+	// cloudEventsHeaderPrefix - the prefix of the attributes sent as headers in binary mode, as the AMQP binding defines.
+	cloudEventsHeaderPrefix = "cloudEvents:"
+	// cloudEventsContentType - the content type of the messages in structured mode.
+	cloudEventsContentType = "application/cloudevents+json"
+	// dataContentType - the content type of the event payloads.
+	dataContentType = "application/json"
+)
+
+// ParseMessageFormat - return the format named, the envelope one when the name is empty.
+func ParseMessageFormat(name string) (MessageFormat, error) {
+	switch format := MessageFormat(name); format {
+	case "":
+		return FormatEnvelope, nil
+	case FormatEnvelope, FormatCloudEventsBinary, FormatCloudEventsStructured:
+		return format, nil
+	default:
+		return "", fmt.Errorf("unknown message format %q, use %s, %s or %s", name,
+			FormatEnvelope, FormatCloudEventsBinary, FormatCloudEventsStructured)
+	}
+}
+
+// cloudEvent - an event in the CloudEvents 1.0 JSON format, schemaversion is an extension attribute.
+type cloudEvent struct {
+	SpecVersion     string          `json:"specversion"`
+	ID              string          `json:"id"`
+	Source          string          `json:"source"`
+	Type            string          `json:"type"`
+	Time            string          `json:"time"`
+	DataContentType string          `json:"datacontenttype"`
+	SchemaVersion   int             `json:"schemaversion"`
+	Data            json.RawMessage `json:"data"`
+}
+
+// encodeEvent - write an event into a message in the format provided, source is the CloudEvents source attribute.
+func encodeEvent(event domain.Event, format MessageFormat, source string) (amqpi.Publishing, error) {
+	msg := amqpi.Publishing{
+		DeliveryMode: amqpi.Persistent,
+		MessageId:    event.ID,
+		Type:         string(event.Type),
+		Timestamp:    event.OccurredAt,
+	}
+	switch format {
+	case FormatCloudEventsBinary:
+		msg.ContentType = dataContentType
+		msg.Headers = amqpi.Table{
+			cloudEventsHeaderPrefix + "specversion":   cloudEventsSpecVersion,
+			cloudEventsHeaderPrefix + "id":            event.ID,
+			cloudEventsHeaderPrefix + "source":        source,
+			cloudEventsHeaderPrefix + "type":          string(event.Type),
+			cloudEventsHeaderPrefix + "time":          event.OccurredAt.UTC().Format(time.RFC3339),
+			cloudEventsHeaderPrefix + "schemaversion": int32(event.SchemaVersion),
+		}
+		msg.Body = event.Payload
+	case FormatCloudEventsStructured:
+		body, err := json.Marshal(cloudEvent{
+			SpecVersion:     cloudEventsSpecVersion,
+			ID:              event.ID,
+			Source:          source,
+			Type:            string(event.Type),
+			Time:            event.OccurredAt.UTC().Format(time.RFC3339),
+			DataContentType: dataContentType,
+			SchemaVersion:   event.SchemaVersion,
+			Data:            event.Payload,
+		})
+		if err != nil {
+			return amqpi.Publishing{}, err
+		}
+		msg.ContentType = cloudEventsContentType
+		msg.Body = body
+	default:
+		body, err := json.Marshal(event)
+		if err != nil {
+			return amqpi.Publishing{}, err
+		}
+		msg.ContentType = dataContentType
+		msg.Body = body
+	}
+	return msg, nil
+}
+
+// decodeEvent - read the event of a message in any of the formats, told apart by the content type and headers.
+func decodeEvent(delivery amqpi.Delivery) (domain.Event, error) {
+	var event domain.Event
+	switch {
+	case delivery.ContentType == cloudEventsContentType:
+		var ce cloudEvent
+		if err := json.Unmarshal(delivery.Body, &ce); err != nil {
+			return domain.Event{}, fmt.Errorf("%w: %s", domain.ErrInvalidEvent, err)
+		}
+		occurredAt, err := time.Parse(time.RFC3339, ce.Time)
+		if err != nil {
+			return domain.Event{}, fmt.Errorf("%w: invalid time %q", domain.ErrInvalidEvent, ce.Time)
+		}
+		event = domain.Event{ID: ce.ID, Type: domain.EventType(ce.Type), OccurredAt: occurredAt, SchemaVersion: ce.SchemaVersion, Payload: ce.Data}
+	case delivery.Headers[cloudEventsHeaderPrefix+"specversion"] != nil:
+		header := func(name string) string {
+			value, _ := delivery.Headers[cloudEventsHeaderPrefix+name].(string)
+			return value
+		}
+		occurredAt, err := time.Parse(time.RFC3339, header("time"))
+		if err != nil {
+			return domain.Event{}, fmt.Errorf("%w: invalid time %q", domain.ErrInvalidEvent, header("time"))
+		}
+		event = domain.Event{ID: header("id"), Type: domain.EventType(header("type")), OccurredAt: occurredAt, SchemaVersion: domain.EventSchemaVersion, Payload: delivery.Body}
+		if version, ok := delivery.Headers[cloudEventsHeaderPrefix+"schemaversion"].(int32); ok {
+			event.SchemaVersion = int(version)
+		}
+	default:
+		if err := json.Unmarshal(delivery.Body, &event); err != nil {
+			return domain.Event{}, fmt.Errorf("%w: %s", domain.ErrInvalidEvent, err)
+		}
+	}
+	if event.ID == "" || event.Type == "" {
+		return domain.Event{}, fmt.Errorf("%w: the event id and type are required", domain.ErrInvalidEvent)
+	}
+	return event, nil
+}
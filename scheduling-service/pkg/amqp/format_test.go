@@ -0,0 +1,176 @@
+package amqp
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"github.com/ronilsonalves/GoLang-in-a-spring-cloud-architecture/scheduling-service/internal/domain"
+	"github.com/ronilsonalves/GoLang-in-a-spring-cloud-architecture/scheduling-service/pkg/tracing"
+	amqpi "github.com/streadway/amqp"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+const testTraceParent = "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01"
+
+func newTestEvent(t *testing.T) domain.Event {
+	t.Helper()
+	event, err := domain.NewEvent(domain.EventAppointmentConfirmed, domain.Appointment{Id: 42, DentistCRO: "CRO-1", PatientRG: "RG-1"})
+	if err != nil {
+		t.Fatalf("NewEvent() error = %v", err)
+	}
+	return event
+}
+
+// deliveryOf - the delivery a consumer receives for the message published.
+func deliveryOf(msg amqpi.Publishing) amqpi.Delivery {
+	return amqpi.Delivery{
+		ContentType: msg.ContentType,
+		MessageId:   msg.MessageId,
+		Type:        msg.Type,
+		Timestamp:   msg.Timestamp,
+		Headers:     msg.Headers,
+		Body:        msg.Body,
+	}
+}
+
+func TestEncodeEventRoundTrip(t *testing.T) {
+	for _, format := range []MessageFormat{FormatEnvelope, FormatCloudEventsBinary, FormatCloudEventsStructured} {
+		t.Run(string(format), func(t *testing.T) {
+			event := newTestEvent(t)
+			msg, err := encodeEvent(event, format, "/scheduling-service")
+			if err != nil {
+				t.Fatalf("encodeEvent() error = %v", err)
+			}
+			if msg.MessageId != event.ID || msg.Type != string(event.Type) || msg.DeliveryMode != amqpi.Persistent {
+				t.Errorf("message properties = id %q, type %q, delivery mode %d", msg.MessageId, msg.Type, msg.DeliveryMode)
+			}
+
+			decoded, err := decodeEvent(deliveryOf(msg))
+			if err != nil {
+				t.Fatalf("decodeEvent() error = %v", err)
+			}
+			if decoded.ID != event.ID || decoded.Type != event.Type || decoded.SchemaVersion != event.SchemaVersion {
+				t.Errorf("decodeEvent() = %+v, want %+v", decoded, event)
+			}
+			if !decoded.OccurredAt.Equal(event.OccurredAt) {
+				t.Errorf("OccurredAt = %v, want %v", decoded.OccurredAt, event.OccurredAt)
+			}
+			assertJSONEqual(t, decoded.Payload, event.Payload)
+		})
+	}
+}
+
+func TestEncodeEventCloudEventsBinary(t *testing.T) {
+	event := newTestEvent(t)
+	msg, err := encodeEvent(event, FormatCloudEventsBinary, "/scheduling-service")
+	if err != nil {
+		t.Fatalf("encodeEvent() error = %v", err)
+	}
+	want := amqpi.Table{
+		"cloudEvents:specversion":   "1.0",
+		"cloudEvents:id":            event.ID,
+		"cloudEvents:source":        "/scheduling-service",
+		"cloudEvents:type":          "appointment.confirmed",
+		"cloudEvents:time":          event.OccurredAt.UTC().Format(time.RFC3339),
+		"cloudEvents:schemaversion": int32(domain.EventSchemaVersion),
+	}
+	if !reflect.DeepEqual(msg.Headers, want) {
+		t.Errorf("headers = %v, want %v", msg.Headers, want)
+	}
+	// the datacontenttype attribute maps to the content type of the message in binary mode
+	if msg.ContentType != "application/json" {
+		t.Errorf("ContentType = %q, want application/json", msg.ContentType)
+	}
+	assertJSONEqual(t, msg.Body, event.Payload)
+}
+
+func TestEncodeEventCloudEventsStructured(t *testing.T) {
+	event := newTestEvent(t)
+	msg, err := encodeEvent(event, FormatCloudEventsStructured, "/scheduling-service")
+	if err != nil {
+		t.Fatalf("encodeEvent() error = %v", err)
+	}
+	if msg.ContentType != "application/cloudevents+json" {
+		t.Errorf("ContentType = %q, want application/cloudevents+json", msg.ContentType)
+	}
+	var attributes map[string]interface{}
+	if err := json.Unmarshal(msg.Body, &attributes); err != nil {
+		t.Fatalf("json.Unmarshal() error = %v", err)
+	}
+	for name, want := range map[string]interface{}{
+		"specversion":     "1.0",
+		"id":              event.ID,
+		"source":          "/scheduling-service",
+		"type":            "appointment.confirmed",
+		"time":            event.OccurredAt.UTC().Format(time.RFC3339),
+		"datacontenttype": "application/json",
+	} {
+		if attributes[name] != want {
+			t.Errorf("attribute %s = %v, want %v", name, attributes[name], want)
+		}
+	}
+	data, _ := json.Marshal(attributes["data"])
+	assertJSONEqual(t, data, event.Payload)
+}
+
+func TestDecodeEventRefusesInvalidMessages(t *testing.T) {
+	tests := []struct {
+		name     string
+		delivery amqpi.Delivery
+	}{
+		{"envelope not in JSON", amqpi.Delivery{ContentType: "application/json", Body: []byte("not json")}},
+		{"envelope without type", amqpi.Delivery{ContentType: "application/json", Body: []byte(`{"eventId":"1"}`)}},
+		{"structured with invalid time", amqpi.Delivery{ContentType: "application/cloudevents+json", Body: []byte(`{"id":"1","type":"invoice.paid","time":"yesterday"}`)}},
+		{"binary without id", amqpi.Delivery{
+			Headers: amqpi.Table{"cloudEvents:specversion": "1.0", "cloudEvents:type": "invoice.paid", "cloudEvents:time": "2023-01-30T10:00:00Z"},
+			Body:    []byte(`{}`),
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := decodeEvent(tt.delivery); !errors.Is(err, domain.ErrInvalidEvent) {
+				t.Errorf("decodeEvent() error = %v, want ErrInvalidEvent", err)
+			}
+		})
+	}
+}
+
+func TestStartPublishInjectsTheTraceParent(t *testing.T) {
+	tracing.Setup("scheduling-service", nil, 1)
+	for _, format := range []MessageFormat{FormatEnvelope, FormatCloudEventsBinary, FormatCloudEventsStructured} {
+		t.Run(string(format), func(t *testing.T) {
+			msg, err := encodeEvent(newTestEvent(t), format, "/scheduling-service")
+			if err != nil {
+				t.Fatalf("encodeEvent() error = %v", err)
+			}
+			ctx := tracing.ContextWithTraceParent(context.Background(), testTraceParent)
+			_, span := startPublish(ctx, "scheduling.events", "appointment.confirmed", &msg)
+			span.End()
+
+			traceParent, _ := msg.Headers["traceparent"].(string)
+			if !strings.HasPrefix(traceParent, "00-4bf92f3577b34da6a3ce929d0e0e4736-") {
+				t.Errorf("traceparent header = %q, want the trace of the request", traceParent)
+			}
+			if format == FormatCloudEventsBinary && msg.Headers["cloudEvents:id"] == nil {
+				t.Error("the trace context replaced the CloudEvents headers")
+			}
+		})
+	}
+}
+
+func assertJSONEqual(t *testing.T, got, want []byte) {
+	t.Helper()
+	var gotValue, wantValue interface{}
+	if err := json.Unmarshal(got, &gotValue); err != nil {
+		t.Fatalf("json.Unmarshal(%s) error = %v", got, err)
+	}
+	if err := json.Unmarshal(want, &wantValue); err != nil {
+		t.Fatalf("json.Unmarshal(%s) error = %v", want, err)
+	}
+	if !reflect.DeepEqual(gotValue, wantValue) {
+		t.Errorf("JSON = %s, want %s", got, want)
+	}
+}
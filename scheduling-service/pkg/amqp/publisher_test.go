@@ -0,0 +1,219 @@
+package amqp
+
+import (
+	"context"
+	"errors"
+	amqpi "github.com/streadway/amqp"
+	"sync"
+	"testing"
+	"time"
+)
+
+// fakeConnection - a connection whose channels answer the messages with the confirms provided, in order, ack once
+// they're over. A confirm of nil is never sent.
+type fakeConnection struct {
+	mu       sync.Mutex
+	confirms []*bool
+	closed   bool
+	channels int
+	messages []amqpi.Publishing
+}
+
+func (c *fakeConnection) Channel() (publishChannel, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.channels++
+	return &fakeChannel{conn: c}, nil
+}
+
+func (c *fakeConnection) IsClosed() bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.closed
+}
+
+func (c *fakeConnection) Close() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.closed = true
+	return nil
+}
+
+// fakeChannel - a channel of a fakeConnection.
+type fakeChannel struct {
+	conn     *fakeConnection
+	confirms chan amqpi.Confirmation
+	tag      uint64
+}
+
+func (ch *fakeChannel) Confirm(noWait bool) error { return nil }
+
+func (ch *fakeChannel) NotifyPublish(confirms chan amqpi.Confirmation) chan amqpi.Confirmation {
+	ch.confirms = confirms
+	return confirms
+}
+
+func (ch *fakeChannel) Publish(exchange, key string, mandatory, immediate bool, msg amqpi.Publishing) error {
+	c := ch.conn
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.closed {
+		return amqpi.ErrClosed
+	}
+	c.messages = append(c.messages, msg)
+	ack := true
+	if len(c.confirms) > 0 {
+		next := c.confirms[0]
+		c.confirms = c.confirms[1:]
+		if next == nil {
+			return nil
+		}
+		ack = *next
+	}
+	ch.tag++
+	ch.confirms <- amqpi.Confirmation{DeliveryTag: ch.tag, Ack: ack}
+	return nil
+}
+
+func (ch *fakeChannel) Close() error { return nil }
+
+func confirm(ack bool) *bool { return &ack }
+
+// newTestPublisher - a publisher dialing the connections provided in order, failing with the dial error once they're
+// over, along with the count of the dials.
+func newTestPublisher(dialErr error, conns ...*fakeConnection) (*Publisher, func() int) {
+	p := NewPublisher(PublisherConfig{
+		Exchange:       "scheduling.events",
+		MaxRetries:     2,
+		RetryBackoff:   time.Millisecond,
+		ConfirmTimeout: 50 * time.Millisecond,
+	})
+	var mu sync.Mutex
+	dials := 0
+	p.dial = func() (connection, error) {
+		mu.Lock()
+		defer mu.Unlock()
+		dials++
+		if dials > len(conns) {
+			return nil, dialErr
+		}
+		return conns[dials-1], nil
+	}
+	return p, func() int {
+		mu.Lock()
+		defer mu.Unlock()
+		return dials
+	}
+}
+
+func TestPublisherPublish(t *testing.T) {
+	errUnreachable := errors.New("connection refused")
+	tests := []struct {
+		name         string
+		confirms     []*bool
+		wantErr      error
+		wantMessages int
+		wantChannels int
+	}{
+		{"confirmed", nil, nil, 1, 1},
+		{"refused then confirmed", []*bool{confirm(false)}, nil, 2, 2},
+		{"not confirmed in time then confirmed", []*bool{nil}, nil, 2, 2},
+		{"refused at every attempt", []*bool{confirm(false), confirm(false), confirm(false)}, errNotConfirmed, 3, 3},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			conn := &fakeConnection{confirms: tt.confirms}
+			p, dials := newTestPublisher(errUnreachable, conn)
+			err := p.Publish(context.Background(), "appointment.created", amqpi.Publishing{Body: []byte(`{}`)})
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Publish() error = %v, want %v", err, tt.wantErr)
+			}
+			if len(conn.messages) != tt.wantMessages || conn.channels != tt.wantChannels {
+				t.Errorf("published %d message(s) at %d channel(s), want %d at %d", len(conn.messages), conn.channels, tt.wantMessages, tt.wantChannels)
+			}
+			if dials() != 1 {
+				t.Errorf("dialed %d times, want the connection reused", dials())
+			}
+		})
+	}
+}
+
+func TestPublisherReusesTheConfirmedChannels(t *testing.T) {
+	conn := &fakeConnection{}
+	p, _ := newTestPublisher(nil, conn)
+	for i := 0; i < 3; i++ {
+		if err := p.Publish(context.Background(), "appointment.created", amqpi.Publishing{}); err != nil {
+			t.Fatalf("Publish() error = %v", err)
+		}
+	}
+	if conn.channels != 1 {
+		t.Errorf("opened %d channels, want the confirmed one reused", conn.channels)
+	}
+}
+
+func TestPublisherReconnectsWhenTheConnectionIsLost(t *testing.T) {
+	lost, reconnected := &fakeConnection{}, &fakeConnection{}
+	p, dials := newTestPublisher(nil, lost, reconnected)
+	if err := p.Publish(context.Background(), "appointment.created", amqpi.Publishing{}); err != nil {
+		t.Fatalf("Publish() error = %v", err)
+	}
+	lost.Close()
+	if err := p.Publish(context.Background(), "appointment.confirmed", amqpi.Publishing{}); err != nil {
+		t.Fatalf("Publish() after the connection was lost error = %v", err)
+	}
+	if dials() != 2 || len(reconnected.messages) != 1 {
+		t.Errorf("dialed %d times and published %d message(s) at the new connection, want 2 and 1", dials(), len(reconnected.messages))
+	}
+}
+
+func TestPublisherRetriesUntilTheBrokerIsReachable(t *testing.T) {
+	errUnreachable := errors.New("connection refused")
+	p, dials := newTestPublisher(errUnreachable)
+	err := p.Publish(context.Background(), "appointment.created", amqpi.Publishing{})
+	if !errors.Is(err, errUnreachable) {
+		t.Fatalf("Publish() error = %v, want %v", err, errUnreachable)
+	}
+	if dials() != 3 {
+		t.Errorf("dialed %d times, want the first attempt and 2 retries", dials())
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if err := p.Publish(ctx, "appointment.created", amqpi.Publishing{}); !errors.Is(err, context.Canceled) {
+		t.Errorf("Publish() with the context done error = %v, want context.Canceled", err)
+	}
+}
+
+func TestPublisherDialsWithoutHoldingTheLock(t *testing.T) {
+	p := NewPublisher(PublisherConfig{Exchange: "scheduling.events", ConfirmTimeout: time.Second})
+	dialing, unreachable := make(chan struct{}), make(chan struct{})
+	p.dial = func() (connection, error) {
+		close(dialing)
+		<-unreachable
+		return &fakeConnection{}, nil
+	}
+	published := make(chan error, 1)
+	go func() {
+		published <- p.Publish(context.Background(), "appointment.created", amqpi.Publishing{})
+	}()
+	<-dialing
+
+	closed := make(chan error, 1)
+	go func() { closed <- p.Close() }()
+	select {
+	case err := <-closed:
+		if err != nil {
+			t.Fatalf("Close() error = %v", err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("Close() waited for the dial")
+	}
+
+	close(unreachable)
+	if err := <-published; !errors.Is(err, ErrPublisherClosed) {
+		t.Errorf("Publish() error = %v, want ErrPublisherClosed", err)
+	}
+	if err := p.Check(context.Background()); !errors.Is(err, ErrPublisherClosed) {
+		t.Errorf("Check() error = %v, want ErrPublisherClosed", err)
+	}
+}
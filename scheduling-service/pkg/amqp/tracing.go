@@ -0,0 +1,69 @@
+package amqp
+
+import (
+	"context"
+	"github.com/ronilsonalves/GoLang-in-a-spring-cloud-architecture/scheduling-service/pkg/tracing"
+	amqpi "github.com/streadway/amqp"
+	semconv "go.opentelemetry.io/otel/semconv/v1.12.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// tableCarrier - reads and writes the trace context at the headers of a message, as the traceparent header the
+// Spring services read with Micrometer Tracing.
+type tableCarrier amqpi.Table
+
+// Get - the header provided, empty when it's missing or isn't a string.
+func (t tableCarrier) Get(key string) string {
+	value, _ := t[key].(string)
+	return value
+}
+
+// Set - write the header provided.
+func (t tableCarrier) Set(key, value string) {
+	t[key] = value
+}
+
+// Keys - the headers of the message.
+func (t tableCarrier) Keys() []string {
+	keys := make([]string, 0, len(t))
+	for key := range t {
+		keys = append(keys, key)
+	}
+	return keys
+}
+
+// startPublish - start the producer span of a message sent to the exchange provided and write its trace context at
+// the message headers, so the consumers continue the trace.
+func startPublish(ctx context.Context, exchange, routingKey string, msg *amqpi.Publishing) (context.Context, trace.Span) {
+	ctx, span := tracing.Tracer().Start(ctx, routingKey+" publish",
+		trace.WithSpanKind(trace.SpanKindProducer),
+		trace.WithAttributes(
+			semconv.MessagingSystemKey.String("rabbitmq"),
+			semconv.MessagingDestinationKindTopic,
+			semconv.MessagingDestinationKey.String(exchange),
+			semconv.MessagingRabbitmqRoutingKeyKey.String(routingKey),
+			semconv.MessagingMessageIDKey.String(msg.MessageId),
+		))
+	if msg.Headers == nil {
+		msg.Headers = amqpi.Table{}
+	}
+	tracing.Inject(ctx, tableCarrier(msg.Headers))
+	return ctx, span
+}
+
+// startProcess - start the consumer span of a message read from the queue provided, within the trace of its
+// publisher.
+func startProcess(ctx context.Context, queue string, delivery amqpi.Delivery) (context.Context, trace.Span) {
+	if delivery.Headers != nil {
+		ctx = tracing.Extract(ctx, tableCarrier(delivery.Headers))
+	}
+	return tracing.Tracer().Start(ctx, queue+" process",
+		trace.WithSpanKind(trace.SpanKindConsumer),
+		trace.WithAttributes(
+			semconv.MessagingSystemKey.String("rabbitmq"),
+			semconv.MessagingOperationProcess,
+			semconv.MessagingDestinationKey.String(queue),
+			semconv.MessagingRabbitmqRoutingKeyKey.String(delivery.RoutingKey),
+			semconv.MessagingMessageIDKey.String(delivery.MessageId),
+		))
+}
@@ -0,0 +1,124 @@
+package amqp
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"github.com/ronilsonalves/GoLang-in-a-spring-cloud-architecture/scheduling-service/pkg/middleware"
+	"github.com/ronilsonalves/GoLang-in-a-spring-cloud-architecture/scheduling-service/pkg/tracing"
+	amqpi "github.com/streadway/amqp"
+	"go.opentelemetry.io/otel"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// fakeConnector - a database whose queries return no rows.
+type fakeConnector struct{}
+
+func (fakeConnector) Connect(ctx context.Context) (driver.Conn, error) { return fakeConn{}, nil }
+func (fakeConnector) Driver() driver.Driver                            { return nil }
+
+type fakeConn struct{}
+
+func (fakeConn) Prepare(query string) (driver.Stmt, error) { return fakeStmt{}, nil }
+func (fakeConn) Close() error                              { return nil }
+func (fakeConn) Begin() (driver.Tx, error)                 { return nil, driver.ErrSkip }
+
+type fakeStmt struct{}
+
+func (fakeStmt) Close() error                                    { return nil }
+func (fakeStmt) NumInput() int                                   { return -1 }
+func (fakeStmt) Exec(args []driver.Value) (driver.Result, error) { return driver.RowsAffected(0), nil }
+func (fakeStmt) Query(args []driver.Value) (driver.Rows, error)  { return fakeRows{}, nil }
+
+type fakeRows struct{}
+
+func (fakeRows) Columns() []string              { return []string{"id"} }
+func (fakeRows) Close() error                   { return nil }
+func (fakeRows) Next(dest []driver.Value) error { return io.EOF }
+
+func TestTraceFollowsTheRequestToTheMessage(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	exporter := tracetest.NewInMemoryExporter()
+	shutdown := tracing.Setup("scheduling-service", exporter, 1)
+	defer shutdown(context.Background())
+
+	db := sql.OpenDB(tracing.WrapConnector(fakeConnector{}, "mysql"))
+	defer db.Close()
+
+	var msg amqpi.Publishing
+	router := gin.New()
+	router.Use(middleware.Tracing())
+	router.POST("/appointments", func(c *gin.Context) {
+		ctx := c.Request.Context()
+		rows, err := db.QueryContext(ctx, "SELECT id FROM appointments WHERE dentist_id = ?", 1)
+		if err != nil {
+			t.Errorf("QueryContext() error = %v", err)
+			return
+		}
+		rows.Close()
+
+		msg, err = encodeEvent(newTestEvent(t), FormatEnvelope, "/scheduling-service")
+		if err != nil {
+			t.Errorf("encodeEvent() error = %v", err)
+			return
+		}
+		// the relay publishes later, within the trace recorded along with the outbox message
+		publishCtx := tracing.ContextWithTraceParent(context.Background(), tracing.TraceParent(ctx))
+		_, span := startPublish(publishCtx, "scheduling.events", "appointment.created", &msg)
+		span.End()
+		c.Status(http.StatusCreated)
+	})
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/appointments", nil))
+	if rec.Code != http.StatusCreated {
+		t.Fatalf("status = %d, want %d", rec.Code, http.StatusCreated)
+	}
+
+	_, span := startProcess(context.Background(), "invoice-service.appointments", deliveryOf(msg))
+	span.End()
+
+	if err := otel.GetTracerProvider().(*sdktrace.TracerProvider).ForceFlush(context.Background()); err != nil {
+		t.Fatalf("ForceFlush() error = %v", err)
+	}
+	spans := map[string]tracetest.SpanStub{}
+	for _, s := range exporter.GetSpans() {
+		spans[s.Name] = s
+	}
+	request, query := spans["POST /appointments"], spans["SELECT"]
+	publish, process := spans["appointment.created publish"], spans["invoice-service.appointments process"]
+	for name, s := range map[string]tracetest.SpanStub{"request": request, "query": query, "publish": publish, "process": process} {
+		if !s.SpanContext.IsValid() {
+			t.Fatalf("the %s span wasn't exported, got %v", name, exporter.GetSpans().Snapshots())
+		}
+		if s.SpanContext.TraceID() != request.SpanContext.TraceID() {
+			t.Errorf("the %s span is at trace %s, want the trace of the request %s", name, s.SpanContext.TraceID(), request.SpanContext.TraceID())
+		}
+	}
+	if request.Parent.IsValid() {
+		t.Errorf("the request span has parent %s, want it to start the trace", request.Parent.SpanID())
+	}
+	for _, tt := range []struct {
+		child, parent string
+		got, want     tracetest.SpanStub
+	}{
+		{"query", "request", query, request},
+		{"publish", "request", publish, request},
+		{"process", "publish", process, publish},
+	} {
+		if tt.got.Parent.SpanID() != tt.want.SpanContext.SpanID() {
+			t.Errorf("the %s span has parent %s, want the %s span %s", tt.child, tt.got.Parent.SpanID(), tt.parent, tt.want.SpanContext.SpanID())
+		}
+	}
+
+	want := fmt.Sprintf("00-%s-%s-01", publish.SpanContext.TraceID(), publish.SpanContext.SpanID())
+	if traceParent := msg.Headers["traceparent"]; traceParent != want {
+		t.Errorf("traceparent header = %v, want %s", traceParent, want)
+	}
+}
@@ -0,0 +1,56 @@
+package broker
+
+import (
+	"context"
+	"github.com/ronilsonalves/GoLang-in-a-spring-cloud-architecture/scheduling-service/internal/domain"
+	"sync"
+)
+
+// MemoryPublisher - keeps the events published and the dead letters replayed in memory, used for tests and local
+// development without a broker.
+type MemoryPublisher struct {
+	mu       sync.Mutex
+	events   []domain.Event
+	replayed []domain.DeadLetter
+}
+
+// NewMemoryPublisher - Initialize an empty MemoryPublisher.
+func NewMemoryPublisher() *MemoryPublisher {
+	return &MemoryPublisher{}
+}
+
+// PublishMessage - keep the event of an outbox message.
+func (p *MemoryPublisher) PublishMessage(ctx context.Context, message domain.OutboxMessage) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.events = append(p.events, message.Event)
+	return nil
+}
+
+// Replay - keep the dead letter replayed.
+func (p *MemoryPublisher) Replay(ctx context.Context, letter domain.DeadLetter) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.replayed = append(p.replayed, letter)
+	return nil
+}
+
+// Events - the events published so far, in the order they were published.
+func (p *MemoryPublisher) Events() []domain.Event {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return append([]domain.Event(nil), p.events...)
+}
+
+// Replayed - the dead letters replayed so far, in the order they were replayed.
+func (p *MemoryPublisher) Replayed() []domain.DeadLetter {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return append([]domain.DeadLetter(nil), p.replayed...)
+}
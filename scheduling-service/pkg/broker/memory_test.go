@@ -0,0 +1,73 @@
+package broker
+
+import (
+	"context"
+	"errors"
+	"github.com/ronilsonalves/GoLang-in-a-spring-cloud-architecture/scheduling-service/internal/domain"
+	"reflect"
+	"sync"
+	"testing"
+)
+
+func TestMemoryPublisherKeepsTheOrder(t *testing.T) {
+	p := NewMemoryPublisher()
+	ctx := context.Background()
+	var want []domain.Event
+	for _, eventType := range []domain.EventType{domain.EventAppointmentCreated, domain.EventAppointmentConfirmed, domain.EventAppointmentCompleted} {
+		event, err := domain.NewEvent(eventType, domain.Appointment{Id: 1})
+		if err != nil {
+			t.Fatalf("NewEvent() error = %v", err)
+		}
+		if err := p.PublishMessage(ctx, domain.OutboxMessage{Event: event}); err != nil {
+			t.Fatalf("PublishMessage() error = %v", err)
+		}
+		want = append(want, event)
+	}
+	if err := p.Replay(ctx, domain.DeadLetter{Id: 1, Queue: "scheduling-service.invoices"}); err != nil {
+		t.Fatalf("Replay() error = %v", err)
+	}
+
+	events := p.Events()
+	if !reflect.DeepEqual(events, want) {
+		t.Errorf("Events() = %v, want %v", events, want)
+	}
+	// the events returned are a copy
+	events[0].Type = domain.EventAppointmentDeleted
+	if p.Events()[0].Type != domain.EventAppointmentCreated {
+		t.Error("changing the events returned changed the ones kept")
+	}
+	if replayed := p.Replayed(); len(replayed) != 1 || replayed[0].Id != 1 {
+		t.Errorf("Replayed() = %v, want the dead letter 1", replayed)
+	}
+}
+
+func TestMemoryPublisherRefusesTheContextsDone(t *testing.T) {
+	p := NewMemoryPublisher()
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if err := p.PublishMessage(ctx, domain.OutboxMessage{}); !errors.Is(err, context.Canceled) {
+		t.Errorf("PublishMessage() error = %v, want context.Canceled", err)
+	}
+	if err := p.Replay(ctx, domain.DeadLetter{}); !errors.Is(err, context.Canceled) {
+		t.Errorf("Replay() error = %v, want context.Canceled", err)
+	}
+	if len(p.Events()) != 0 || len(p.Replayed()) != 0 {
+		t.Error("kept a message published with the context done")
+	}
+}
+
+func TestMemoryPublisherConcurrently(t *testing.T) {
+	p := NewMemoryPublisher()
+	var wg sync.WaitGroup
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			p.PublishMessage(context.Background(), domain.OutboxMessage{})
+		}()
+	}
+	wg.Wait()
+	if got := len(p.Events()); got != 50 {
+		t.Errorf("kept %d events, want 50", got)
+	}
+}
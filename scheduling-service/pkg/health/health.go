@@ -0,0 +1,134 @@
+package health
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// Status - the state of the service or of one of its dependencies, as Spring Boot Actuator reports it.
+type Status string
+
+const (
+	// StatusUp - working as expected.
+	StatusUp Status = "UP"
+	// StatusDown - failing or shutting down, the service shouldn't receive traffic.
+	StatusDown Status = "DOWN"
+)
+
+// Check - reach a dependency, returning why it's unavailable.
+type Check func(ctx context.Context) error
+
+// Checker - a dependency that tells whether it's reachable, as the publishers do.
+type Checker interface {
+	Check(ctx context.Context) error
+}
+
+// Component - the health of a dependency, the details tell the error when it's down.
+type Component struct {
+	Status  Status                 `json:"status" enums:"UP,DOWN"`
+	Details map[string]interface{} `json:"details,omitempty"`
+}
+
+// Health - the health of the service in the shape of the Spring Boot Actuator health endpoint, so the Eureka
+// dashboard and the gateway read it as they read the Java services one.
+type Health struct {
+	Status     Status               `json:"status" enums:"UP,DOWN"`
+	Components map[string]Component `json:"components,omitempty"`
+}
+
+// Indicator - checks the dependencies the service needs to handle requests. Readiness turns down once the service
+// starts shutting down, so the gateway stops sending it traffic while the requests in flight finish.
+type Indicator struct {
+	timeout      time.Duration
+	mu           sync.RWMutex
+	checks       map[string]Check
+	shuttingDown atomic.Bool
+}
+
+// NewIndicator - Initialize an Indicator with no dependencies, each check is given up to timeout to succeed.
+func NewIndicator(timeout time.Duration) *Indicator {
+	return &Indicator{
+		timeout: timeout,
+		checks:  map[string]Check{},
+	}
+}
+
+// Register - add the check of a dependency, the name is its component at the health reports, e.g. db.
+func (i *Indicator) Register(name string, check Check) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	i.checks[name] = check
+}
+
+// ShuttingDown - turn readiness down until the process exits.
+func (i *Indicator) ShuttingDown() {
+	i.shuttingDown.Store(true)
+}
+
+// Liveness - up while the process is able to answer, the dependencies aren't checked so an outage of one of them
+// doesn't get the service restarted.
+func (i *Indicator) Liveness() Health {
+	return Health{Status: StatusUp}
+}
+
+// Readiness - check every dependency at once, up only when all of them are up and the service isn't shutting down.
+func (i *Indicator) Readiness(ctx context.Context) Health {
+	i.mu.RLock()
+	names := make([]string, 0, len(i.checks))
+	for name := range i.checks {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	checks := make([]Check, len(names))
+	for n, name := range names {
+		checks[n] = i.checks[name]
+	}
+	i.mu.RUnlock()
+
+	ctx, cancel := context.WithTimeout(ctx, i.timeout)
+	defer cancel()
+	results := make([]Component, len(names))
+	var wg sync.WaitGroup
+	for n, check := range checks {
+		wg.Add(1)
+		go func(n int, check Check) {
+			defer wg.Done()
+			results[n] = run(ctx, check)
+		}(n, check)
+	}
+	wg.Wait()
+
+	health := Health{Status: StatusUp, Components: map[string]Component{}}
+	for n, name := range names {
+		health.Components[name] = results[n]
+		if results[n].Status != StatusUp {
+			health.Status = StatusDown
+		}
+	}
+	if i.shuttingDown.Load() {
+		health.Status = StatusDown
+		health.Components["shutdown"] = Component{Status: StatusDown, Details: map[string]interface{}{"reason": "the service is shutting down"}}
+	}
+	return health
+}
+
+// run - run a check until it returns or the context is done, whichever comes first.
+func run(ctx context.Context, check Check) Component {
+	done := make(chan error, 1)
+	go func() {
+		done <- check(ctx)
+	}()
+	var err error
+	select {
+	case err = <-done:
+	case <-ctx.Done():
+		err = ctx.Err()
+	}
+	if err != nil {
+		return Component{Status: StatusDown, Details: map[string]interface{}{"error": err.Error()}}
+	}
+	return Component{Status: StatusUp}
+}
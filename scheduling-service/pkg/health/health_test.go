@@ -0,0 +1,58 @@
+package health
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestReadiness(t *testing.T) {
+	up := func(ctx context.Context) error { return nil }
+	down := func(ctx context.Context) error { return errors.New("connection refused") }
+	// ignores the context, as a driver stuck at a dial does
+	hanging := func(ctx context.Context) error {
+		time.Sleep(200 * time.Millisecond)
+		return nil
+	}
+
+	tests := []struct {
+		name           string
+		checks         map[string]Check
+		shuttingDown   bool
+		wantStatus     Status
+		wantComponents map[string]Status
+	}{
+		{"without dependencies", nil, false, StatusUp, map[string]Status{}},
+		{"every dependency up", map[string]Check{"db": up, "rabbitmq": up}, false, StatusUp, map[string]Status{"db": StatusUp, "rabbitmq": StatusUp}},
+		{"a dependency down", map[string]Check{"db": up, "rabbitmq": down}, false, StatusDown, map[string]Status{"db": StatusUp, "rabbitmq": StatusDown}},
+		{"a dependency not answering in time", map[string]Check{"db": hanging, "oidc": up}, false, StatusDown, map[string]Status{"db": StatusDown, "oidc": StatusUp}},
+		{"shutting down", map[string]Check{"db": up}, true, StatusDown, map[string]Status{"db": StatusUp, "shutdown": StatusDown}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			indicator := NewIndicator(20 * time.Millisecond)
+			for name, check := range tt.checks {
+				indicator.Register(name, check)
+			}
+			if tt.shuttingDown {
+				indicator.ShuttingDown()
+			}
+			health := indicator.Readiness(context.Background())
+			if health.Status != tt.wantStatus {
+				t.Errorf("Status = %s, want %s", health.Status, tt.wantStatus)
+			}
+			if len(health.Components) != len(tt.wantComponents) {
+				t.Errorf("Components = %v, want %v", health.Components, tt.wantComponents)
+			}
+			for name, want := range tt.wantComponents {
+				if got := health.Components[name]; got.Status != want {
+					t.Errorf("%s = %+v, want %s", name, got, want)
+				}
+			}
+			if liveness := indicator.Liveness(); liveness.Status != StatusUp {
+				t.Errorf("Liveness() = %s, want it up whatever the dependencies", liveness.Status)
+			}
+		})
+	}
+}
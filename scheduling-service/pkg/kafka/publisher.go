@@ -0,0 +1,135 @@
+package kafka
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/ronilsonalves/GoLang-in-a-spring-cloud-architecture/scheduling-service/internal/domain"
+	"github.com/ronilsonalves/GoLang-in-a-spring-cloud-architecture/scheduling-service/pkg/metrics"
+	"github.com/ronilsonalves/GoLang-in-a-spring-cloud-architecture/scheduling-service/pkg/tracing"
+	kafkai "github.com/segmentio/kafka-go"
+	"go.opentelemetry.io/otel/propagation"
+	semconv "go.opentelemetry.io/otel/semconv/v1.12.0"
+	"go.opentelemetry.io/otel/trace"
+	"strconv"
+	"time"
+)
+
+// ErrReplayNotSupported - the dead letters are parked by the RabbitMQ consumers, so they can't be replayed through
+// Kafka.
+var ErrReplayNotSupported = errors.New("the dead letters can only be replayed through RabbitMQ")
+
+// PublisherConfig - the settings of a Publisher.
+type PublisherConfig struct {
+	// Brokers - the addresses the partitions of the topic are discovered from, e.g. localhost:9092
+	Brokers []string
+	// Topic - the topic the events are written to, it must exist unless the brokers create topics on demand.
+	Topic string
+	// MaxRetries - the attempts made after the first one failed.
+	MaxRetries int
+	// WriteTimeout - how long the brokers have to acknowledge a message.
+	WriteTimeout time.Duration
+}
+
+// Publisher - a long-lived Kafka publisher, every message is acknowledged by all the in-sync replicas before
+// PublishMessage returns. The events of an entity share a key, so they land at the same partition in the order
+// they were recorded.
+type Publisher struct {
+	brokers []string
+	writer  messageWriter
+}
+
+// messageWriter - writes the messages to the partitions of the topic, a *kafkai.Writer.
+type messageWriter interface {
+	WriteMessages(ctx context.Context, msgs ...kafkai.Message) error
+	Close() error
+}
+
+// NewPublisher - Initialize a Publisher, nothing is dialed until the first message so the API starts while Kafka is
+// down.
+func NewPublisher(config PublisherConfig) *Publisher {
+	return &Publisher{
+		brokers: config.Brokers,
+		writer: kafkai.NewWriter(kafkai.WriterConfig{
+			Brokers:      config.Brokers,
+			Topic:        config.Topic,
+			Balancer:     &kafkai.Hash{},
+			MaxAttempts:  config.MaxRetries + 1,
+			BatchSize:    1,
+			WriteTimeout: config.WriteTimeout,
+			RequiredAcks: -1,
+		}),
+	}
+}
+
+// PublishMessage - write an event of the outbox as its envelope in JSON, keyed by the entity it tells about. The
+// event type and ID go as headers so consumers filter and drop repeated ones without reading the value, along with the
+// trace context of ctx.
+func (p *Publisher) PublishMessage(ctx context.Context, message domain.OutboxMessage) error {
+	ctx, span := tracing.Tracer().Start(ctx, string(message.Event.Type)+" publish",
+		trace.WithSpanKind(trace.SpanKindProducer),
+		trace.WithAttributes(
+			semconv.MessagingSystemKey.String("kafka"),
+			semconv.MessagingDestinationKindTopic,
+			semconv.MessagingMessageIDKey.String(message.Event.ID),
+		))
+	msg, err := encodeEvent(ctx, message.Event)
+	if err == nil {
+		err = p.writer.WriteMessages(ctx, msg)
+	}
+	tracing.End(span, err)
+	metrics.MessagePublished("kafka", string(message.Event.Type), err)
+	if err != nil {
+		return fmt.Errorf("failed to publish to %s: %w", message.Event.Type, err)
+	}
+	return nil
+}
+
+// encodeEvent - the message of an event: its envelope in JSON, keyed by the entity it tells about, with the event
+// headers and the trace context of ctx.
+func encodeEvent(ctx context.Context, event domain.Event) (kafkai.Message, error) {
+	value, err := json.Marshal(event)
+	if err != nil {
+		return kafkai.Message{}, err
+	}
+	headers := []kafkai.Header{
+		{Key: "content-type", Value: []byte("application/json")},
+		{Key: "event-id", Value: []byte(event.ID)},
+		{Key: "event-type", Value: []byte(event.Type)},
+		{Key: "schema-version", Value: []byte(strconv.Itoa(event.SchemaVersion))},
+	}
+	carrier := propagation.MapCarrier{}
+	tracing.Inject(ctx, carrier)
+	for key, value := range carrier {
+		headers = append(headers, kafkai.Header{Key: key, Value: []byte(value)})
+	}
+	return kafkai.Message{
+		Key:     []byte(event.AggregateKey()),
+		Value:   value,
+		Time:    event.OccurredAt,
+		Headers: headers,
+	}, nil
+}
+
+// Replay - fail, the dead letters came from RabbitMQ queues.
+func (p *Publisher) Replay(ctx context.Context, letter domain.DeadLetter) error {
+	return ErrReplayNotSupported
+}
+
+// Check - dial the brokers until one of them answers, failing with the last error when none does.
+func (p *Publisher) Check(ctx context.Context) error {
+	err := errors.New("no Kafka broker configured")
+	for _, address := range p.brokers {
+		var conn *kafkai.Conn
+		if conn, err = kafkai.DialContext(ctx, "tcp", address); err == nil {
+			return conn.Close()
+		}
+	}
+	return err
+}
+
+// Close - flush and close the connections, PublishMessage fails afterwards.
+func (p *Publisher) Close() error {
+	return p.writer.Close()
+}
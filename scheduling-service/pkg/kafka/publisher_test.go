@@ -0,0 +1,101 @@
+package kafka
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"github.com/ronilsonalves/GoLang-in-a-spring-cloud-architecture/scheduling-service/internal/domain"
+	"github.com/ronilsonalves/GoLang-in-a-spring-cloud-architecture/scheduling-service/pkg/tracing"
+	kafkai "github.com/segmentio/kafka-go"
+	"reflect"
+	"testing"
+	"time"
+)
+
+const testTraceParent = "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01"
+
+// fakeWriter - keeps the messages written, or fails them all with err.
+type fakeWriter struct {
+	messages []kafkai.Message
+	err      error
+}
+
+func (w *fakeWriter) WriteMessages(ctx context.Context, msgs ...kafkai.Message) error {
+	if w.err != nil {
+		return w.err
+	}
+	w.messages = append(w.messages, msgs...)
+	return nil
+}
+
+func (w *fakeWriter) Close() error { return nil }
+
+func newTestEvent(t *testing.T) domain.Event {
+	t.Helper()
+	event, err := domain.NewEvent(domain.EventAppointmentConfirmed, domain.Appointment{Id: 12, DentistCRO: "CRO-1"})
+	if err != nil {
+		t.Fatalf("NewEvent() error = %v", err)
+	}
+	return event
+}
+
+func TestPublishMessage(t *testing.T) {
+	tracing.Setup("scheduling-service", nil, 1)
+	writer := &fakeWriter{}
+	p := &Publisher{writer: writer}
+	event := newTestEvent(t)
+	ctx := tracing.ContextWithTraceParent(context.Background(), testTraceParent)
+	if err := p.PublishMessage(ctx, domain.OutboxMessage{Event: event}); err != nil {
+		t.Fatalf("PublishMessage() error = %v", err)
+	}
+	if len(writer.messages) != 1 {
+		t.Fatalf("wrote %d messages, want 1", len(writer.messages))
+	}
+	msg := writer.messages[0]
+
+	if string(msg.Key) != "appointment:12" {
+		t.Errorf("key = %q, want the appointment the event tells about", msg.Key)
+	}
+	if !msg.Time.Equal(event.OccurredAt) {
+		t.Errorf("time = %v, want %v", msg.Time, event.OccurredAt)
+	}
+	var decoded domain.Event
+	if err := json.Unmarshal(msg.Value, &decoded); err != nil {
+		t.Fatalf("json.Unmarshal() error = %v", err)
+	}
+	if decoded.ID != event.ID || decoded.Type != event.Type || !decoded.OccurredAt.Equal(event.OccurredAt) || string(decoded.Payload) != string(event.Payload) {
+		t.Errorf("value = %+v, want the envelope of %+v", decoded, event)
+	}
+
+	headers := map[string]string{}
+	for _, header := range msg.Headers {
+		headers[header.Key] = string(header.Value)
+	}
+	want := map[string]string{
+		"content-type":   "application/json",
+		"event-id":       event.ID,
+		"event-type":     "appointment.confirmed",
+		"schema-version": "1",
+		"traceparent":    testTraceParent,
+	}
+	if !reflect.DeepEqual(headers, want) {
+		t.Errorf("headers = %v, want %v", headers, want)
+	}
+}
+
+func TestPublishMessageFails(t *testing.T) {
+	errUnavailable := errors.New("leader not available")
+	p := &Publisher{writer: &fakeWriter{err: errUnavailable}}
+	err := p.PublishMessage(context.Background(), domain.OutboxMessage{Event: newTestEvent(t)})
+	if !errors.Is(err, errUnavailable) {
+		t.Errorf("PublishMessage() error = %v, want %v", err, errUnavailable)
+	}
+}
+
+func TestReplayIsNotSupported(t *testing.T) {
+	p := NewPublisher(PublisherConfig{Brokers: []string{"localhost:9092"}, Topic: "scheduling.events", WriteTimeout: time.Second})
+	defer p.Close()
+	if err := p.Replay(context.Background(), domain.DeadLetter{Id: 1}); !errors.Is(err, ErrReplayNotSupported) {
+		t.Errorf("Replay() error = %v, want ErrReplayNotSupported", err)
+	}
+}
@@ -0,0 +1,76 @@
+package logging
+
+import (
+	"context"
+	"fmt"
+	"github.com/go-kit/log"
+	"github.com/go-kit/log/level"
+	"io"
+	stdlog "log"
+	"strings"
+)
+
+// Output formats of the log lines.
+const (
+	FormatJSON   = "json"
+	FormatLogfmt = "logfmt"
+)
+
+// New - Initialize the logger of the service writing a line per call to w in the format provided, with its time, level
+// and the service name. The lines below the level provided (debug, info, warn or error) are dropped and the secrets
+// and personal data are redacted from every line, see Redact.
+func New(w io.Writer, format, minLevel, service string) (log.Logger, error) {
+	var logger log.Logger
+	switch format {
+	case FormatJSON:
+		logger = log.NewJSONLogger(log.NewSyncWriter(w))
+	case FormatLogfmt:
+		logger = log.NewLogfmtLogger(log.NewSyncWriter(w))
+	default:
+		return nil, fmt.Errorf("unknown log format %q, it must be %s or %s", format, FormatJSON, FormatLogfmt)
+	}
+	allow, err := levelOption(minLevel)
+	if err != nil {
+		return nil, err
+	}
+	logger = level.NewFilter(redactor{next: logger}, allow)
+	return log.With(logger, "ts", log.DefaultTimestampUTC, "service", service), nil
+}
+
+// RedirectStandardLog - write the lines of the standard log package through the logger at the info level, so the
+// libraries logging with it produce structured lines as well.
+func RedirectStandardLog(logger log.Logger) {
+	stdlog.SetFlags(0)
+	stdlog.SetOutput(log.NewStdlibAdapter(level.NewInjector(logger, level.InfoValue())))
+}
+
+func levelOption(minLevel string) (level.Option, error) {
+	switch strings.ToLower(minLevel) {
+	case "debug":
+		return level.AllowDebug(), nil
+	case "info", "":
+		return level.AllowInfo(), nil
+	case "warn":
+		return level.AllowWarn(), nil
+	case "error":
+		return level.AllowError(), nil
+	default:
+		return nil, fmt.Errorf("unknown log level %q, it must be debug, info, warn or error", minLevel)
+	}
+}
+
+type contextKey struct{}
+
+// NewContext - keep the logger of a request or a message at its context, the layers handling it log through
+// FromContext so their lines carry its request or message ID.
+func NewContext(ctx context.Context, logger log.Logger) context.Context {
+	return context.WithValue(ctx, contextKey{}, logger)
+}
+
+// FromContext - the logger kept at ctx, one discarding every line when there's none.
+func FromContext(ctx context.Context) log.Logger {
+	if logger, ok := ctx.Value(contextKey{}).(log.Logger); ok {
+		return logger
+	}
+	return log.NewNopLogger()
+}
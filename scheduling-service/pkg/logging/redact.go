@@ -0,0 +1,92 @@
+package logging
+
+import (
+	"fmt"
+	"github.com/go-kit/log"
+	"net/url"
+	"regexp"
+	"strings"
+)
+
+// Redacted - replaces the secrets and the personal data at the log lines.
+const Redacted = "[REDACTED]"
+
+// sensitiveKeys - the log keys, query parameters and route parameters whose values are never logged: credentials and
+// the patients' identity numbers (RG). Compared in lower case.
+var sensitiveKeys = map[string]bool{
+	"authorization":   true,
+	"access_token":    true,
+	"refresh_token":   true,
+	"id_token":        true,
+	"token":           true,
+	"password":        true,
+	"secret":          true,
+	"client_secret":   true,
+	"rg":              true,
+	"patientrg":       true,
+	"patient_rg":      true,
+	"identity_number": true,
+}
+
+var (
+	// bearerPattern - a bearer token, e.g. at an Authorization header written into an error.
+	bearerPattern = regexp.MustCompile(`(?i)bearer\s+[A-Za-z0-9\-_.~+/]+=*`)
+	// jwtPattern - a JWT anywhere at a text, its header always starts with eyJ once encoded.
+	jwtPattern = regexp.MustCompile(`eyJ[A-Za-z0-9\-_]+\.[A-Za-z0-9\-_]+\.[A-Za-z0-9\-_]*`)
+	// rgPattern - an RG written with its punctuation, e.g. 12.345.678-9, or without it, e.g. 123456789.
+	rgPattern = regexp.MustCompile(`\b(?:\d{1,2}\.\d{3}\.\d{3}-[\dXx]|\d{8}[\dXx])\b`)
+)
+
+// IsSensitive - whether the values of the key provided are never logged.
+func IsSensitive(key string) bool {
+	return sensitiveKeys[strings.ToLower(key)]
+}
+
+// Redact - replace the bearer tokens, JWTs and RGs found at a text, with or without their punctuation.
+func Redact(text string) string {
+	text = bearerPattern.ReplaceAllString(text, "Bearer "+Redacted)
+	text = jwtPattern.ReplaceAllString(text, Redacted)
+	return rgPattern.ReplaceAllString(text, Redacted)
+}
+
+// RedactQuery - the query string provided with the values of its sensitive parameters replaced, in the same order.
+func RedactQuery(rawQuery string) string {
+	if rawQuery == "" {
+		return ""
+	}
+	params := strings.Split(rawQuery, "&")
+	for i, param := range params {
+		key, _, _ := strings.Cut(param, "=")
+		if unescaped, err := url.QueryUnescape(key); err != nil || IsSensitive(unescaped) {
+			params[i] = key + "=" + Redacted
+		}
+	}
+	return strings.Join(params, "&")
+}
+
+// redactor - redacts every line before it's written: the values of the sensitive keys are dropped and the texts are
+// scanned by Redact.
+type redactor struct {
+	next log.Logger
+}
+
+// Log - write the line Redacted.
+func (r redactor) Log(keyvals ...interface{}) error {
+	clean := make([]interface{}, len(keyvals))
+	copy(clean, keyvals)
+	for i := 1; i < len(clean); i += 2 {
+		if key, ok := clean[i-1].(string); ok && IsSensitive(key) {
+			clean[i] = Redacted
+			continue
+		}
+		switch value := clean[i].(type) {
+		case string:
+			clean[i] = Redact(value)
+		case error:
+			clean[i] = Redact(value.Error())
+		case fmt.Stringer:
+			clean[i] = Redact(value.String())
+		}
+	}
+	return r.next.Log(clean...)
+}
@@ -0,0 +1,122 @@
+package metrics
+
+import (
+	"database/sql"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// application - the application label of every metric of the service, as the Spring services tag theirs, so the
+// same dashboards select it.
+const application = "scheduling-service"
+
+// registerer - registers the metrics of the service at the default registry, along with the Go runtime and process
+// ones it already has.
+var registerer = prometheus.WrapRegistererWith(prometheus.Labels{"application": application}, prometheus.DefaultRegisterer)
+
+var (
+	// httpRequests - named as Spring Boot names the latency of the requests, so the dashboards of the Spring services
+	// read it as well.
+	httpRequests = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "http_server_requests_seconds",
+		Help:    "Duration of the HTTP requests by route and status.",
+		Buckets: []float64{.005, .01, .025, .05, .1, .25, .5, 1, 2.5, 5, 10, 30},
+	}, []string{"method", "uri", "status", "outcome"})
+	messagesPublished = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "scheduling_messages_published_total",
+		Help: "Messages sent to the broker, by routing key and whether the broker acknowledged them.",
+	}, []string{"broker", "routing_key", "outcome"})
+	authFailures = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "scheduling_auth_failures_total",
+		Help: "Requests refused by the authentication or the authorization, by reason.",
+	}, []string{"reason"})
+	appointmentsCreated = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "scheduling_appointments_created_total",
+		Help: "Appointments scheduled, by dentist.",
+	}, []string{"dentist"})
+	appointmentsCancelled = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "scheduling_appointments_cancelled_total",
+		Help: "Appointments cancelled, by dentist.",
+	}, []string{"dentist"})
+	appointmentsNoShow = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "scheduling_appointments_no_show_total",
+		Help: "Appointments the patient didn't show up to, by dentist.",
+	}, []string{"dentist"})
+)
+
+func init() {
+	registerer.MustRegister(httpRequests, messagesPublished, authFailures,
+		appointmentsCreated, appointmentsCancelled, appointmentsNoShow)
+}
+
+// Reasons an authentication or authorization failed.
+const (
+	AuthMissingToken = "missing_token"
+	AuthInvalidToken = "invalid_token"
+	AuthForbidden    = "forbidden"
+)
+
+// Handler - serve every metric in the Prometheus text format.
+func Handler() http.Handler {
+	return promhttp.Handler()
+}
+
+// RegisterDB - expose the connection pool stats of a database, e.g. the connections in use and the waits for one.
+func RegisterDB(db *sql.DB, name string) {
+	registerer.MustRegister(collectors.NewDBStatsCollector(db, name))
+}
+
+// ObserveRequest - record the duration of a request to the route provided, the uri is the route template so the
+// requests to every appointment share one series.
+func ObserveRequest(method, uri string, status int, duration time.Duration) {
+	httpRequests.WithLabelValues(method, uri, strconv.Itoa(status), outcome(status)).Observe(duration.Seconds())
+}
+
+// MessagePublished - count a message sent to the broker, err tells whether it failed.
+func MessagePublished(broker, routingKey string, err error) {
+	result := "success"
+	if err != nil {
+		result = "failure"
+	}
+	messagesPublished.WithLabelValues(broker, routingKey, result).Inc()
+}
+
+// AuthFailed - count a request refused for the reason provided.
+func AuthFailed(reason string) {
+	authFailures.WithLabelValues(reason).Inc()
+}
+
+// AppointmentCreated - count an appointment scheduled with the dentist provided.
+func AppointmentCreated(dentistCRO string) {
+	appointmentsCreated.WithLabelValues(dentistCRO).Inc()
+}
+
+// AppointmentCancelled - count an appointment of the dentist provided being cancelled.
+func AppointmentCancelled(dentistCRO string) {
+	appointmentsCancelled.WithLabelValues(dentistCRO).Inc()
+}
+
+// AppointmentNoShow - count a patient not showing up to an appointment with the dentist provided.
+func AppointmentNoShow(dentistCRO string) {
+	appointmentsNoShow.WithLabelValues(dentistCRO).Inc()
+}
+
+// outcome - the class of a status, as Spring Boot tags its requests.
+func outcome(status int) string {
+	switch {
+	case status < 200:
+		return "INFORMATIONAL"
+	case status < 300:
+		return "SUCCESS"
+	case status < 400:
+		return "REDIRECTION"
+	case status < 500:
+		return "CLIENT_ERROR"
+	default:
+		return "SERVER_ERROR"
+	}
+}
@@ -4,12 +4,10 @@ import (
 	"github.com/gin-gonic/gin"
 	"github.com/ronilsonalves/GoLang-in-a-spring-cloud-architecture/scheduling-service/pkg/web"
 	"net/http"
-	"os"
 )
 
-func Authentication() gin.HandlerFunc {
-	requiredToken := os.Getenv("TOKEN")
-
+// Authentication - require the static token provided at the SECRET_TOKEN header.
+func Authentication(requiredToken string) gin.HandlerFunc {
 	return func(ctx *gin.Context) {
 		tokenReceived := ctx.GetHeader("SECRET_TOKEN")
 
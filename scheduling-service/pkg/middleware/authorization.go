@@ -0,0 +1,70 @@
+package middleware
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"github.com/ronilsonalves/GoLang-in-a-spring-cloud-architecture/scheduling-service/pkg/metrics"
+	"github.com/ronilsonalves/GoLang-in-a-spring-cloud-architecture/scheduling-service/pkg/web"
+	"net/http"
+	"os"
+	"sort"
+	"strings"
+)
+
+// Policies - the roles allowed to each permission, read from a file so the access rules change without a new build.
+type Policies struct {
+	roles      map[string][]string
+	undeclared map[string]bool
+}
+
+// LoadPolicies - read the policies from a JSON file mapping each permission to the roles allowed, e.g.
+// {"appointments:read": ["ADMIN", "RECEPTIONIST"]}
+func LoadPolicies(path string) (*Policies, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	policies := &Policies{undeclared: map[string]bool{}}
+	if err := json.Unmarshal(content, &policies.roles); err != nil {
+		return nil, err
+	}
+	return policies, nil
+}
+
+// Require - allow the route only to users having a role allowed to the permission. A permission not declared at the
+// policies denies every request and is reported by Validate, so a typo at the routes stops the service at startup
+// instead of locking users out.
+func (p *Policies) Require(permission string) gin.HandlerFunc {
+	allowed, ok := p.roles[permission]
+	if !ok {
+		p.undeclared[permission] = true
+	}
+
+	return func(c *gin.Context) {
+		for _, userRole := range c.GetStringSlice(rolesKey) {
+			for _, role := range allowed {
+				if userRole == role {
+					c.Next()
+					return
+				}
+			}
+		}
+		metrics.AuthFailed(metrics.AuthForbidden)
+		web.BadResponse(c, http.StatusForbidden, "ERROR", "The user has no permission to access this resource")
+	}
+}
+
+// Validate - return an error naming the permissions required by the routes but not declared at the policies, called
+// once the routes are registered.
+func (p *Policies) Validate() error {
+	if len(p.undeclared) == 0 {
+		return nil
+	}
+	var permissions []string
+	for permission := range p.undeclared {
+		permissions = append(permissions, permission)
+	}
+	sort.Strings(permissions)
+	return fmt.Errorf("permissions %s aren't declared at the authorization policies", strings.Join(permissions, ", "))
+}
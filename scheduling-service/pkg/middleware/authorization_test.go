@@ -0,0 +1,76 @@
+package middleware
+
+import (
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func loadTestPolicies(t *testing.T, content string) *Policies {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "policies.json")
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatalf("os.WriteFile() error = %v", err)
+	}
+	policies, err := LoadPolicies(path)
+	if err != nil {
+		t.Fatalf("LoadPolicies() error = %v", err)
+	}
+	return policies
+}
+
+func TestPoliciesRequire(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	policies := loadTestPolicies(t, `{"appointments:read": ["ADMIN", "PATIENT"]}`)
+
+	tests := []struct {
+		name       string
+		roles      []string
+		wantStatus int
+	}{
+		{"role allowed", []string{"offline_access", "PATIENT"}, http.StatusOK},
+		{"role not allowed", []string{"DENTIST"}, http.StatusForbidden},
+		{"without roles", nil, http.StatusForbidden},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			router := gin.New()
+			router.GET("/appointments", func(c *gin.Context) { c.Set(rolesKey, tt.roles) },
+				policies.Require("appointments:read"), func(c *gin.Context) { c.Status(http.StatusOK) })
+			rec := httptest.NewRecorder()
+			router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/appointments", nil))
+			if rec.Code != tt.wantStatus {
+				t.Errorf("status = %d, want %d", rec.Code, tt.wantStatus)
+			}
+		})
+	}
+	if err := policies.Validate(); err != nil {
+		t.Errorf("Validate() error = %v", err)
+	}
+}
+
+func TestPoliciesValidateReportsUndeclaredPermissions(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	policies := loadTestPolicies(t, `{"appointments:read": ["ADMIN"]}`)
+
+	router := gin.New()
+	router.GET("/appointments", policies.Require("appointments:read"))
+	router.POST("/appointments", policies.Require("appointment:write"))
+	router.DELETE("/appointments", policies.Require("appointment:write"))
+	router.GET("/patients", policies.Require("patient:read"))
+
+	err := policies.Validate()
+	if err == nil || !strings.Contains(err.Error(), "appointment:write, patient:read ") {
+		t.Fatalf("Validate() error = %v, want the undeclared permissions listed once", err)
+	}
+
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/appointments", nil))
+	if rec.Code != http.StatusForbidden {
+		t.Errorf("status = %d, want undeclared permissions to deny every request", rec.Code)
+	}
+}
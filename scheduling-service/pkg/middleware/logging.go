@@ -0,0 +1,114 @@
+package middleware
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"github.com/gin-gonic/gin"
+	"github.com/go-kit/log"
+	"github.com/go-kit/log/level"
+	"github.com/ronilsonalves/GoLang-in-a-spring-cloud-architecture/scheduling-service/pkg/logging"
+	"github.com/ronilsonalves/GoLang-in-a-spring-cloud-architecture/scheduling-service/pkg/web"
+	"go.opentelemetry.io/otel/trace"
+	"net/http"
+	"regexp"
+	"runtime/debug"
+	"strings"
+	"time"
+)
+
+// RequestIDHeader - the header carrying the ID of a request, kept when the gateway already sent one and returned at
+// every response so a client reporting a failure can tell which request it was.
+const RequestIDHeader = "X-Request-ID"
+
+// validRequestID - the request IDs accepted from the callers, others are replaced so they can't forge log lines.
+var validRequestID = regexp.MustCompile(`^[A-Za-z0-9\-_.:]{1,64}$`)
+
+// RequestID - identify every request and keep a logger tagged with its ID, and its trace ID when it's traced, at the
+// request context, so every line logged while handling it can be told apart. Must run after Tracing.
+func RequestID(logger log.Logger) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		requestID := c.GetHeader(RequestIDHeader)
+		if !validRequestID.MatchString(requestID) {
+			requestID = newRequestID()
+		}
+		c.Header(RequestIDHeader, requestID)
+
+		requestLogger := log.With(logger, "request_id", requestID)
+		if span := trace.SpanContextFromContext(c.Request.Context()); span.IsValid() {
+			requestLogger = log.With(requestLogger, "trace_id", span.TraceID().String())
+		}
+		c.Request = c.Request.WithContext(logging.NewContext(c.Request.Context(), requestLogger))
+		c.Next()
+	}
+}
+
+// AccessLog - log every request once it's handled, with its route, status and duration. The sensitive route and query
+// parameters, e.g. the patients' RG, are redacted from the path logged.
+func AccessLog() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		start := time.Now()
+		c.Next()
+
+		status := c.Writer.Status()
+		logger := level.Info(logging.FromContext(c.Request.Context()))
+		if status >= http.StatusInternalServerError {
+			logger = level.Error(logging.FromContext(c.Request.Context()))
+		}
+		keyvals := []interface{}{
+			"msg", "request handled",
+			"method", c.Request.Method,
+			"route", c.FullPath(),
+			"path", redactedPath(c),
+			"status", status,
+			"duration_ms", time.Since(start).Milliseconds(),
+			"client_ip", c.ClientIP(),
+		}
+		if len(c.Errors) > 0 {
+			keyvals = append(keyvals, "err", strings.Join(c.Errors.Errors(), "; "))
+		}
+		logger.Log(keyvals...)
+	}
+}
+
+// Recovery - answer 500 to a request whose handling panicked, logging the panic and its stack, so a bug at a handler
+// fails that request only.
+func Recovery() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		defer func() {
+			if recovered := recover(); recovered != nil {
+				level.Error(logging.FromContext(c.Request.Context())).Log(
+					"msg", "request handling panicked",
+					"panic", recovered,
+					"stack", string(debug.Stack()))
+				if c.Writer.Written() {
+					c.Abort()
+					return
+				}
+				web.BadResponse(c, http.StatusInternalServerError, "ERROR", "an unexpected error occurred")
+			}
+		}()
+		c.Next()
+	}
+}
+
+// redactedPath - the path of the request with its sensitive route and query parameters replaced.
+func redactedPath(c *gin.Context) string {
+	path := c.Request.URL.Path
+	for _, param := range c.Params {
+		if logging.IsSensitive(param.Key) && param.Value != "" {
+			path = strings.Replace(path, "/"+param.Value, "/"+logging.Redacted, 1)
+		}
+	}
+	if query := logging.RedactQuery(c.Request.URL.RawQuery); query != "" {
+		path += "?" + query
+	}
+	return path
+}
+
+func newRequestID() string {
+	var b [16]byte
+	if _, err := rand.Read(b[:]); err != nil {
+		return "unknown"
+	}
+	return hex.EncodeToString(b[:])
+}
@@ -0,0 +1,81 @@
+package middleware
+
+import (
+	"bytes"
+	"github.com/gin-gonic/gin"
+	"github.com/go-kit/log"
+	"github.com/ronilsonalves/GoLang-in-a-spring-cloud-architecture/scheduling-service/pkg/logging"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestRedactedPath(t *testing.T) {
+	tests := []struct {
+		name   string
+		route  string
+		target string
+		want   string
+	}{
+		{"nothing sensitive", "/appointments/:id", "/appointments/12?page=1", "/appointments/12?page=1"},
+		{"rg at the route", "/appointments/patient/:identity_number", "/appointments/patient/123456789", "/appointments/patient/[REDACTED]"},
+		{"rg at the route and the query", "/appointments/patient/:identity_number", "/appointments/patient/RG-1?rg=RG-1&page=2", "/appointments/patient/[REDACTED]?rg=[REDACTED]&page=2"},
+		{"rg at the query", "/appointments", "/appointments?patientRG=12.345.678-9", "/appointments?patientRG=[REDACTED]"},
+		{"other params kept", "/patients/:rg/appointments/:id", "/patients/RG-1/appointments/7", "/patients/[REDACTED]/appointments/7"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gin.SetMode(gin.TestMode)
+			router := gin.New()
+			var got string
+			router.GET(tt.route, func(c *gin.Context) { got = redactedPath(c) })
+			router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, tt.target, nil))
+			if got != tt.want {
+				t.Errorf("redactedPath() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRequestID(t *testing.T) {
+	tests := []struct {
+		name     string
+		sent     string
+		wantSame bool
+	}{
+		{"sent by the gateway", "6f1c2b7e-0d3a-4a57-9b1e-52f0d9c8a4e1", true},
+		{"not sent", "", false},
+		{"forged", "abc\nlevel=error msg=forged", false},
+		{"too long", strings.Repeat("a", 65), false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gin.SetMode(gin.TestMode)
+			var out bytes.Buffer
+			router := gin.New()
+			router.Use(RequestID(log.NewLogfmtLogger(&out)))
+			router.GET("/appointments/:id", func(c *gin.Context) {
+				logging.FromContext(c.Request.Context()).Log("msg", "handling")
+				c.Status(http.StatusOK)
+			})
+			req := httptest.NewRequest(http.MethodGet, "/appointments/1", nil)
+			if tt.sent != "" {
+				req.Header.Set(RequestIDHeader, tt.sent)
+			}
+			rec := httptest.NewRecorder()
+			router.ServeHTTP(rec, req)
+
+			got := rec.Header().Get(RequestIDHeader)
+			if tt.wantSame && got != tt.sent {
+				t.Errorf("%s = %q, want the one sent %q", RequestIDHeader, got, tt.sent)
+			}
+			if !tt.wantSame && (got == tt.sent || !validRequestID.MatchString(got)) {
+				t.Errorf("%s = %q, want a new one", RequestIDHeader, got)
+			}
+			if want := "request_id=" + got; !strings.Contains(out.String(), want) {
+				t.Errorf("the line %q doesn't have %s", out.String(), want)
+			}
+		})
+	}
+}
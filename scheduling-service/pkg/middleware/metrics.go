@@ -0,0 +1,26 @@
+package middleware
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/ronilsonalves/GoLang-in-a-spring-cloud-architecture/scheduling-service/pkg/metrics"
+	"net/http"
+	"time"
+)
+
+// Metrics - record the duration of every request by its route template, the requests matching no route are recorded
+// as NOT_FOUND so scanners don't create a series per path.
+func Metrics() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		start := time.Now()
+		c.Next()
+
+		uri := c.FullPath()
+		if uri == "" {
+			uri = "UNKNOWN"
+			if c.Writer.Status() == http.StatusNotFound {
+				uri = "NOT_FOUND"
+			}
+		}
+		metrics.ObserveRequest(c.Request.Method, uri, c.Writer.Status(), time.Since(start))
+	}
+}
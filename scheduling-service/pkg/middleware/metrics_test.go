@@ -0,0 +1,42 @@
+package middleware
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/ronilsonalves/GoLang-in-a-spring-cloud-architecture/scheduling-service/pkg/metrics"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestMetricsLabelsTheRouteTemplate(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(Metrics())
+	router.GET("/api/v1/appointments/:id", func(c *gin.Context) { c.Status(http.StatusOK) })
+	router.GET("/metrics", gin.WrapH(metrics.Handler()))
+
+	for _, target := range []string{"/api/v1/appointments/12", "/api/v1/appointments/13", "/wp-login.php"} {
+		router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, target, nil))
+	}
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d, want %d", rec.Code, http.StatusOK)
+	}
+	body := rec.Body.String()
+
+	for _, want := range []string{
+		`http_server_requests_seconds_count{application="scheduling-service",method="GET",outcome="SUCCESS",status="200",uri="/api/v1/appointments/:id"} 2`,
+		`http_server_requests_seconds_count{application="scheduling-service",method="GET",outcome="CLIENT_ERROR",status="404",uri="NOT_FOUND"} 1`,
+	} {
+		if !strings.Contains(body, want) {
+			t.Errorf("the metrics don't have %s", want)
+		}
+	}
+	for _, path := range []string{"/api/v1/appointments/12", "/wp-login.php"} {
+		if strings.Contains(body, `uri="`+path+`"`) {
+			t.Errorf("the metrics have a series of the path %s", path)
+		}
+	}
+}
@@ -3,9 +3,13 @@ package middleware
 import (
 	"context"
 	"crypto/tls"
+	"crypto/x509"
+	"errors"
 	"fmt"
 	"github.com/coreos/go-oidc/v3/oidc"
 	"github.com/gin-gonic/gin"
+	"github.com/ronilsonalves/GoLang-in-a-spring-cloud-architecture/scheduling-service/internal/domain"
+	"github.com/ronilsonalves/GoLang-in-a-spring-cloud-architecture/scheduling-service/pkg/metrics"
 	"github.com/ronilsonalves/GoLang-in-a-spring-cloud-architecture/scheduling-service/pkg/web"
 	"net/http"
 	"os"
@@ -14,67 +18,143 @@ import (
 )
 
 type Claims struct {
-	RealmAccess roles  `json:"realm_access,omitempty"`
-	JTI         string `json:"jti,omitempty"`
+	RealmAccess    roles            `json:"realm_access,omitempty"`
+	ResourceAccess map[string]roles `json:"resource_access,omitempty"`
+	JTI            string           `json:"jti,omitempty"`
+	// RG and CRO - identify the patient or dentist the user is, scoping the appointments they can access.
+	RG  string `json:"rg,omitempty"`
+	CRO string `json:"cro,omitempty"`
 }
 
 type roles struct {
 	Roles []string `json:"roles,omitempty"`
 }
 
-var RealmConfigURL = os.Getenv("REALM_CONFIG_URL")
-var clientID = os.Getenv("CLIENT_ID")
-var authorizedRole = "ADMIN"
+// Roles - the realm roles of the user along with the roles of the client provided.
+func (c Claims) Roles(clientID string) []string {
+	userRoles := append([]string{}, c.RealmAccess.Roles...)
+	return append(userRoles, c.ResourceAccess[clientID].Roles...)
+}
 
-func IsAuthorizedJWT() gin.HandlerFunc {
-	return func(c *gin.Context) {
-		rawAccessToken := strings.Replace(c.GetHeader("Authorization"), "Bearer", "", 1)
-		fmt.Println(rawAccessToken)
-		trans := &http.Transport{
-			TLSClientConfig: &tls.Config{
-				InsecureSkipVerify: true,
-			},
-		}
-		client := &http.Client{
-			Timeout:   time.Duration(6000) * time.Second,
-			Transport: trans,
-		}
+// rolesKey - the gin context key holding the roles of the authenticated user.
+const rolesKey = "roles"
+
+// issuerTimeout - bounds every call to the issuer, the keys are refreshed outside of any request deadline.
+const issuerTimeout = 10 * time.Second
+
+// JWTConfig - how the access tokens are verified.
+type JWTConfig struct {
+	// IssuerURL - the realm issuing the tokens, it must match their iss claim.
+	IssuerURL string
+	// Audience - the audience the tokens must have.
+	Audience string
+	// ClientID - the client whose roles are granted to the user besides the realm roles.
+	ClientID string
+	// CAFile - a PEM bundle trusted besides the system roots when calling the issuer, optional.
+	CAFile string
+}
+
+// JWTVerifier - verify access tokens signed by the issuer configured. The discovery document is read once, at startup,
+// and the signing keys are cached, being fetched again only when a token is signed by an unknown key, so keys rotation
+// is followed and the issuer isn't called on every request.
+type JWTVerifier struct {
+	config   JWTConfig
+	client   *http.Client
+	verifier *oidc.IDTokenVerifier
+}
 
-		ctx := oidc.ClientContext(context.Background(), client)
-		provider, err := oidc.NewProvider(ctx, RealmConfigURL)
+// NewJWTVerifier - Initialize a JWTVerifier for the configuration provided reading the discovery document of the
+// issuer, failing when the CA bundle is invalid or the issuer can't be discovered.
+func NewJWTVerifier(ctx context.Context, config JWTConfig) (*JWTVerifier, error) {
+	rootCAs, err := x509.SystemCertPool()
+	if err != nil {
+		rootCAs = x509.NewCertPool()
+	}
+	if config.CAFile != "" {
+		bundle, err := os.ReadFile(config.CAFile)
 		if err != nil {
-			authorizationFailed("an authorization error occurred while getting the provider: "+err.Error(), c)
-			return
+			return nil, err
 		}
-
-		oidcConfig := &oidc.Config{
-			ClientID: clientID,
+		if !rootCAs.AppendCertsFromPEM(bundle) {
+			return nil, errors.New("no certificate found at the CA bundle " + config.CAFile)
 		}
+	}
+	client := &http.Client{
+		Timeout: issuerTimeout,
+		Transport: &http.Transport{
+			TLSClientConfig: &tls.Config{RootCAs: rootCAs},
+		},
+	}
+	// the key set keeps the client of this context to fetch the rotated keys, not its deadline
+	provider, err := oidc.NewProvider(oidc.ClientContext(ctx, client), config.IssuerURL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to discover the issuer %s: %w", config.IssuerURL, err)
+	}
+	return &JWTVerifier{
+		config:   config,
+		client:   client,
+		verifier: provider.Verifier(&oidc.Config{ClientID: config.Audience}),
+	}, nil
+}
 
-		verifier := provider.Verifier(oidcConfig)
-		idToken, err := verifier.Verify(ctx, rawAccessToken)
-		if err != nil {
-			authorizationFailed("an authorization error occurred while verifying the token: "+err.Error(), c)
+// Verify - check the signature, expiration, issuer and audience of an access token and return its claims.
+func (v *JWTVerifier) Verify(ctx context.Context, rawAccessToken string) (Claims, error) {
+	token, err := v.verifier.Verify(ctx, rawAccessToken)
+	if err != nil {
+		return Claims{}, err
+	}
+	var claims Claims
+	if err := token.Claims(&claims); err != nil {
+		return Claims{}, err
+	}
+	return claims, nil
+}
+
+// Check - read the discovery document of the issuer, failing when it can't be reached. The document read at startup
+// is kept, this only tells whether rotated keys can still be fetched.
+func (v *JWTVerifier) Check(ctx context.Context) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, strings.TrimSuffix(v.config.IssuerURL, "/")+"/.well-known/openid-configuration", nil)
+	if err != nil {
+		return err
+	}
+	resp, err := v.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("the issuer answered %s", resp.Status)
+	}
+	return nil
+}
+
+// IsAuthorizedJWT - authenticate the user by the access token, keeping the user roles for the route policies and the
+// principal at the request context.
+func IsAuthorizedJWT(verifier *JWTVerifier) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		rawAccessToken := strings.TrimSpace(strings.TrimPrefix(c.GetHeader("Authorization"), "Bearer"))
+		if rawAccessToken == "" {
+			metrics.AuthFailed(metrics.AuthMissingToken)
+			authorizationFailed("an access token is required", c)
 			return
 		}
 
-		var IDTokenClaims Claims
-		if err := idToken.Claims(&IDTokenClaims); err != nil {
-			authorizationFailed("An error occurred while extracting claims: "+err.Error(), c)
+		claims, err := verifier.Verify(c.Request.Context(), rawAccessToken)
+		if err != nil {
+			metrics.AuthFailed(metrics.AuthInvalidToken)
+			authorizationFailed("an authorization error occurred while verifying the token: "+err.Error(), c)
 			return
 		}
 
-		userAccessRoles := IDTokenClaims.RealmAccess.Roles
-		for _, userRole := range userAccessRoles {
-			if userRole == authorizedRole {
-				c.Next()
-				return
-			}
+		principal := domain.Principal{
+			Roles: claims.Roles(verifier.config.ClientID),
+			RG:    claims.RG,
+			CRO:   claims.CRO,
 		}
-
-		authorizationFailed("The user has no permission to access this API", c)
+		c.Set(rolesKey, principal.Roles)
+		c.Request = c.Request.WithContext(domain.ContextWithPrincipal(c.Request.Context(), principal))
+		c.Next()
 	}
-
 }
 
 func authorizationFailed(message string, c *gin.Context) {
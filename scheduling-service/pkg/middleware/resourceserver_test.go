@@ -0,0 +1,263 @@
+package middleware
+
+import (
+	"context"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"github.com/gin-gonic/gin"
+	"github.com/ronilsonalves/GoLang-in-a-spring-cloud-architecture/scheduling-service/internal/domain"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+)
+
+// stubIssuer - an OIDC issuer serving its discovery document and the JWKS of the keys it currently signs with.
+type stubIssuer struct {
+	*httptest.Server
+
+	mu         sync.Mutex
+	keys       map[string]*rsa.PrivateKey
+	jwksServed int
+}
+
+func newStubIssuer(t *testing.T) *stubIssuer {
+	t.Helper()
+	issuer := &stubIssuer{keys: map[string]*rsa.PrivateKey{}}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/.well-known/openid-configuration", func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"issuer":                                issuer.URL,
+			"jwks_uri":                              issuer.URL + "/protocol/openid-connect/certs",
+			"authorization_endpoint":                issuer.URL + "/protocol/openid-connect/auth",
+			"token_endpoint":                        issuer.URL + "/protocol/openid-connect/token",
+			"id_token_signing_alg_values_supported": []string{"RS256"},
+		})
+	})
+	mux.HandleFunc("/protocol/openid-connect/certs", func(w http.ResponseWriter, r *http.Request) {
+		issuer.mu.Lock()
+		defer issuer.mu.Unlock()
+		issuer.jwksServed++
+		var keys []map[string]string
+		for kid, key := range issuer.keys {
+			keys = append(keys, map[string]string{
+				"kty": "RSA",
+				"use": "sig",
+				"alg": "RS256",
+				"kid": kid,
+				"n":   base64.RawURLEncoding.EncodeToString(key.N.Bytes()),
+				"e":   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.E)).Bytes()),
+			})
+		}
+		json.NewEncoder(w).Encode(map[string]interface{}{"keys": keys})
+	})
+	issuer.Server = httptest.NewServer(mux)
+	t.Cleanup(issuer.Close)
+	return issuer
+}
+
+// rotate - publish only a new signing key, returning it.
+func (s *stubIssuer) rotate(t *testing.T, kid string) *rsa.PrivateKey {
+	t.Helper()
+	key := newKey(t)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.keys = map[string]*rsa.PrivateKey{kid: key}
+	return key
+}
+
+func (s *stubIssuer) served() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.jwksServed
+}
+
+func newKey(t *testing.T) *rsa.PrivateKey {
+	t.Helper()
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("rsa.GenerateKey() error = %v", err)
+	}
+	return key
+}
+
+// sign - build a RS256 JWT with the claims provided.
+func sign(t *testing.T, key *rsa.PrivateKey, kid string, claims map[string]interface{}) string {
+	t.Helper()
+	encode := func(value interface{}) string {
+		content, err := json.Marshal(value)
+		if err != nil {
+			t.Fatalf("json.Marshal() error = %v", err)
+		}
+		return base64.RawURLEncoding.EncodeToString(content)
+	}
+	signingInput := encode(map[string]string{"alg": "RS256", "typ": "JWT", "kid": kid}) + "." + encode(claims)
+	digest := sha256.Sum256([]byte(signingInput))
+	signature, err := rsa.SignPKCS1v15(rand.Reader, key, crypto.SHA256, digest[:])
+	if err != nil {
+		t.Fatalf("rsa.SignPKCS1v15() error = %v", err)
+	}
+	return signingInput + "." + base64.RawURLEncoding.EncodeToString(signature)
+}
+
+// accessToken - the claims of an access token issued by the issuer provided, valid for the next hour.
+func accessToken(issuer string) map[string]interface{} {
+	return map[string]interface{}{
+		"iss":             issuer,
+		"aud":             "scheduling-service",
+		"sub":             "user",
+		"exp":             time.Now().Add(time.Hour).Unix(),
+		"iat":             time.Now().Unix(),
+		"rg":              "1234567",
+		"realm_access":    map[string]interface{}{"roles": []string{"PATIENT"}},
+		"resource_access": map[string]interface{}{"scheduling-service": map[string]interface{}{"roles": []string{"viewer"}}},
+	}
+}
+
+func newTestVerifier(t *testing.T, issuer *stubIssuer) *JWTVerifier {
+	t.Helper()
+	verifier, err := NewJWTVerifier(context.Background(), JWTConfig{
+		IssuerURL: issuer.URL,
+		Audience:  "scheduling-service",
+		ClientID:  "scheduling-service",
+	})
+	if err != nil {
+		t.Fatalf("NewJWTVerifier() error = %v", err)
+	}
+	return verifier
+}
+
+func TestJWTVerifierVerify(t *testing.T) {
+	issuer := newStubIssuer(t)
+	key := issuer.rotate(t, "first")
+	verifier := newTestVerifier(t, issuer)
+
+	wrongAudience := accessToken(issuer.URL)
+	wrongAudience["aud"] = "another-client"
+	expired := accessToken(issuer.URL)
+	expired["exp"] = time.Now().Add(-time.Minute).Unix()
+	wrongIssuer := accessToken(issuer.URL)
+	wrongIssuer["iss"] = "http://another-issuer"
+
+	tests := []struct {
+		name    string
+		token   string
+		wantErr bool
+	}{
+		{"valid", sign(t, key, "first", accessToken(issuer.URL)), false},
+		{"wrong audience", sign(t, key, "first", wrongAudience), true},
+		{"expired", sign(t, key, "first", expired), true},
+		{"wrong issuer", sign(t, key, "first", wrongIssuer), true},
+		{"bad signature", sign(t, newKey(t), "first", accessToken(issuer.URL)), true},
+		{"unknown key", sign(t, newKey(t), "unknown", accessToken(issuer.URL)), true},
+		{"malformed", "not-a-token", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			claims, err := verifier.Verify(context.Background(), tt.token)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Verify() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if want := []string{"PATIENT", "viewer"}; !reflect.DeepEqual(claims.Roles("scheduling-service"), want) {
+				t.Errorf("Roles() = %v, want %v", claims.Roles("scheduling-service"), want)
+			}
+			if claims.RG != "1234567" {
+				t.Errorf("RG = %q, want 1234567", claims.RG)
+			}
+		})
+	}
+}
+
+func TestJWTVerifierFollowsKeyRotation(t *testing.T) {
+	issuer := newStubIssuer(t)
+	oldKey := issuer.rotate(t, "first")
+	verifier := newTestVerifier(t, issuer)
+
+	oldToken := sign(t, oldKey, "first", accessToken(issuer.URL))
+	if _, err := verifier.Verify(context.Background(), oldToken); err != nil {
+		t.Fatalf("Verify() before rotation error = %v", err)
+	}
+	if _, err := verifier.Verify(context.Background(), oldToken); err != nil {
+		t.Fatalf("Verify() with cached keys error = %v", err)
+	}
+	if served := issuer.served(); served != 1 {
+		t.Errorf("JWKS served %d times before rotation, want the keys cached after the first fetch", served)
+	}
+
+	newKey := issuer.rotate(t, "second")
+	if _, err := verifier.Verify(context.Background(), sign(t, newKey, "second", accessToken(issuer.URL))); err != nil {
+		t.Fatalf("Verify() with the rotated key error = %v", err)
+	}
+	if served := issuer.served(); served != 2 {
+		t.Errorf("JWKS served %d times after rotation, want it fetched again once", served)
+	}
+	if _, err := verifier.Verify(context.Background(), oldToken); err == nil {
+		t.Error("Verify() accepted a token signed by a key no longer published")
+	}
+}
+
+func TestNewJWTVerifierFailsWithoutIssuer(t *testing.T) {
+	issuer := newStubIssuer(t)
+	issuer.Close()
+	_, err := NewJWTVerifier(context.Background(), JWTConfig{IssuerURL: issuer.URL, Audience: "scheduling-service"})
+	if err == nil {
+		t.Fatal("NewJWTVerifier() error = nil, want the discovery failure")
+	}
+}
+
+func TestIsAuthorizedJWT(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	issuer := newStubIssuer(t)
+	key := issuer.rotate(t, "first")
+	verifier := newTestVerifier(t, issuer)
+
+	router := gin.New()
+	router.Use(IsAuthorizedJWT(verifier))
+	router.GET("/me", func(c *gin.Context) {
+		principal, _ := domain.PrincipalFromContext(c.Request.Context())
+		c.JSON(http.StatusOK, principal)
+	})
+
+	tests := []struct {
+		name          string
+		authorization string
+		wantStatus    int
+	}{
+		{"without token", "", http.StatusUnauthorized},
+		{"invalid token", "Bearer not-a-token", http.StatusUnauthorized},
+		{"valid token", "Bearer " + sign(t, key, "first", accessToken(issuer.URL)), http.StatusOK},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/me", nil)
+			if tt.authorization != "" {
+				req.Header.Set("Authorization", tt.authorization)
+			}
+			rec := httptest.NewRecorder()
+			router.ServeHTTP(rec, req)
+			if rec.Code != tt.wantStatus {
+				t.Fatalf("status = %d, want %d: %s", rec.Code, tt.wantStatus, rec.Body.String())
+			}
+			if tt.wantStatus != http.StatusOK {
+				return
+			}
+			var principal domain.Principal
+			if err := json.Unmarshal(rec.Body.Bytes(), &principal); err != nil {
+				t.Fatalf("json.Unmarshal() error = %v", err)
+			}
+			if principal.RG != "1234567" || !reflect.DeepEqual(principal.Roles, []string{"PATIENT", "viewer"}) {
+				t.Errorf("principal = %+v", principal)
+			}
+		})
+	}
+}
@@ -0,0 +1,350 @@
+package migration
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"github.com/go-kit/log"
+	"github.com/go-kit/log/level"
+	"io/fs"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ErrSchemaBehind - returned by EnsureUpToDate when there are migrations not applied yet.
+var ErrSchemaBehind = errors.New("database schema is behind, run the pending migrations before starting the service")
+
+// ErrLocked - returned by Up and Down when another instance kept the migration lock for longer than lockTimeout.
+var ErrLocked = errors.New("timed out waiting for the migration lock held by another instance")
+
+// lockName - the advisory lock taken while migrating, so replicas starting together apply each migration once.
+const lockName = "schema_migrations"
+
+// lockTimeout - seconds to wait for the lock held by another instance applying the migrations.
+const lockTimeout = 60
+
+const createVersionsTable = `CREATE TABLE IF NOT EXISTS schema_migrations (
+    version INT NOT NULL,
+    name VARCHAR(255) NOT NULL,
+    applied_at DATETIME NOT NULL,
+
+    PRIMARY KEY (version)
+)ENGINE = INNODB`
+
+// Migration - a versioned schema change with the statements to apply and to revert it.
+type Migration struct {
+	Version int
+	Name    string
+	Up      string
+	Down    string
+}
+
+// Status - a migration and whether it was already applied.
+type Status struct {
+	Migration
+	Applied   bool
+	AppliedAt time.Time
+}
+
+// querier - runs the statements of the migrator, either the pool or the connection holding the migration lock.
+type querier interface {
+	ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error)
+	QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error)
+}
+
+// Migrator - apply and revert the migrations provided, keeping the applied versions at schema_migrations table.
+type Migrator struct {
+	db         *sql.DB
+	migrations []Migration
+	logger     log.Logger
+}
+
+// NewMigrator - Initialize a Migrator with every migration found at the provided file system, ordered by version. Each
+// migration applied or reverted is logged.
+func NewMigrator(db *sql.DB, files fs.FS, logger log.Logger) (*Migrator, error) {
+	migrations, err := load(files)
+	if err != nil {
+		return nil, err
+	}
+	return &Migrator{db: db, migrations: migrations, logger: logger}, nil
+}
+
+// Up - apply every pending migration in order, holding the migration lock so concurrent instances wait for it and
+// find the migrations already applied.
+func (m *Migrator) Up() error {
+	return m.locked(func(ctx context.Context, conn querier) error {
+		// read only once the lock is held, another instance may have applied them meanwhile
+		pending, err := m.pending(ctx, conn)
+		if err != nil {
+			return err
+		}
+		for _, migration := range pending {
+			level.Info(m.logger).Log("msg", "applying migration", "version", migration.Version, "name", migration.Name)
+			if err := m.exec(ctx, conn, migration.Up); err != nil {
+				return fmt.Errorf("migration %04d_%s failed: %w", migration.Version, migration.Name, err)
+			}
+			if _, err := conn.ExecContext(ctx, "INSERT INTO schema_migrations(version, name, applied_at) VALUES (?,?,?)",
+				migration.Version, migration.Name, time.Now()); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// Down - revert the last applied migration, holding the migration lock.
+func (m *Migrator) Down() error {
+	return m.locked(func(ctx context.Context, conn querier) error {
+		applied, err := m.applied(ctx, conn)
+		if err != nil {
+			return err
+		}
+		for i := len(m.migrations) - 1; i >= 0; i-- {
+			migration := m.migrations[i]
+			if _, ok := applied[migration.Version]; !ok {
+				continue
+			}
+			level.Info(m.logger).Log("msg", "reverting migration", "version", migration.Version, "name", migration.Name)
+			if err := m.exec(ctx, conn, migration.Down); err != nil {
+				return fmt.Errorf("revert of migration %04d_%s failed: %w", migration.Version, migration.Name, err)
+			}
+			_, err := conn.ExecContext(ctx, "DELETE FROM schema_migrations WHERE version = ?", migration.Version)
+			return err
+		}
+		return errors.New("there's no migration applied to revert")
+	})
+}
+
+// Status - return every migration known and whether it was applied.
+func (m *Migrator) Status() ([]Status, error) {
+	applied, err := m.applied(context.Background(), m.db)
+	if err != nil {
+		return nil, err
+	}
+	var statuses []Status
+	for _, migration := range m.migrations {
+		appliedAt, ok := applied[migration.Version]
+		statuses = append(statuses, Status{Migration: migration, Applied: ok, AppliedAt: appliedAt})
+	}
+	return statuses, nil
+}
+
+// Pending - return the migrations not applied yet, ordered by version.
+func (m *Migrator) Pending() ([]Migration, error) {
+	return m.pending(context.Background(), m.db)
+}
+
+func (m *Migrator) pending(ctx context.Context, conn querier) ([]Migration, error) {
+	applied, err := m.applied(ctx, conn)
+	if err != nil {
+		return nil, err
+	}
+	var pending []Migration
+	for _, migration := range m.migrations {
+		if _, ok := applied[migration.Version]; !ok {
+			pending = append(pending, migration)
+		}
+	}
+	return pending, nil
+}
+
+// EnsureUpToDate - return ErrSchemaBehind when there's any pending migration.
+func (m *Migrator) EnsureUpToDate() error {
+	pending, err := m.Pending()
+	if err != nil {
+		return err
+	}
+	if len(pending) > 0 {
+		return fmt.Errorf("%w: %d pending, first is %04d_%s", ErrSchemaBehind, len(pending), pending[0].Version, pending[0].Name)
+	}
+	return nil
+}
+
+// locked - run apply at a single connection holding the migration lock, GET_LOCK is bound to the connection that
+// takes it, so every statement must go through it.
+func (m *Migrator) locked(apply func(ctx context.Context, conn querier) error) error {
+	ctx := context.Background()
+	conn, err := m.db.Conn(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	var acquired sql.NullInt64
+	if err := conn.QueryRowContext(ctx, "SELECT GET_LOCK(?, ?)", lockName, lockTimeout).Scan(&acquired); err != nil {
+		return err
+	}
+	if acquired.Int64 != 1 {
+		return ErrLocked
+	}
+	defer func() {
+		var released sql.NullInt64
+		if err := conn.QueryRowContext(ctx, "SELECT RELEASE_LOCK(?)", lockName).Scan(&released); err != nil {
+			level.Warn(m.logger).Log("msg", "failed to release the migration lock", "err", err)
+		}
+	}()
+	return apply(ctx, conn)
+}
+
+// applied - return the versions at schema_migrations and when they were applied, creating the table if needed.
+func (m *Migrator) applied(ctx context.Context, conn querier) (map[int]time.Time, error) {
+	if _, err := conn.ExecContext(ctx, createVersionsTable); err != nil {
+		return nil, err
+	}
+	rows, err := conn.QueryContext(ctx, "SELECT version, DATE_FORMAT(applied_at,'%Y-%m-%d %H:%i:%s') FROM schema_migrations")
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	applied := make(map[int]time.Time)
+	for rows.Next() {
+		var version int
+		var appliedAt string
+		if err := rows.Scan(&version, &appliedAt); err != nil {
+			return nil, err
+		}
+		applied[version], _ = time.ParseInLocation("2006-01-02 15:04:05", appliedAt, time.Local)
+	}
+	return applied, rows.Err()
+}
+
+// exec - run every statement of a migration script, one by one since the driver doesn't allow multi statements.
+func (m *Migrator) exec(ctx context.Context, conn querier, script string) error {
+	for _, statement := range splitStatements(script) {
+		if _, err := conn.ExecContext(ctx, statement); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// load - read the <version>_<name>.up.sql and <version>_<name>.down.sql pairs from the provided file system.
+func load(files fs.FS) ([]Migration, error) {
+	entries, err := fs.ReadDir(files, ".")
+	if err != nil {
+		return nil, err
+	}
+
+	byVersion := make(map[int]*Migration)
+	for _, entry := range entries {
+		fileName := entry.Name()
+		var direction string
+		switch {
+		case strings.HasSuffix(fileName, ".up.sql"):
+			direction = "up"
+		case strings.HasSuffix(fileName, ".down.sql"):
+			direction = "down"
+		default:
+			continue
+		}
+
+		base := strings.TrimSuffix(fileName, "."+direction+".sql")
+		versionAndName := strings.SplitN(base, "_", 2)
+		if len(versionAndName) != 2 {
+			return nil, fmt.Errorf("invalid migration file name %q, must be <version>_<name>.%s.sql", fileName, direction)
+		}
+		version, err := strconv.Atoi(versionAndName[0])
+		if err != nil {
+			return nil, fmt.Errorf("invalid migration version at %q: %w", fileName, err)
+		}
+		content, err := fs.ReadFile(files, fileName)
+		if err != nil {
+			return nil, err
+		}
+
+		migration, ok := byVersion[version]
+		if !ok {
+			migration = &Migration{Version: version, Name: versionAndName[1]}
+			byVersion[version] = migration
+		}
+		if migration.Name != versionAndName[1] {
+			return nil, fmt.Errorf("migration version %d is used by %q and %q", version, migration.Name, versionAndName[1])
+		}
+		if direction == "up" {
+			migration.Up = string(content)
+		} else {
+			migration.Down = string(content)
+		}
+	}
+
+	var migrations []Migration
+	for _, migration := range byVersion {
+		if migration.Up == "" || migration.Down == "" {
+			return nil, fmt.Errorf("migration %04d_%s must have both up and down files", migration.Version, migration.Name)
+		}
+		migrations = append(migrations, *migration)
+	}
+	sort.Slice(migrations, func(i, j int) bool { return migrations[i].Version < migrations[j].Version })
+	return migrations, nil
+}
+
+// splitStatements - break a script into its statements at the semicolons out of quotes and comments, dropping the
+// comments: -- and # up to the end of the line and /* */ blocks.
+func splitStatements(script string) []string {
+	var statements []string
+	var current strings.Builder
+	flush := func() {
+		if statement := strings.TrimSpace(current.String()); statement != "" {
+			statements = append(statements, statement)
+		}
+		current.Reset()
+	}
+
+	for i := 0; i < len(script); i++ {
+		c := script[i]
+		switch {
+		case c == '\'' || c == '"' || c == '`':
+			end := closingQuote(script, i)
+			current.WriteString(script[i:end])
+			i = end - 1
+		case c == '#' || c == '-' && strings.HasPrefix(script[i:], "--") && (i+2 == len(script) || isSpace(script[i+2])):
+			for i < len(script) && script[i] != '\n' {
+				i++
+			}
+			current.WriteByte('\n')
+		case c == '/' && strings.HasPrefix(script[i:], "/*"):
+			end := strings.Index(script[i+2:], "*/")
+			if end < 0 {
+				i = len(script)
+			} else {
+				i += end + 3
+			}
+			current.WriteByte(' ')
+		case c == ';':
+			flush()
+		default:
+			current.WriteByte(c)
+		}
+	}
+	flush()
+	return statements
+}
+
+// closingQuote - the index right after the quote closing the one at start, a quote is escaped either by a backslash
+// or by doubling it. An unterminated quote runs to the end of the script.
+func closingQuote(script string, start int) int {
+	quote := script[start]
+	for i := start + 1; i < len(script); i++ {
+		switch script[i] {
+		case '\\':
+			if quote != '`' {
+				i++
+			}
+		case quote:
+			if i+1 < len(script) && script[i+1] == quote {
+				i++
+				continue
+			}
+			return i + 1
+		}
+	}
+	return len(script)
+}
+
+func isSpace(c byte) bool {
+	return c == ' ' || c == '\t' || c == '\n' || c == '\r'
+}
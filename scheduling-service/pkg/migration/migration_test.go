@@ -0,0 +1,172 @@
+package migration
+
+import (
+	"github.com/ronilsonalves/GoLang-in-a-spring-cloud-architecture/scheduling-service/migrations"
+	"reflect"
+	"strings"
+	"testing"
+	"testing/fstest"
+)
+
+func TestLoadOrdersByVersion(t *testing.T) {
+	files := fstest.MapFS{
+		"0010_add_index.up.sql":      {Data: []byte("CREATE INDEX i ON t (c);")},
+		"0010_add_index.down.sql":    {Data: []byte("DROP INDEX i ON t;")},
+		"0002_create_table.up.sql":   {Data: []byte("CREATE TABLE t (c INT);")},
+		"0002_create_table.down.sql": {Data: []byte("DROP TABLE t;")},
+		"README.md":                  {Data: []byte("not a migration")},
+		"0003_create_other.up.sql":   {Data: []byte("CREATE TABLE o (c INT);")},
+		"0003_create_other.down.sql": {Data: []byte("DROP TABLE o;")},
+	}
+
+	loaded, err := load(files)
+	if err != nil {
+		t.Fatalf("load() error = %v", err)
+	}
+	var versions []int
+	for _, migration := range loaded {
+		versions = append(versions, migration.Version)
+	}
+	if want := []int{2, 3, 10}; !reflect.DeepEqual(versions, want) {
+		t.Fatalf("load() versions = %v, want %v", versions, want)
+	}
+	if loaded[0].Name != "create_table" || loaded[0].Up != "CREATE TABLE t (c INT);" || loaded[0].Down != "DROP TABLE t;" {
+		t.Errorf("load() first migration = %+v", loaded[0])
+	}
+}
+
+func TestLoadRefusesInvalidFiles(t *testing.T) {
+	tests := []struct {
+		name  string
+		files fstest.MapFS
+		want  string
+	}{
+		{
+			name:  "missing down",
+			files: fstest.MapFS{"0001_create.up.sql": {Data: []byte("CREATE TABLE t (c INT);")}},
+			want:  "must have both up and down files",
+		},
+		{
+			name:  "missing name",
+			files: fstest.MapFS{"0001.up.sql": {Data: []byte("SELECT 1;")}},
+			want:  "invalid migration file name",
+		},
+		{
+			name:  "invalid version",
+			files: fstest.MapFS{"first_create.up.sql": {Data: []byte("SELECT 1;")}},
+			want:  "invalid migration version",
+		},
+		{
+			name: "version used twice",
+			files: fstest.MapFS{
+				"0001_create.up.sql":  {Data: []byte("SELECT 1;")},
+				"0001_other.down.sql": {Data: []byte("SELECT 1;")},
+			},
+			want: "migration version 1 is used by",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := load(tt.files)
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Fatalf("load() error = %v, want it to contain %q", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestLoadEmbeddedMigrations(t *testing.T) {
+	loaded, err := load(migrations.FS)
+	if err != nil {
+		t.Fatalf("load() error = %v", err)
+	}
+	for i, migration := range loaded {
+		if migration.Version != i+1 {
+			t.Errorf("migration %04d_%s found at position %d, versions must have no gaps", migration.Version, migration.Name, i+1)
+		}
+		if len(splitStatements(migration.Up)) == 0 || len(splitStatements(migration.Down)) == 0 {
+			t.Errorf("migration %04d_%s has no statements", migration.Version, migration.Name)
+		}
+	}
+}
+
+func TestSplitStatements(t *testing.T) {
+	tests := []struct {
+		name   string
+		script string
+		want   []string
+	}{
+		{
+			name:   "one per line",
+			script: "CREATE TABLE a (id INT);\nCREATE TABLE b (id INT);\n",
+			want:   []string{"CREATE TABLE a (id INT)", "CREATE TABLE b (id INT)"},
+		},
+		{
+			name:   "statement across lines",
+			script: "CREATE TABLE a (\n    id INT\n);",
+			want:   []string{"CREATE TABLE a (\n    id INT\n)"},
+		},
+		{
+			name:   "two at the same line",
+			script: "DELETE FROM a; DELETE FROM b;",
+			want:   []string{"DELETE FROM a", "DELETE FROM b"},
+		},
+		{
+			name:   "last without semicolon",
+			script: "DELETE FROM a;\nDELETE FROM b",
+			want:   []string{"DELETE FROM a", "DELETE FROM b"},
+		},
+		{
+			name:   "semicolon inside single quotes",
+			script: "INSERT INTO a (note) VALUES ('first; second');",
+			want:   []string{"INSERT INTO a (note) VALUES ('first; second')"},
+		},
+		{
+			name:   "semicolon at the end of a line inside quotes",
+			script: "INSERT INTO a (note) VALUES ('first;\nsecond');\nDELETE FROM b;",
+			want:   []string{"INSERT INTO a (note) VALUES ('first;\nsecond')", "DELETE FROM b"},
+		},
+		{
+			name:   "escaped and doubled quotes",
+			script: `INSERT INTO a (note) VALUES ('it''s; here', 'a \'; b', "say ""hi;""");`,
+			want:   []string{`INSERT INTO a (note) VALUES ('it''s; here', 'a \'; b', "say ""hi;""")`},
+		},
+		{
+			name:   "semicolon inside backticks",
+			script: "CREATE TABLE `odd;name` (id INT);",
+			want:   []string{"CREATE TABLE `odd;name` (id INT)"},
+		},
+		{
+			name:   "line comments",
+			script: "-- creates a; then b\nCREATE TABLE a (id INT); -- trailing; comment\n# hash; comment\nCREATE TABLE b (id INT);",
+			want:   []string{"CREATE TABLE a (id INT)", "CREATE TABLE b (id INT)"},
+		},
+		{
+			name:   "comment marker inside quotes",
+			script: "INSERT INTO a (note) VALUES ('-- not a comment; # nor this');",
+			want:   []string{"INSERT INTO a (note) VALUES ('-- not a comment; # nor this')"},
+		},
+		{
+			name:   "block comment",
+			script: "CREATE TABLE a (id INT /* the key; always set */);\n/* DROP TABLE a; */",
+			want:   []string{"CREATE TABLE a (id INT  )"},
+		},
+		{
+			name:   "double dash without space isn't a comment",
+			script: "UPDATE a SET c = c--1;",
+			want:   []string{"UPDATE a SET c = c--1"},
+		},
+		{
+			name:   "only comments",
+			script: "-- nothing to run;\n",
+			want:   nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := splitStatements(tt.script); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("splitStatements() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
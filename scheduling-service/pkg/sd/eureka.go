@@ -2,47 +2,41 @@ package sd
 
 import (
 	"errors"
-	"fmt"
 	"github.com/go-kit/kit/sd/eureka"
 	"github.com/go-kit/log"
+	"github.com/go-kit/log/level"
 	"github.com/hudl/fargo"
+	gologging "github.com/op/go-logging"
 	"net"
-	"os"
 	"strconv"
 	"strings"
 )
 
-// BuildFargoInstance build a Fargo Instance
-func BuildFargoInstance() eureka.Registrar {
-	eurekaAddr := os.Getenv("EUREKA_SERVER_URL")
+// BuildFargoInstance build a Fargo Instance registering the API served at the port and base path provided at the Eureka
+// server, the registrar logs through the logger provided
+func BuildFargoInstance(logger log.Logger, eurekaAddr string, port int, basePath string) eureka.Registrar {
 	if eurekaAddr == "" {
-		fmt.Println("EUREKA_SERVER_URL is not set")
+		level.Warn(logger).Log("msg", "EUREKA_SERVER_URL is not set")
 	}
-
-	logger := log.NewLogfmtLogger(os.Stderr)
-	logger = log.With(logger, "ts", log.DefaultTimestamp)
+	redirectFargoLog(logger)
 
 	var fargoConfig fargo.Config
 	fargoConfig.Eureka.ServiceUrls = []string{eurekaAddr}
 	fargoConfig.Eureka.PollIntervalSeconds = 1
 
 	fargoConnection := fargo.NewConnFromConfig(fargoConfig)
-	fInstance := buildFargoInstanceBody("scheduling-service", "UP")
-	return *eureka.NewRegistrar(&fargoConnection, fInstance, log.With(logger, "component", "registrar"))
+	fInstance := buildFargoInstanceBody(logger, "scheduling-service", "UP", port, basePath)
+	return *eureka.NewRegistrar(&fargoConnection, fInstance, logger)
 }
 
-func buildFargoInstanceBody(appName, status string) *fargo.Instance {
+func buildFargoInstanceBody(logger log.Logger, appName, status string, port int, basePath string) *fargo.Instance {
 	ipAddr, err := externalIP()
 	if err != nil {
-		fmt.Println(err)
-	}
-	port, err := strconv.Atoi(os.Getenv("PORT"))
-	if err != nil {
-		fmt.Println(err)
-		port = 9000
+		level.Warn(logger).Log("msg", "failed to find the instance address", "err", err)
 	}
+	portText := strconv.Itoa(port)
 	return &fargo.Instance{
-		InstanceId:        ipAddr + ":" + appName + ":" + os.Getenv("PORT"),
+		InstanceId:        ipAddr + ":" + appName + ":" + portText,
 		HostName:          "localhost",
 		App:               strings.ToUpper(appName),
 		IPAddr:            ipAddr,
@@ -54,9 +48,9 @@ func buildFargoInstanceBody(appName, status string) *fargo.Instance {
 		PortEnabled:       true,
 		SecurePort:        8443,
 		SecurePortEnabled: false,
-		HomePageUrl:       "http://localhost:" + os.Getenv("PORT") + os.Getenv("BASE_PATH"),
-		StatusPageUrl:     "http://localhost:" + os.Getenv("PORT") + "/status",
-		HealthCheckUrl:    "http://localhost:" + os.Getenv("PORT") + "/health",
+		HomePageUrl:       "http://localhost:" + portText + basePath,
+		StatusPageUrl:     "http://localhost:" + portText + "/status",
+		HealthCheckUrl:    "http://localhost:" + portText + "/health",
 		CountryId:         0,
 		DataCenterInfo: fargo.DataCenterInfo{
 			Name: "MyOwn", Class: "com.netflix.appinfo.InstanceInfo$DefaultDataCenterInfo",
@@ -67,6 +61,34 @@ func buildFargoInstanceBody(appName, status string) *fargo.Instance {
 	}
 }
 
+// redirectFargoLog - write the lines Fargo logs through the logger provided, from the notice level up.
+func redirectFargoLog(logger log.Logger) {
+	backend := gologging.AddModuleLevel(fargoBackend{logger: logger})
+	backend.SetLevel(gologging.NOTICE, "fargo")
+	backend.SetLevel(gologging.WARNING, "fargo.metadata")
+	backend.SetLevel(gologging.WARNING, "fargo.marshal")
+	gologging.SetBackend(backend)
+}
+
+// fargoBackend - a go-logging backend writing to a go-kit logger at the matching level.
+type fargoBackend struct {
+	logger log.Logger
+}
+
+// Log - write a line of Fargo.
+func (b fargoBackend) Log(lvl gologging.Level, calldepth int, rec *gologging.Record) error {
+	logger := level.Info(b.logger)
+	switch {
+	case lvl <= gologging.ERROR:
+		logger = level.Error(b.logger)
+	case lvl == gologging.WARNING:
+		logger = level.Warn(b.logger)
+	case lvl == gologging.DEBUG:
+		logger = level.Debug(b.logger)
+	}
+	return logger.Log("msg", rec.Message(), "module", rec.Module)
+}
+
 // aux func to get external ip from
 func externalIP() (string, error) {
 	ifaces, err := net.Interfaces()
@@ -0,0 +1,353 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"github.com/ronilsonalves/GoLang-in-a-spring-cloud-architecture/scheduling-service/internal/domain"
+	"sort"
+	"sync"
+	"time"
+)
+
+// NewMemoryAp - Initialize ApStore interface kept in memory, dentists and patients are looked up on the stores
+// provided, as the SQL store does through its joins, and the appointments are checked against the schedules and
+// closures provided. The events of the changes are added to the outbox.
+func NewMemoryAp(dentists Store[domain.Dentist], patients Store[domain.Patient], schedules ScheduleStore, closures ClosureStore, outbox OutboxStore) ApStore {
+	return &appointmentMemoryStore{
+		rows:      newMemoryStore(func(a *domain.Appointment, id int) { a.Id = id }),
+		dentists:  dentists,
+		patients:  patients,
+		schedules: schedules,
+		closures:  closures,
+		outbox:    outbox,
+		billing:   make(map[int]billingRow),
+	}
+}
+
+type appointmentMemoryStore struct {
+	// mu - serializes the checks and the write, as the row locks do at the SQL store.
+	mu        sync.Mutex
+	rows      *memoryStore[domain.Appointment]
+	dentists  Store[domain.Dentist]
+	patients  Store[domain.Patient]
+	schedules ScheduleStore
+	closures  ClosureStore
+	outbox    OutboxStore
+	// billingMu - guards billing, kept apart from the rows as the SQL store keeps it apart from the other columns.
+	billingMu sync.RWMutex
+	billing   map[int]billingRow
+}
+
+// billingRow - the invoice of an appointment along with when it was reported.
+type billingRow struct {
+	billing    domain.Billing
+	occurredAt time.Time
+}
+
+// GetAll - Return all appointments with their dentist and patient ordered by date and time.
+func (sa *appointmentMemoryStore) GetAll(ctx context.Context) ([]domain.AppointmentDTO, error) {
+	return sa.filterAppointmentsDTO(ctx, func(domain.Appointment) bool { return true })
+}
+
+// GetByID - Return an appointment with its dentist and patient by ID
+func (sa *appointmentMemoryStore) GetByID(ctx context.Context, entityID int) (domain.AppointmentDTO, error) {
+	appointment, err := sa.rows.GetByID(ctx, entityID)
+	if err != nil {
+		return domain.AppointmentDTO{}, err
+	}
+	return sa.toDTO(ctx, appointment)
+}
+
+// Save - Insert a new appointment, only the appointment fields are persisted, dentist and patient are loaded back.
+func (sa *appointmentMemoryStore) Save(ctx context.Context, entity domain.AppointmentDTO) (domain.AppointmentDTO, error) {
+	sa.mu.Lock()
+	defer sa.mu.Unlock()
+
+	appointment := keepStatus(entity.Appointment, domain.Appointment{Status: domain.StatusScheduled})
+	appointment, err := sa.prepareWrite(ctx, appointment)
+	if err != nil {
+		return domain.AppointmentDTO{}, err
+	}
+	appointment, err = sa.rows.Save(ctx, appointment)
+	if err != nil {
+		return domain.AppointmentDTO{}, err
+	}
+	return sa.addEvent(ctx, appointment.Id, domain.EventAppointmentCreated)
+}
+
+// Update - update an appointment by ID, only the appointment fields are persisted, dentist and patient are loaded back.
+// The status is kept, it only changes through UpdateStatus, and only open appointments can be changed.
+func (sa *appointmentMemoryStore) Update(ctx context.Context, entityID int, entity domain.AppointmentDTO) (domain.AppointmentDTO, error) {
+	sa.mu.Lock()
+	defer sa.mu.Unlock()
+
+	stored, err := sa.rows.GetByID(ctx, entityID)
+	if err != nil {
+		return domain.AppointmentDTO{}, err
+	}
+	if err := checkOpen(stored); err != nil {
+		return domain.AppointmentDTO{}, err
+	}
+	entity.Id = entityID
+	appointment, err := sa.prepareWrite(ctx, keepStatus(entity.Appointment, stored))
+	if err != nil {
+		return domain.AppointmentDTO{}, err
+	}
+	if _, err := sa.rows.Update(ctx, entityID, appointment); err != nil {
+		return domain.AppointmentDTO{}, err
+	}
+	return sa.addEvent(ctx, entityID, domain.AppointmentChangeEvent(stored, appointment))
+}
+
+// UpdateStatus - move an appointment to the status provided
+func (sa *appointmentMemoryStore) UpdateStatus(ctx context.Context, entityID int, status domain.AppointmentStatus, reason string) (domain.AppointmentDTO, error) {
+	sa.mu.Lock()
+	defer sa.mu.Unlock()
+
+	appointment, err := sa.rows.GetByID(ctx, entityID)
+	if err != nil {
+		return domain.AppointmentDTO{}, err
+	}
+	if err := appointment.TransitionTo(status, time.Now(), reason); err != nil {
+		return domain.AppointmentDTO{}, err
+	}
+	if _, err := sa.rows.Update(ctx, entityID, appointment); err != nil {
+		return domain.AppointmentDTO{}, err
+	}
+	return sa.addEvent(ctx, entityID, domain.AppointmentStatusEvent(status))
+}
+
+// Delete - exclude an appointment by ID, adding the appointment.deleted event to the outbox.
+func (sa *appointmentMemoryStore) Delete(ctx context.Context, entityID int) error {
+	sa.mu.Lock()
+	defer sa.mu.Unlock()
+
+	appointment, err := sa.GetByID(ctx, entityID)
+	if err != nil {
+		return err
+	}
+	if err := sa.rows.Delete(ctx, entityID); err != nil {
+		return err
+	}
+	sa.billingMu.Lock()
+	delete(sa.billing, entityID)
+	sa.billingMu.Unlock()
+	return addEvent(ctx, sa.outbox, domain.EventAppointmentDeleted, appointment)
+}
+
+// UpdateBilling - replace the invoice of an appointment by the one reported at occurredAt, a report older than the one
+// kept is ignored.
+func (sa *appointmentMemoryStore) UpdateBilling(ctx context.Context, entityID int, billing domain.Billing, occurredAt time.Time) error {
+	if _, err := sa.rows.GetByID(ctx, entityID); err != nil {
+		return err
+	}
+	sa.billingMu.Lock()
+	defer sa.billingMu.Unlock()
+
+	if stored, ok := sa.billing[entityID]; ok && stored.occurredAt.After(occurredAt) {
+		return nil
+	}
+	billing.UpdatedAt = occurredAt.Format(domain.DateTimeLayout)
+	sa.billing[entityID] = billingRow{billing: billing, occurredAt: occurredAt}
+	return nil
+}
+
+// appointmentSorts - how each sort field of the appointments list orders two appointments.
+var appointmentSorts = map[string]func(a, b domain.Appointment) bool{
+	"id": func(a, b domain.Appointment) bool { return a.Id < b.Id },
+	"dateAndTime": func(a, b domain.Appointment) bool {
+		aStart, _ := domain.ParseDateTime(a.DateAndTime)
+		bStart, _ := domain.ParseDateTime(b.DateAndTime)
+		return aStart.Before(bStart)
+	},
+	"dentistCRO": func(a, b domain.Appointment) bool { return a.DentistCRO < b.DentistCRO },
+	"patientRG":  func(a, b domain.Appointment) bool { return a.PatientRG < b.PatientRG },
+	"status":     func(a, b domain.Appointment) bool { return a.Status < b.Status },
+}
+
+// List - Return a page of the appointments matching the filter with their dentist and patient, sorted by date and
+// time unless requested otherwise. Only the appointments of the page are joined.
+func (sa *appointmentMemoryStore) List(ctx context.Context, q domain.ListQuery[domain.AppointmentFilter]) (domain.Page[domain.AppointmentDTO], error) {
+	all, err := sa.rows.GetAll(ctx)
+	if err != nil {
+		return domain.Page[domain.AppointmentDTO]{}, err
+	}
+	page, err := paginate(all, q, func(a domain.Appointment) bool {
+		start, err := domain.ParseDateTime(a.DateAndTime)
+		if err != nil {
+			return false
+		}
+		return (q.Filter.From.IsZero() || !start.Before(q.Filter.From)) &&
+			(q.Filter.To.IsZero() || start.Before(q.Filter.To)) &&
+			(q.Filter.DentistCRO == "" || a.DentistCRO == q.Filter.DentistCRO) &&
+			(q.Filter.PatientRG == "" || a.PatientRG == q.Filter.PatientRG) &&
+			(q.Filter.Status == "" || a.Status == q.Filter.Status)
+	}, appointmentSorts, "dateAndTime")
+	if err != nil {
+		return domain.Page[domain.AppointmentDTO]{}, err
+	}
+
+	appointments := make([]domain.AppointmentDTO, 0, len(page.Items))
+	for _, appointment := range page.Items {
+		dto, err := sa.toDTO(ctx, appointment)
+		if err != nil {
+			return domain.Page[domain.AppointmentDTO]{}, err
+		}
+		appointments = append(appointments, dto)
+	}
+	return domain.NewPage(appointments, q, page.Total), nil
+}
+
+// GetAllAppointmentsByDateTimeInterval - return a list of all appointments overlapping a datetime interval.
+func (sa *appointmentMemoryStore) GetAllAppointmentsByDateTimeInterval(ctx context.Context, startDateTime, endDateTime time.Time) ([]domain.Appointment, error) {
+	all, err := sa.rows.GetAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var appointments []domain.Appointment
+	for _, appointment := range all {
+		start, end, err := appointment.Interval()
+		if err != nil {
+			return nil, err
+		}
+		if domain.Overlaps(start, end, startDateTime, endDateTime) {
+			appointments = append(appointments, appointment)
+		}
+	}
+	sortByDateAndTime(appointments, func(a domain.Appointment) string { return a.DateAndTime })
+	return appointments, nil
+}
+
+// prepareWrite - validate the appointment references, the dentist schedule, the clinic closures and the other
+// appointments, filling its duration and end date and time. Called holding the lock.
+func (sa *appointmentMemoryStore) prepareWrite(ctx context.Context, appointment domain.Appointment) (domain.Appointment, error) {
+	if _, err := sa.toDTO(ctx, appointment); err != nil {
+		return appointment, err
+	}
+	start, end, err := appointment.Interval()
+	if err != nil {
+		return appointment, errors.New("failed to convert datetime")
+	}
+
+	schedule, err := sa.schedules.GetByDentistCRO(ctx, appointment.DentistCRO)
+	if err != nil {
+		return appointment, err
+	}
+	if !schedule.IsAvailable(start, end) {
+		return appointment, ErrOutsideWorkingHours
+	}
+	closures, err := sa.closures.GetAllByDateTimeInterval(ctx, start, end)
+	if err != nil {
+		return appointment, err
+	}
+	if len(closures) > 0 {
+		return appointment, ErrClinicClosed
+	}
+
+	all, err := sa.rows.GetAll(ctx)
+	if err != nil {
+		return appointment, err
+	}
+	for _, other := range all {
+		if appointment.ConflictsWith(other) {
+			return appointment, ErrScheduleConflict
+		}
+	}
+
+	appointment.Duration = appointment.DurationOrDefault()
+	appointment.EndDateAndTime = end.Format(domain.DateTimeLayout)
+	return appointment, nil
+}
+
+// addEvent - add the event of a change to the appointment written to the outbox, called holding the lock.
+func (sa *appointmentMemoryStore) addEvent(ctx context.Context, entityID int, eventType domain.EventType) (domain.AppointmentDTO, error) {
+	appointment, err := sa.GetByID(ctx, entityID)
+	if err != nil {
+		return domain.AppointmentDTO{}, err
+	}
+	if err := addEvent(ctx, sa.outbox, eventType, appointment); err != nil {
+		return domain.AppointmentDTO{}, err
+	}
+	return appointment, nil
+}
+
+// keepStatus - copy the status and transition fields of the stored appointment, they aren't written by Save and Update.
+func keepStatus(appointment, stored domain.Appointment) domain.Appointment {
+	appointment.Status = stored.Status
+	appointment.ConfirmedAt = stored.ConfirmedAt
+	appointment.CheckedInAt = stored.CheckedInAt
+	appointment.CompletedAt = stored.CompletedAt
+	appointment.CancelledAt = stored.CancelledAt
+	appointment.CancellationReason = stored.CancellationReason
+	appointment.NoShowAt = stored.NoShowAt
+	return appointment
+}
+
+// filterAppointmentsDTO - return every appointment matching the filter provided with its dentist and patient.
+func (sa *appointmentMemoryStore) filterAppointmentsDTO(ctx context.Context, match func(a domain.Appointment) bool) ([]domain.AppointmentDTO, error) {
+	all, err := sa.rows.GetAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var appointments []domain.AppointmentDTO
+	for _, appointment := range all {
+		if !match(appointment) {
+			continue
+		}
+		dto, err := sa.toDTO(ctx, appointment)
+		if err != nil {
+			return appointments, err
+		}
+		appointments = append(appointments, dto)
+	}
+	sortByDateAndTime(appointments, func(a domain.AppointmentDTO) string { return a.DateAndTime })
+	return appointments, nil
+}
+
+// toDTO - resolve the dentist and patient of an appointment, failing as a foreign key would when one is missing.
+func (sa *appointmentMemoryStore) toDTO(ctx context.Context, appointment domain.Appointment) (domain.AppointmentDTO, error) {
+	if _, err := domain.ParseDateTime(appointment.DateAndTime); err != nil {
+		return domain.AppointmentDTO{}, errors.New("failed to convert datetime")
+	}
+	dto := domain.AppointmentDTO{Appointment: appointment}
+
+	dentists, err := sa.dentists.GetAll(ctx)
+	if err != nil {
+		return dto, err
+	}
+	for _, dentist := range dentists {
+		if dentist.CRO == appointment.DentistCRO {
+			dto.Dentist = dentist
+		}
+	}
+	patients, err := sa.patients.GetAll(ctx)
+	if err != nil {
+		return dto, err
+	}
+	for _, patient := range patients {
+		if patient.RG == appointment.PatientRG {
+			dto.Patient = patient
+		}
+	}
+
+	if dto.Dentist.Id == 0 || dto.Patient.Id == 0 {
+		return dto, errors.New("dentist or patient provided does not exist")
+	}
+
+	sa.billingMu.RLock()
+	defer sa.billingMu.RUnlock()
+	if row, ok := sa.billing[appointment.Id]; ok {
+		billing := row.billing
+		dto.Billing = &billing
+	}
+	return dto, nil
+}
+
+// sortByDateAndTime - order appointments by their start, as the SQL store does.
+func sortByDateAndTime[T any](appointments []T, dateAndTime func(T) string) {
+	sort.SliceStable(appointments, func(i, j int) bool {
+		a, _ := domain.ParseDateTime(dateAndTime(appointments[i]))
+		b, _ := domain.ParseDateTime(dateAndTime(appointments[j]))
+		return a.Before(b)
+	})
+}
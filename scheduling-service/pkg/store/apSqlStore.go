@@ -1,125 +1,447 @@
 package store
 
 import (
+	"context"
 	"database/sql"
-	"github.com/ronilsonalves/GoLang-in-a-spring-cloud-architecture/scheduling-service/config"
+	"errors"
+	"fmt"
 	"github.com/ronilsonalves/GoLang-in-a-spring-cloud-architecture/scheduling-service/internal/domain"
-	"log"
+	"time"
 )
 
-func init() {
-	config.LoadConfig()
+const appointmentQuery = "SELECT id, description, DATE_FORMAT(date_and_time,'%d/%m/%Y %H:%i'), dentist_cro, patient_rg, procedure_name, duration_minutes, DATE_FORMAT(end_date_and_time,'%d/%m/%Y %H:%i'), " + statusColumns + " FROM appointments"
+
+const appointmentDTOQuery = "SELECT a.id, a.description, DATE_FORMAT(a.date_and_time,'%d/%m/%Y %H:%i') date_and_time,a.dentist_cro,a.patient_rg,a.procedure_name,a.duration_minutes,DATE_FORMAT(a.end_date_and_time,'%d/%m/%Y %H:%i') end_date_and_time," + statusColumns + ",d.id,d.last_name,d.name,d.cro,p.id,p.last_name,p.name,p.rg,DATE_FORMAT(p.created_at,'%d/%m/%Y %H:%i') created_at," + billingColumns + " FROM appointments a INNER JOIN dentists d on a.dentist_cro = d.cro INNER JOIN patients p on a.patient_rg = p.rg"
+
+// statusColumns - the status and transition timestamps of an appointment, the timestamps are empty until it happens.
+const statusColumns = "status, IFNULL(DATE_FORMAT(confirmed_at,'%d/%m/%Y %H:%i'),''), IFNULL(DATE_FORMAT(checked_in_at,'%d/%m/%Y %H:%i'),''), IFNULL(DATE_FORMAT(completed_at,'%d/%m/%Y %H:%i'),''), IFNULL(DATE_FORMAT(cancelled_at,'%d/%m/%Y %H:%i'),''), cancellation_reason, IFNULL(DATE_FORMAT(no_show_at,'%d/%m/%Y %H:%i'),'')"
+
+// billingColumns - the invoice of an appointment reported by the invoice service, empty until there's one.
+const billingColumns = "IFNULL(a.invoice_id,''), a.payment_status, IFNULL(DATE_FORMAT(a.billing_updated_at,'%d/%m/%Y %H:%i'),'')"
+
+// statusTimestampColumns - the column recording when an appointment moved to each status.
+var statusTimestampColumns = map[domain.AppointmentStatus]string{
+	domain.StatusConfirmed: "confirmed_at",
+	domain.StatusCheckedIn: "checked_in_at",
+	domain.StatusCompleted: "completed_at",
+	domain.StatusCancelled: "cancelled_at",
+	domain.StatusNoShow:    "no_show_at",
+}
+
+// appointmentSortColumns - the column each sort field of the appointments list maps to.
+var appointmentSortColumns = map[string]string{
+	"id":          "a.id",
+	"dateAndTime": "a.date_and_time",
+	"dentistCRO":  "a.dentist_cro",
+	"patientRG":   "a.patient_rg",
+	"status":      "a.status",
 }
 
 // ApStore - Set the contract for ApStore that is made of a composition of Store interface.
 type ApStore interface {
-	Store
-	GetAllAppointmentsByPatientIdentify(identifyNumber string) ([]domain.AppointmentDTO, error)
-	GetAllAppointmentsByDentistsLicense(licenseNumber string) ([]domain.AppointmentDTO, error)
-	GetAllAppointmentsByDateTimeInterval(startDateTime, endDateTime string) ([]domain.Appointment, error)
+	Store[domain.AppointmentDTO]
+	Lister[domain.AppointmentDTO, domain.AppointmentFilter]
+	GetAllAppointmentsByDateTimeInterval(ctx context.Context, startDateTime, endDateTime time.Time) ([]domain.Appointment, error)
+	UpdateStatus(ctx context.Context, entityID int, status domain.AppointmentStatus, reason string) (domain.AppointmentDTO, error)
+	UpdateBilling(ctx context.Context, entityID int, billing domain.Billing, occurredAt time.Time) error
+}
+
+// ErrScheduleConflict - returned when saving an appointment that overlaps another one of the same dentist or patient.
+var ErrScheduleConflict = errors.New("the appointment overlaps another one of the same dentist or patient")
+
+// ErrOutsideWorkingHours - returned when saving an appointment the dentist doesn't work during, breaks and exceptions
+// included.
+var ErrOutsideWorkingHours = errors.New("the appointment is outside the dentist working hours")
+
+// ErrClinicClosed - returned when saving an appointment overlapping a clinic closure.
+var ErrClinicClosed = errors.New("the clinic is closed during the appointment")
+
+// NewSQLAp - Initialize ApStore interface backed by the provided database
+func NewSQLAp(db *sql.DB) ApStore {
+	return &appointmentStore{db: db}
+}
+
+type appointmentStore struct {
+	db *sql.DB
 }
 
-// NewSQLAp - Initialize ApStore interface
-func NewSQLAp() ApStore {
-	database, err := config.ConnectDatabase()
+// GetAll - Return all appointments with their dentist and patient.
+func (sa *appointmentStore) GetAll(ctx context.Context) ([]domain.AppointmentDTO, error) {
+	return sa.queryAppointmentsDTO(ctx, appointmentDTOQuery+" ORDER BY a.date_and_time")
+}
+
+// GetByID - Return an appointment with its dentist and patient by ID
+func (sa *appointmentStore) GetByID(ctx context.Context, entityID int) (domain.AppointmentDTO, error) {
+	row := sa.db.QueryRowContext(ctx, appointmentDTOQuery+" WHERE a.id = ?", entityID)
+	appointment, err := scanAppointmentDTO(row)
+	if errors.Is(err, sql.ErrNoRows) {
+		return domain.AppointmentDTO{}, ErrNotFound
+	}
+	return appointment, err
+}
+
+// Save - Insert a new appointment, only the appointment fields are persisted, dentist and patient are loaded back.
+// The conflict check and the insert run in the same transaction holding the dentist and patient rows locked, so
+// concurrent bookings for any of them are serialized. The appointment.created event is recorded at the outbox in the
+// same transaction.
+func (sa *appointmentStore) Save(ctx context.Context, entity domain.AppointmentDTO) (domain.AppointmentDTO, error) {
+	appointment := entity.Appointment
+	start, end, err := appointment.Interval()
 	if err != nil {
-		panic(err)
+		return domain.AppointmentDTO{}, errors.New("failed to convert datetime")
 	}
-	return &appointmentStore{
-		sqlStore: &sqlStore{db: database},
-		db:       database,
+
+	tx, err := sa.db.BeginTx(ctx, nil)
+	if err != nil {
+		return domain.AppointmentDTO{}, err
 	}
+	defer tx.Rollback()
+
+	if err := lockAndCheckConflicts(ctx, tx, appointment, start, end); err != nil {
+		return domain.AppointmentDTO{}, err
+	}
+	result, err := tx.ExecContext(ctx, "INSERT INTO appointments(description, date_and_time, dentist_cro, patient_rg, procedure_name, duration_minutes, end_date_and_time, status) VALUES(?,?,?,?,?,?,?,?)",
+		appointment.Description,
+		toSQLDateTime(start),
+		appointment.DentistCRO,
+		appointment.PatientRG,
+		appointment.Procedure,
+		appointment.DurationOrDefault(),
+		toSQLDateTime(end),
+		domain.StatusScheduled)
+	if err != nil {
+		return domain.AppointmentDTO{}, err
+	}
+	lastInsertedID, err := result.LastInsertId()
+	if err != nil {
+		return domain.AppointmentDTO{}, err
+	}
+	return commitWithEvent(ctx, tx, int(lastInsertedID), func(domain.AppointmentDTO) domain.EventType {
+		return domain.EventAppointmentCreated
+	})
 }
 
-type appointmentStore struct {
-	*sqlStore
-	db *sql.DB
+// Update - update an appointment by ID, only the appointment fields are persisted, dentist and patient are loaded back.
+// As in Save, the conflict check, the update and its event are atomic, the event is appointment.rescheduled when the
+// dentist or the interval change. The status is kept, it only changes through UpdateStatus, and it's checked to be open
+// while the row is locked, so a concurrent cancel or completion can't slip in before the write.
+func (sa *appointmentStore) Update(ctx context.Context, entityID int, entity domain.AppointmentDTO) (domain.AppointmentDTO, error) {
+	appointment := entity.Appointment
+	appointment.Id = entityID
+	start, end, err := appointment.Interval()
+	if err != nil {
+		return domain.AppointmentDTO{}, errors.New("failed to convert datetime")
+	}
+
+	tx, err := sa.db.BeginTx(ctx, nil)
+	if err != nil {
+		return domain.AppointmentDTO{}, err
+	}
+	defer tx.Rollback()
+
+	if err := lockAndCheckConflicts(ctx, tx, appointment, start, end); err != nil {
+		return domain.AppointmentDTO{}, err
+	}
+	before, err := scanAppointment(tx.QueryRowContext(ctx, appointmentQuery+" WHERE id = ? FOR UPDATE", entityID))
+	if errors.Is(err, sql.ErrNoRows) {
+		return domain.AppointmentDTO{}, ErrNotFound
+	}
+	if err != nil {
+		return domain.AppointmentDTO{}, err
+	}
+	if err := checkOpen(before); err != nil {
+		return domain.AppointmentDTO{}, err
+	}
+	_, err = tx.ExecContext(ctx, "UPDATE appointments SET description = ?, date_and_time = ?, dentist_cro = ?, patient_rg = ?, procedure_name = ?, duration_minutes = ?, end_date_and_time = ? WHERE id = ?",
+		appointment.Description,
+		toSQLDateTime(start),
+		appointment.DentistCRO,
+		appointment.PatientRG,
+		appointment.Procedure,
+		appointment.DurationOrDefault(),
+		toSQLDateTime(end),
+		entityID)
+	if err != nil {
+		return domain.AppointmentDTO{}, err
+	}
+	return commitWithEvent(ctx, tx, entityID, func(after domain.AppointmentDTO) domain.EventType {
+		return domain.AppointmentChangeEvent(before, after.Appointment)
+	})
 }
 
-// GetAllAppointmentsByPatientIdentify - return a list of all appointments made by a patient through your identity number
-func (sa *appointmentStore) GetAllAppointmentsByPatientIdentify(identifyNumber string) ([]domain.AppointmentDTO, error) {
-	var appointment domain.AppointmentDTO
-	var appointments []domain.AppointmentDTO
+// UpdateStatus - move an appointment to the status provided, the row stays locked from the transition check to the write
+// of the change and its event.
+func (sa *appointmentStore) UpdateStatus(ctx context.Context, entityID int, status domain.AppointmentStatus, reason string) (domain.AppointmentDTO, error) {
+	tx, err := sa.db.BeginTx(ctx, nil)
+	if err != nil {
+		return domain.AppointmentDTO{}, err
+	}
+	defer tx.Rollback()
 
-	query := "SELECT a.id, a.description, DATE_FORMAT(a.date_and_time,'%d/%m/%Y %H:%i') date_and_time,a.dentist_cro,a.patient_rg,d.id,d.surname,d.name,d.cro,p.id,p.surname,p.name,p.rg,DATE_FORMAT(p.created_at,'%d/%m/%Y %H:%i') created_at FROM appointments a INNER JOIN dentists d on a.dentist_cro = d.cro INNER JOIN patients p on a.patient_rg = p.rg WHERE a.patient_rg = ? ORDER BY a.date_and_time"
-	rows, err := sa.db.Query(query, identifyNumber)
+	appointment, err := scanAppointment(tx.QueryRowContext(ctx, appointmentQuery+" WHERE id = ? FOR UPDATE", entityID))
+	if errors.Is(err, sql.ErrNoRows) {
+		return domain.AppointmentDTO{}, ErrNotFound
+	}
 	if err != nil {
-		log.Fatalln(err)
+		return domain.AppointmentDTO{}, err
 	}
-	for rows.Next() {
-		if err := rows.Scan(
-			&appointment.Id,
-			&appointment.Description,
-			&appointment.DateAndTime,
-			&appointment.DentistCRO,
-			&appointment.PatientRG,
-			&appointment.Dentist.Id,
-			&appointment.Dentist.LastName,
-			&appointment.Dentist.Name,
-			&appointment.Dentist.CRO,
-			&appointment.Patient.Id,
-			&appointment.Patient.LastName,
-			&appointment.Patient.Name,
-			&appointment.Patient.RG,
-			&appointment.Patient.CreatedAt); err != nil {
-			return appointments, err
-		}
-		appointments = append(appointments, appointment)
+	now := time.Now()
+	if err := appointment.TransitionTo(status, now, reason); err != nil {
+		return domain.AppointmentDTO{}, err
 	}
-	return appointments, nil
+	_, err = tx.ExecContext(ctx, "UPDATE appointments SET status = ?, cancellation_reason = ?, "+statusTimestampColumns[status]+" = ? WHERE id = ?",
+		appointment.Status,
+		appointment.CancellationReason,
+		toSQLDateTime(now),
+		entityID)
+	if err != nil {
+		return domain.AppointmentDTO{}, err
+	}
+	return commitWithEvent(ctx, tx, entityID, func(domain.AppointmentDTO) domain.EventType {
+		return domain.AppointmentStatusEvent(status)
+	})
 }
 
-// GetAllAppointmentsByDentistsLicense - return a list of all appointments made by a dentist through your license number
-func (sa *appointmentStore) GetAllAppointmentsByDentistsLicense(licenseNumber string) ([]domain.AppointmentDTO, error) {
-	var appointment domain.AppointmentDTO
-	var appointments []domain.AppointmentDTO
+// UpdateBilling - replace the invoice of an appointment by the one reported at occurredAt, a report older than the one
+// kept is ignored since the invoice events may arrive out of order. No event is recorded, the change comes from the
+// invoice service itself.
+func (sa *appointmentStore) UpdateBilling(ctx context.Context, entityID int, billing domain.Billing, occurredAt time.Time) error {
+	result, err := sa.db.ExecContext(ctx, "UPDATE appointments SET invoice_id = ?, payment_status = ?, billing_updated_at = ? WHERE id = ? AND (billing_updated_at IS NULL OR billing_updated_at <= ?)",
+		billing.InvoiceID,
+		billing.PaymentStatus,
+		toSQLDateTime(occurredAt),
+		entityID,
+		toSQLDateTime(occurredAt))
+	if err != nil {
+		return err
+	}
+	if affected, err := result.RowsAffected(); err == nil && affected > 0 {
+		return nil
+	}
+	// nothing changed, either the report is stale or repeated or the appointment doesn't exist
+	var id int
+	err = sa.db.QueryRowContext(ctx, "SELECT id FROM appointments WHERE id = ?", entityID).Scan(&id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return ErrNotFound
+	}
+	return err
+}
+
+// Delete - exclude an appointment by ID, recording its removal at the outbox in the same transaction.
+func (sa *appointmentStore) Delete(ctx context.Context, entityID int) error {
+	return deleteWithEvent(ctx, sa.db, "appointments", appointmentDTOQuery+" WHERE a.id = ?", scanAppointmentDTO, entityID, domain.EventAppointmentDeleted)
+}
 
-	query := "SELECT a.id, a.description, DATE_FORMAT(a.date_and_time,'%d/%m/%Y %H:%i') date_and_time,a.dentist_cro,a.patient_rg,d.id,d.surname,d.name,d.cro,p.id,p.surname,p.name,p.rg,DATE_FORMAT(p.created_at,'%d/%m/%Y %H:%i') created_at FROM appointments a INNER JOIN dentists d on a.dentist_cro = d.cro INNER JOIN patients p on a.patient_rg = p.rg WHERE a.dentist_cro = ? ORDER BY a.date_and_time"
-	rows, err := sa.db.Query(query, licenseNumber)
+// List - Return a page of the appointments matching the filter with their dentist and patient, sorted by date and
+// time unless requested otherwise. Only the appointments of the page are joined.
+func (sa *appointmentStore) List(ctx context.Context, q domain.ListQuery[domain.AppointmentFilter]) (domain.Page[domain.AppointmentDTO], error) {
+	order, err := orderBy(q, appointmentSortColumns, "dateAndTime", "a.id")
 	if err != nil {
-		log.Fatalln(err)
+		return domain.Page[domain.AppointmentDTO]{}, err
+	}
+	var filter sqlFilter
+	if !q.Filter.From.IsZero() {
+		filter.add("a.date_and_time >= ?", toSQLDateTime(q.Filter.From))
+	}
+	if !q.Filter.To.IsZero() {
+		filter.add("a.date_and_time < ?", toSQLDateTime(q.Filter.To))
+	}
+	if q.Filter.DentistCRO != "" {
+		filter.add("a.dentist_cro = ?", q.Filter.DentistCRO)
+	}
+	if q.Filter.PatientRG != "" {
+		filter.add("a.patient_rg = ?", q.Filter.PatientRG)
 	}
+	if q.Filter.Status != "" {
+		filter.add("a.status = ?", q.Filter.Status)
+	}
+
+	var total int
+	if err := sa.db.QueryRowContext(ctx, "SELECT COUNT(*) FROM appointments a"+filter.where(), filter.args...).Scan(&total); err != nil {
+		return domain.Page[domain.AppointmentDTO]{}, err
+	}
+	appointments, err := sa.queryAppointmentsDTO(ctx, appointmentDTOQuery+filter.where()+order+" LIMIT ? OFFSET ?", append(filter.args, q.Limit, q.Offset())...)
+	if err != nil {
+		return domain.Page[domain.AppointmentDTO]{}, err
+	}
+	return domain.NewPage(appointments, q, total), nil
+}
+
+// GetAllAppointmentsByDateTimeInterval - return a list of all appointments overlapping a datetime interval.
+func (sa *appointmentStore) GetAllAppointmentsByDateTimeInterval(ctx context.Context, startDateTime, endDateTime time.Time) ([]domain.Appointment, error) {
+	rows, err := sa.db.QueryContext(ctx, appointmentQuery+" WHERE date_and_time < ? AND end_date_and_time > ? ORDER BY date_and_time",
+		toSQLDateTime(endDateTime), toSQLDateTime(startDateTime))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var appointments []domain.Appointment
 	for rows.Next() {
-		if err := rows.Scan(
-			&appointment.Id,
-			&appointment.Description,
-			&appointment.DateAndTime,
-			&appointment.DentistCRO,
-			&appointment.PatientRG,
-			&appointment.Dentist.Id,
-			&appointment.Dentist.LastName,
-			&appointment.Dentist.Name,
-			&appointment.Dentist.CRO,
-			&appointment.Patient.Id,
-			&appointment.Patient.LastName,
-			&appointment.Patient.Name,
-			&appointment.Patient.RG,
-			&appointment.Patient.CreatedAt); err != nil {
+		appointment, err := scanAppointment(rows)
+		if err != nil {
 			return appointments, err
 		}
 		appointments = append(appointments, appointment)
 	}
-	return appointments, nil
+	return appointments, rows.Err()
 }
 
-// GetAllAppointmentsByDateTimeInterval - return a list of all appointments during a datetime interval. Used mostly to validate if a date is available.
-func (sa *appointmentStore) GetAllAppointmentsByDateTimeInterval(startDateTime, endDateTime string) ([]domain.Appointment, error) {
-	var appointment domain.Appointment
-	var appointments []domain.Appointment
-	rows, err := sa.db.Query("SELECT * FROM appointments WHERE date_and_time BETWEEN ? AND ?", startDateTime, endDateTime)
+// queryAppointmentsDTO - run a query built on top of appointmentDTOQuery and scan every row returned.
+func (sa *appointmentStore) queryAppointmentsDTO(ctx context.Context, query string, args ...interface{}) ([]domain.AppointmentDTO, error) {
+	rows, err := sa.db.QueryContext(ctx, query, args...)
 	if err != nil {
-		log.Fatalln(err)
+		return nil, err
 	}
+	defer rows.Close()
+
+	var appointments []domain.AppointmentDTO
 	for rows.Next() {
-		if err := rows.Scan(
-			&appointment.Id,
-			&appointment.Description,
-			&appointment.DateAndTime,
-			&appointment.DentistCRO,
-			&appointment.PatientRG); err != nil {
+		appointment, err := scanAppointmentDTO(rows)
+		if err != nil {
 			return appointments, err
 		}
 		appointments = append(appointments, appointment)
 	}
-	return appointments, nil
+	return appointments, rows.Err()
+}
+
+// commitWithEvent - load the appointment written at the transaction, record the event of the change at the outbox and
+// commit, so the event is published if and only if the change is persisted.
+func commitWithEvent(ctx context.Context, tx *sql.Tx, entityID int, eventType func(domain.AppointmentDTO) domain.EventType) (domain.AppointmentDTO, error) {
+	appointment, err := scanAppointmentDTO(tx.QueryRowContext(ctx, appointmentDTOQuery+" WHERE a.id = ?", entityID))
+	if err != nil {
+		return domain.AppointmentDTO{}, err
+	}
+	if err := recordEvent(ctx, tx, eventType(appointment), appointment); err != nil {
+		return domain.AppointmentDTO{}, err
+	}
+	if err := tx.Commit(); err != nil {
+		return domain.AppointmentDTO{}, err
+	}
+	return appointment, nil
+}
+
+// lockAndCheckConflicts - lock the dentist and patient rows of the appointment until the transaction ends and check
+// the interval provided against the dentist schedule, the clinic closures and the other appointments of any of them,
+// cancelled and no-show appointments are ignored. The schedule changes lock the dentist row too and the closures are
+// read with a shared lock, so none of them changes between the checks and the commit.
+func lockAndCheckConflicts(ctx context.Context, tx *sql.Tx, appointment domain.Appointment, start, end time.Time) error {
+	var dentistID, patientID int
+	if err := tx.QueryRowContext(ctx, "SELECT id FROM dentists WHERE cro = ? FOR UPDATE", appointment.DentistCRO).Scan(&dentistID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return errors.New("dentist provided does not exist")
+		}
+		return err
+	}
+	if err := tx.QueryRowContext(ctx, "SELECT id FROM patients WHERE rg = ? FOR UPDATE", appointment.PatientRG).Scan(&patientID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return errors.New("patient provided does not exist")
+		}
+		return err
+	}
+
+	schedule, err := loadSchedule(ctx, tx, dentistID)
+	if err != nil {
+		return err
+	}
+	if !schedule.IsAvailable(start, end) {
+		return ErrOutsideWorkingHours
+	}
+	closures, err := queryClosures(ctx, tx, closureQuery+" WHERE start_date_and_time < ? AND end_date_and_time > ? LOCK IN SHARE MODE",
+		toSQLDateTime(end), toSQLDateTime(start))
+	if err != nil {
+		return err
+	}
+	if len(closures) > 0 {
+		return ErrClinicClosed
+	}
+
+	var conflicts int
+	err = tx.QueryRowContext(ctx, "SELECT COUNT(*) FROM appointments WHERE id <> ? AND (dentist_cro = ? OR patient_rg = ?) AND date_and_time < ? AND end_date_and_time > ? AND status NOT IN (?,?)",
+		appointment.Id,
+		appointment.DentistCRO,
+		appointment.PatientRG,
+		toSQLDateTime(end),
+		toSQLDateTime(start),
+		domain.StatusCancelled,
+		domain.StatusNoShow).Scan(&conflicts)
+	if err != nil {
+		return err
+	}
+	if conflicts > 0 {
+		return ErrScheduleConflict
+	}
+	return nil
+}
+
+// checkOpen - verify if the stored appointment can still be changed, completed, cancelled and no-show ones can't.
+func checkOpen(stored domain.Appointment) error {
+	if !stored.Status.IsOpen() {
+		return fmt.Errorf("%w: an appointment %s can't be changed", domain.ErrInvalidStatusTransition, stored.Status)
+	}
+	return nil
+}
+
+// toSQLDateTime - format a time as a DATETIME literal keeping its wall clock, so the driver doesn't shift it to UTC.
+func toSQLDateTime(t time.Time) string {
+	return t.Format("2006-01-02 15:04:05")
+}
+
+func scanAppointment(row scanner) (domain.Appointment, error) {
+	var appointment domain.Appointment
+	err := row.Scan(
+		&appointment.Id,
+		&appointment.Description,
+		&appointment.DateAndTime,
+		&appointment.DentistCRO,
+		&appointment.PatientRG,
+		&appointment.Procedure,
+		&appointment.Duration,
+		&appointment.EndDateAndTime,
+		&appointment.Status,
+		&appointment.ConfirmedAt,
+		&appointment.CheckedInAt,
+		&appointment.CompletedAt,
+		&appointment.CancelledAt,
+		&appointment.CancellationReason,
+		&appointment.NoShowAt)
+	return appointment, err
+}
+
+func scanAppointmentDTO(row scanner) (domain.AppointmentDTO, error) {
+	var appointment domain.AppointmentDTO
+	var billing domain.Billing
+	err := row.Scan(
+		&appointment.Id,
+		&appointment.Description,
+		&appointment.DateAndTime,
+		&appointment.DentistCRO,
+		&appointment.PatientRG,
+		&appointment.Procedure,
+		&appointment.Duration,
+		&appointment.EndDateAndTime,
+		&appointment.Status,
+		&appointment.ConfirmedAt,
+		&appointment.CheckedInAt,
+		&appointment.CompletedAt,
+		&appointment.CancelledAt,
+		&appointment.CancellationReason,
+		&appointment.NoShowAt,
+		&appointment.Dentist.Id,
+		&appointment.Dentist.LastName,
+		&appointment.Dentist.Name,
+		&appointment.Dentist.CRO,
+		&appointment.Patient.Id,
+		&appointment.Patient.LastName,
+		&appointment.Patient.Name,
+		&appointment.Patient.RG,
+		&appointment.Patient.CreatedAt,
+		&billing.InvoiceID,
+		&billing.PaymentStatus,
+		&billing.UpdatedAt)
+	if billing.InvoiceID != "" {
+		appointment.Billing = &billing
+	}
+	return appointment, err
 }
@@ -0,0 +1,60 @@
+package store
+
+import (
+	"context"
+	"github.com/ronilsonalves/GoLang-in-a-spring-cloud-architecture/scheduling-service/internal/domain"
+	"time"
+)
+
+// NewMemoryClosure - Initialize ClosureStore interface kept in memory, used for tests and local development
+func NewMemoryClosure() ClosureStore {
+	return &closureMemoryStore{
+		memoryStore: newMemoryStore(func(c *domain.ClinicClosure, id int) { c.Id = id }),
+	}
+}
+
+type closureMemoryStore struct {
+	*memoryStore[domain.ClinicClosure]
+}
+
+// GetAll - Return all clinic closures ordered by start.
+func (s *closureMemoryStore) GetAll(ctx context.Context) ([]domain.ClinicClosure, error) {
+	closures, err := s.memoryStore.GetAll(ctx)
+	sortByDateAndTime(closures, func(c domain.ClinicClosure) string { return c.DateAndTime })
+	return closures, err
+}
+
+// Save - Insert a new clinic closure
+func (s *closureMemoryStore) Save(ctx context.Context, closure domain.ClinicClosure) (domain.ClinicClosure, error) {
+	if _, err := closure.Interval(); err != nil {
+		return domain.ClinicClosure{}, err
+	}
+	return s.memoryStore.Save(ctx, closure)
+}
+
+// Update - update a clinic closure by ID
+func (s *closureMemoryStore) Update(ctx context.Context, entityID int, closure domain.ClinicClosure) (domain.ClinicClosure, error) {
+	if _, err := closure.Interval(); err != nil {
+		return domain.ClinicClosure{}, err
+	}
+	return s.memoryStore.Update(ctx, entityID, closure)
+}
+
+// GetAllByDateTimeInterval - return a list of all clinic closures overlapping a datetime interval.
+func (s *closureMemoryStore) GetAllByDateTimeInterval(ctx context.Context, startDateTime, endDateTime time.Time) ([]domain.ClinicClosure, error) {
+	all, err := s.GetAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var closures []domain.ClinicClosure
+	for _, closure := range all {
+		interval, err := closure.Interval()
+		if err != nil {
+			return nil, err
+		}
+		if domain.Overlaps(interval.Start, interval.End, startDateTime, endDateTime) {
+			closures = append(closures, closure)
+		}
+	}
+	return closures, nil
+}
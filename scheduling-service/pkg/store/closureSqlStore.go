@@ -0,0 +1,119 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"github.com/ronilsonalves/GoLang-in-a-spring-cloud-architecture/scheduling-service/internal/domain"
+	"time"
+)
+
+const closureQuery = "SELECT id, DATE_FORMAT(start_date_and_time,'%d/%m/%Y %H:%i'), DATE_FORMAT(end_date_and_time,'%d/%m/%Y %H:%i'), reason FROM clinic_closures"
+
+// ClosureStore - Set the contract for the store of clinic closures that is made of a composition of Store interface.
+type ClosureStore interface {
+	Store[domain.ClinicClosure]
+	GetAllByDateTimeInterval(ctx context.Context, startDateTime, endDateTime time.Time) ([]domain.ClinicClosure, error)
+}
+
+// NewSQLClosure - Initialize ClosureStore interface backed by the provided database
+func NewSQLClosure(db *sql.DB) ClosureStore {
+	return &closureStore{db: db}
+}
+
+type closureStore struct {
+	db *sql.DB
+}
+
+// GetAll - Return all clinic closures ordered by start.
+func (s *closureStore) GetAll(ctx context.Context) ([]domain.ClinicClosure, error) {
+	return queryClosures(ctx, s.db, closureQuery+" ORDER BY start_date_and_time")
+}
+
+// GetByID - Return a clinic closure by ID
+func (s *closureStore) GetByID(ctx context.Context, entityID int) (domain.ClinicClosure, error) {
+	row := s.db.QueryRowContext(ctx, closureQuery+" WHERE id = ?", entityID)
+	closure, err := scanClosure(row)
+	if errors.Is(err, sql.ErrNoRows) {
+		return domain.ClinicClosure{}, ErrNotFound
+	}
+	return closure, err
+}
+
+// Save - Insert a new clinic closure
+func (s *closureStore) Save(ctx context.Context, closure domain.ClinicClosure) (domain.ClinicClosure, error) {
+	interval, err := closure.Interval()
+	if err != nil {
+		return domain.ClinicClosure{}, err
+	}
+	result, err := s.db.ExecContext(ctx, "INSERT INTO clinic_closures(start_date_and_time, end_date_and_time, reason) VALUES (?,?,?)",
+		toSQLDateTime(interval.Start),
+		toSQLDateTime(interval.End),
+		closure.Reason)
+	if err != nil {
+		return domain.ClinicClosure{}, err
+	}
+	lastInsertedID, err := result.LastInsertId()
+	if err != nil {
+		return domain.ClinicClosure{}, err
+	}
+	closure.Id = int(lastInsertedID)
+	return closure, nil
+}
+
+// Update - update a clinic closure by ID
+func (s *closureStore) Update(ctx context.Context, entityID int, closure domain.ClinicClosure) (domain.ClinicClosure, error) {
+	interval, err := closure.Interval()
+	if err != nil {
+		return domain.ClinicClosure{}, err
+	}
+	_, err = s.db.ExecContext(ctx, "UPDATE clinic_closures SET start_date_and_time = ?, end_date_and_time = ?, reason = ? WHERE id = ?",
+		toSQLDateTime(interval.Start),
+		toSQLDateTime(interval.End),
+		closure.Reason,
+		entityID)
+	if err != nil {
+		return domain.ClinicClosure{}, err
+	}
+	closure.Id = entityID
+	return closure, nil
+}
+
+// Delete - exclude a clinic closure by ID
+func (s *closureStore) Delete(ctx context.Context, entityID int) error {
+	return deleteByID(ctx, s.db, "clinic_closures", entityID)
+}
+
+// GetAllByDateTimeInterval - return a list of all clinic closures overlapping a datetime interval.
+func (s *closureStore) GetAllByDateTimeInterval(ctx context.Context, startDateTime, endDateTime time.Time) ([]domain.ClinicClosure, error) {
+	return queryClosures(ctx, s.db, closureQuery+" WHERE start_date_and_time < ? AND end_date_and_time > ? ORDER BY start_date_and_time",
+		toSQLDateTime(endDateTime), toSQLDateTime(startDateTime))
+}
+
+func queryClosures(ctx context.Context, db querier, query string, args ...interface{}) ([]domain.ClinicClosure, error) {
+	rows, err := db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var closures []domain.ClinicClosure
+	for rows.Next() {
+		closure, err := scanClosure(rows)
+		if err != nil {
+			return closures, err
+		}
+		closures = append(closures, closure)
+	}
+	return closures, rows.Err()
+}
+
+func scanClosure(row scanner) (domain.ClinicClosure, error) {
+	var closure domain.ClinicClosure
+	err := row.Scan(
+		&closure.Id,
+		&closure.DateAndTime,
+		&closure.EndDateAndTime,
+		&closure.Reason)
+	return closure, err
+}
@@ -0,0 +1,75 @@
+package store
+
+import (
+	"context"
+	"github.com/ronilsonalves/GoLang-in-a-spring-cloud-architecture/scheduling-service/internal/domain"
+	"sync"
+)
+
+// NewMemoryDeadLetter - Initialize DeadLetterStore interface kept in memory, used for tests and local development
+func NewMemoryDeadLetter() DeadLetterStore {
+	return &deadLetterMemoryStore{}
+}
+
+type deadLetterMemoryStore struct {
+	mu      sync.Mutex
+	lastID  int64
+	letters []domain.DeadLetter
+}
+
+// deadLetterSorts - how each sort field of the dead letters list orders two dead letters.
+var deadLetterSorts = map[string]func(a, b domain.DeadLetter) bool{
+	"id":         func(a, b domain.DeadLetter) bool { return a.Id < b.Id },
+	"queue":      func(a, b domain.DeadLetter) bool { return a.Queue < b.Queue },
+	"routingKey": func(a, b domain.DeadLetter) bool { return a.RoutingKey < b.RoutingKey },
+}
+
+// Add - Insert a message taken from the dead letter queue
+func (s *deadLetterMemoryStore) Add(ctx context.Context, letter domain.DeadLetter) (domain.DeadLetter, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.lastID++
+	letter.Id = s.lastID
+	s.letters = append(s.letters, letter)
+	return letter, nil
+}
+
+// GetByID - Return a dead letter by ID
+func (s *deadLetterMemoryStore) GetByID(ctx context.Context, id int64) (domain.DeadLetter, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, letter := range s.letters {
+		if letter.Id == id {
+			return letter, nil
+		}
+	}
+	return domain.DeadLetter{}, ErrNotFound
+}
+
+// List - Return a page of the dead letters matching the filter, the oldest first unless requested otherwise.
+func (s *deadLetterMemoryStore) List(ctx context.Context, q domain.ListQuery[domain.DeadLetterFilter]) (domain.Page[domain.DeadLetter], error) {
+	s.mu.Lock()
+	letters := append([]domain.DeadLetter{}, s.letters...)
+	s.mu.Unlock()
+
+	return paginate(letters, q, func(letter domain.DeadLetter) bool {
+		return (q.Filter.Queue == "" || letter.Queue == q.Filter.Queue) &&
+			(q.Filter.RoutingKey == "" || letter.RoutingKey == q.Filter.RoutingKey)
+	}, deadLetterSorts, "id")
+}
+
+// Delete - exclude a dead letter by ID, once replayed or discarded.
+func (s *deadLetterMemoryStore) Delete(ctx context.Context, id int64) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for i, letter := range s.letters {
+		if letter.Id == id {
+			s.letters = append(s.letters[:i], s.letters[i+1:]...)
+			return nil
+		}
+	}
+	return ErrNotFound
+}
@@ -0,0 +1,162 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"github.com/ronilsonalves/GoLang-in-a-spring-cloud-architecture/scheduling-service/internal/domain"
+	"time"
+)
+
+const deadLetterQuery = "SELECT id, message_id, queue, exchange, routing_key, reason, retry_count, content_type, headers, body, DATE_FORMAT(dead_lettered_at,'%d/%m/%Y %H:%i:%s') FROM dead_letters"
+
+// deadLetteredAtLayout - the format the time a message was dead-lettered is kept at, seconds included.
+const deadLetteredAtLayout = "02/01/2006 15:04:05"
+
+// maxDeadLetterReasonLength - the length of the reason column, longer reasons are truncated.
+const maxDeadLetterReasonLength = 500
+
+// deadLetterSortColumns - the column each sort field of the dead letters list maps to.
+var deadLetterSortColumns = map[string]string{
+	"id":         "id",
+	"queue":      "queue",
+	"routingKey": "routing_key",
+}
+
+// DeadLetterStore - Set the contract for the store of the messages parked at the dead letter queue.
+type DeadLetterStore interface {
+	Lister[domain.DeadLetter, domain.DeadLetterFilter]
+	Add(ctx context.Context, letter domain.DeadLetter) (domain.DeadLetter, error)
+	GetByID(ctx context.Context, id int64) (domain.DeadLetter, error)
+	Delete(ctx context.Context, id int64) error
+}
+
+// NewSQLDeadLetter - Initialize DeadLetterStore interface backed by the provided database
+func NewSQLDeadLetter(db *sql.DB) DeadLetterStore {
+	return &deadLetterStore{db: db}
+}
+
+type deadLetterStore struct {
+	db *sql.DB
+}
+
+// Add - Insert a message taken from the dead letter queue
+func (s *deadLetterStore) Add(ctx context.Context, letter domain.DeadLetter) (domain.DeadLetter, error) {
+	headers, err := json.Marshal(letter.Headers)
+	if err != nil {
+		return domain.DeadLetter{}, err
+	}
+	deadLetteredAt, err := time.ParseInLocation(deadLetteredAtLayout, letter.DeadLetteredAt, time.Local)
+	if err != nil {
+		return domain.DeadLetter{}, errors.New("failed to convert dead letter dead_lettered_at field")
+	}
+	reason := letter.Reason
+	if len(reason) > maxDeadLetterReasonLength {
+		reason = reason[:maxDeadLetterReasonLength]
+	}
+	result, err := s.db.ExecContext(ctx, "INSERT INTO dead_letters(message_id, queue, exchange, routing_key, reason, retry_count, content_type, headers, body, dead_lettered_at) VALUES (?,?,?,?,?,?,?,?,?,?)",
+		letter.MessageID,
+		letter.Queue,
+		letter.Exchange,
+		letter.RoutingKey,
+		reason,
+		letter.RetryCount,
+		letter.ContentType,
+		string(headers),
+		letter.Body,
+		toSQLDateTime(deadLetteredAt))
+	if err != nil {
+		return domain.DeadLetter{}, err
+	}
+	lastInsertedID, err := result.LastInsertId()
+	if err != nil {
+		return domain.DeadLetter{}, err
+	}
+	letter.Id = lastInsertedID
+	return letter, nil
+}
+
+// GetByID - Return a dead letter by ID
+func (s *deadLetterStore) GetByID(ctx context.Context, id int64) (domain.DeadLetter, error) {
+	letter, err := scanDeadLetter(s.db.QueryRowContext(ctx, deadLetterQuery+" WHERE id = ?", id))
+	if errors.Is(err, sql.ErrNoRows) {
+		return domain.DeadLetter{}, ErrNotFound
+	}
+	return letter, err
+}
+
+// List - Return a page of the dead letters matching the filter, the oldest first unless requested otherwise.
+func (s *deadLetterStore) List(ctx context.Context, q domain.ListQuery[domain.DeadLetterFilter]) (domain.Page[domain.DeadLetter], error) {
+	order, err := orderBy(q, deadLetterSortColumns, "id", "id")
+	if err != nil {
+		return domain.Page[domain.DeadLetter]{}, err
+	}
+	var filter sqlFilter
+	if q.Filter.Queue != "" {
+		filter.add("queue = ?", q.Filter.Queue)
+	}
+	if q.Filter.RoutingKey != "" {
+		filter.add("routing_key = ?", q.Filter.RoutingKey)
+	}
+
+	var total int
+	if err := s.db.QueryRowContext(ctx, "SELECT COUNT(*) FROM dead_letters"+filter.where(), filter.args...).Scan(&total); err != nil {
+		return domain.Page[domain.DeadLetter]{}, err
+	}
+	rows, err := s.db.QueryContext(ctx, deadLetterQuery+filter.where()+order+" LIMIT ? OFFSET ?", append(filter.args, q.Limit, q.Offset())...)
+	if err != nil {
+		return domain.Page[domain.DeadLetter]{}, err
+	}
+	defer rows.Close()
+
+	var letters []domain.DeadLetter
+	for rows.Next() {
+		letter, err := scanDeadLetter(rows)
+		if err != nil {
+			return domain.Page[domain.DeadLetter]{}, err
+		}
+		letters = append(letters, letter)
+	}
+	if err := rows.Err(); err != nil {
+		return domain.Page[domain.DeadLetter]{}, err
+	}
+	return domain.NewPage(letters, q, total), nil
+}
+
+// Delete - exclude a dead letter by ID, once replayed or discarded.
+func (s *deadLetterStore) Delete(ctx context.Context, id int64) error {
+	result, err := s.db.ExecContext(ctx, "DELETE FROM dead_letters WHERE id = ?", id)
+	if err != nil {
+		return err
+	}
+	count, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if count == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+func scanDeadLetter(row scanner) (domain.DeadLetter, error) {
+	var letter domain.DeadLetter
+	var headers string
+	err := row.Scan(
+		&letter.Id,
+		&letter.MessageID,
+		&letter.Queue,
+		&letter.Exchange,
+		&letter.RoutingKey,
+		&letter.Reason,
+		&letter.RetryCount,
+		&letter.ContentType,
+		&headers,
+		&letter.Body,
+		&letter.DeadLetteredAt)
+	if err != nil {
+		return letter, err
+	}
+	return letter, json.Unmarshal([]byte(headers), &letter.Headers)
+}
@@ -0,0 +1,161 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"github.com/ronilsonalves/GoLang-in-a-spring-cloud-architecture/scheduling-service/internal/domain"
+)
+
+const dentistQuery = "SELECT id, last_name, name, cro FROM dentists"
+
+// dentistSortColumns - the column each sort field of the dentists list maps to.
+var dentistSortColumns = map[string]string{
+	"id":       "id",
+	"name":     "name",
+	"lastName": "last_name",
+	"cro":      "cro",
+}
+
+// DentistStore - Set the contract for the store of dentists that is made of a composition of Store interface.
+type DentistStore interface {
+	Store[domain.Dentist]
+	Lister[domain.Dentist, domain.DentistFilter]
+}
+
+// NewSQLDentist - Initialize DentistStore interface backed by the provided database
+func NewSQLDentist(db *sql.DB) DentistStore {
+	return &dentistStore{db: db}
+}
+
+type dentistStore struct {
+	db *sql.DB
+}
+
+// GetAll - Return all dentists.
+func (s *dentistStore) GetAll(ctx context.Context) ([]domain.Dentist, error) {
+	return s.queryDentists(ctx, dentistQuery)
+}
+
+// List - Return a page of the dentists matching the filter, sorted by id unless requested otherwise.
+func (s *dentistStore) List(ctx context.Context, q domain.ListQuery[domain.DentistFilter]) (domain.Page[domain.Dentist], error) {
+	order, err := orderBy(q, dentistSortColumns, "id", "id")
+	if err != nil {
+		return domain.Page[domain.Dentist]{}, err
+	}
+	var filter sqlFilter
+	if q.Filter.Name != "" {
+		filter.add("(name LIKE ? OR last_name LIKE ?)", likePrefix(q.Filter.Name), likePrefix(q.Filter.Name))
+	}
+	if q.Filter.CRO != "" {
+		filter.add("cro = ?", q.Filter.CRO)
+	}
+
+	var total int
+	if err := s.db.QueryRowContext(ctx, "SELECT COUNT(*) FROM dentists"+filter.where(), filter.args...).Scan(&total); err != nil {
+		return domain.Page[domain.Dentist]{}, err
+	}
+	dentists, err := s.queryDentists(ctx, dentistQuery+filter.where()+order+" LIMIT ? OFFSET ?", append(filter.args, q.Limit, q.Offset())...)
+	if err != nil {
+		return domain.Page[domain.Dentist]{}, err
+	}
+	return domain.NewPage(dentists, q, total), nil
+}
+
+func (s *dentistStore) queryDentists(ctx context.Context, query string, args ...interface{}) ([]domain.Dentist, error) {
+	rows, err := s.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var dentists []domain.Dentist
+	for rows.Next() {
+		dentist, err := scanDentist(rows)
+		if err != nil {
+			return dentists, err
+		}
+		dentists = append(dentists, dentist)
+	}
+	return dentists, rows.Err()
+}
+
+// GetByID - Return a dentist by ID
+func (s *dentistStore) GetByID(ctx context.Context, entityID int) (domain.Dentist, error) {
+	row := s.db.QueryRowContext(ctx, dentistQuery+" WHERE id = ?", entityID)
+	dentist, err := scanDentist(row)
+	if errors.Is(err, sql.ErrNoRows) {
+		return domain.Dentist{}, ErrNotFound
+	}
+	return dentist, err
+}
+
+// Save - Insert a new dentist, recording the dentist.created event in the same transaction.
+func (s *dentistStore) Save(ctx context.Context, dentist domain.Dentist) (domain.Dentist, error) {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return domain.Dentist{}, err
+	}
+	defer tx.Rollback()
+
+	result, err := tx.ExecContext(ctx, "INSERT INTO dentists(last_name, name, cro) VALUES (?,?,?)",
+		dentist.LastName,
+		dentist.Name,
+		dentist.CRO)
+	if err != nil {
+		return domain.Dentist{}, err
+	}
+	lastInsertedID, err := result.LastInsertId()
+	if err != nil {
+		return domain.Dentist{}, err
+	}
+	dentist.Id = int(lastInsertedID)
+	if err := recordEvent(ctx, tx, domain.EventDentistCreated, dentist); err != nil {
+		return domain.Dentist{}, err
+	}
+	if err := tx.Commit(); err != nil {
+		return domain.Dentist{}, err
+	}
+	return dentist, nil
+}
+
+// Update - update a dentist by ID, recording the dentist.updated event in the same transaction.
+func (s *dentistStore) Update(ctx context.Context, entityID int, dentist domain.Dentist) (domain.Dentist, error) {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return domain.Dentist{}, err
+	}
+	defer tx.Rollback()
+
+	_, err = tx.ExecContext(ctx, "UPDATE dentists SET last_name = ?, name = ?, cro = ? WHERE id = ?",
+		dentist.LastName,
+		dentist.Name,
+		dentist.CRO,
+		entityID)
+	if err != nil {
+		return domain.Dentist{}, err
+	}
+	dentist.Id = entityID
+	if err := recordEvent(ctx, tx, domain.EventDentistUpdated, dentist); err != nil {
+		return domain.Dentist{}, err
+	}
+	if err := tx.Commit(); err != nil {
+		return domain.Dentist{}, err
+	}
+	return dentist, nil
+}
+
+// Delete - exclude a dentist by ID, recording the dentist.deleted event in the same transaction.
+func (s *dentistStore) Delete(ctx context.Context, entityID int) error {
+	return deleteWithEvent(ctx, s.db, "dentists", dentistQuery+" WHERE id = ?", scanDentist, entityID, domain.EventDentistDeleted)
+}
+
+func scanDentist(row scanner) (domain.Dentist, error) {
+	var dentist domain.Dentist
+	err := row.Scan(
+		&dentist.Id,
+		&dentist.LastName,
+		&dentist.Name,
+		&dentist.CRO)
+	return dentist, err
+}
@@ -0,0 +1,276 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/ronilsonalves/GoLang-in-a-spring-cloud-architecture/scheduling-service/internal/domain"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// NewMemoryDentist - Initialize DentistStore interface kept in memory, used for tests and local development. The events
+// of the changes are added to the outbox provided.
+func NewMemoryDentist(outbox OutboxStore) DentistStore {
+	return &dentistMemoryStore{
+		memoryStore: newMemoryStore(func(d *domain.Dentist, id int) { d.Id = id }),
+		outbox:      outbox,
+	}
+}
+
+// NewMemoryPatient - Initialize PatientStore interface kept in memory, used for tests and local development. The events
+// of the changes are added to the outbox provided.
+func NewMemoryPatient(outbox OutboxStore) PatientStore {
+	return &patientMemoryStore{
+		memoryStore: newMemoryStore(func(p *domain.Patient, id int) { p.Id = id }),
+		outbox:      outbox,
+	}
+}
+
+// memoryStore - a generic, concurrency safe Store that keeps its rows in a map indexed by ID.
+type memoryStore[T any] struct {
+	mu     sync.RWMutex
+	rows   map[int]T
+	lastID int
+	setID  func(entity *T, id int)
+}
+
+func newMemoryStore[T any](setID func(entity *T, id int)) *memoryStore[T] {
+	return &memoryStore[T]{
+		rows:  make(map[int]T),
+		setID: setID,
+	}
+}
+
+// GetAll - Return all rows ordered by ID.
+func (s *memoryStore[T]) GetAll(ctx context.Context) ([]T, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	ids := make([]int, 0, len(s.rows))
+	for id := range s.rows {
+		ids = append(ids, id)
+	}
+	sort.Ints(ids)
+
+	var entities []T
+	for _, id := range ids {
+		entities = append(entities, s.rows[id])
+	}
+	return entities, nil
+}
+
+// GetByID - Return a row by ID
+func (s *memoryStore[T]) GetByID(ctx context.Context, entityID int) (T, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	entity, ok := s.rows[entityID]
+	if !ok {
+		return entity, ErrNotFound
+	}
+	return entity, nil
+}
+
+// Save - Insert a new row, assigning the next ID available
+func (s *memoryStore[T]) Save(ctx context.Context, entity T) (T, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.lastID++
+	s.setID(&entity, s.lastID)
+	s.rows[s.lastID] = entity
+	return entity, nil
+}
+
+// Update - replace a row by ID
+func (s *memoryStore[T]) Update(ctx context.Context, entityID int, entity T) (T, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.rows[entityID]; !ok {
+		var empty T
+		return empty, ErrNotFound
+	}
+	s.setID(&entity, entityID)
+	s.rows[entityID] = entity
+	return entity, nil
+}
+
+// Delete - exclude a row by ID
+func (s *memoryStore[T]) Delete(ctx context.Context, entityID int) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.rows[entityID]; !ok {
+		return ErrNotFound
+	}
+	delete(s.rows, entityID)
+	return nil
+}
+
+// dentistMemoryStore - adds the dentists list and events to the generic memoryStore.
+type dentistMemoryStore struct {
+	*memoryStore[domain.Dentist]
+	outbox OutboxStore
+}
+
+// Save - Insert a new dentist, adding the dentist.created event to the outbox.
+func (s *dentistMemoryStore) Save(ctx context.Context, dentist domain.Dentist) (domain.Dentist, error) {
+	dentist, err := s.memoryStore.Save(ctx, dentist)
+	if err != nil {
+		return domain.Dentist{}, err
+	}
+	return dentist, addEvent(ctx, s.outbox, domain.EventDentistCreated, dentist)
+}
+
+// Update - update a dentist by ID, adding the dentist.updated event to the outbox.
+func (s *dentistMemoryStore) Update(ctx context.Context, entityID int, dentist domain.Dentist) (domain.Dentist, error) {
+	dentist, err := s.memoryStore.Update(ctx, entityID, dentist)
+	if err != nil {
+		return domain.Dentist{}, err
+	}
+	return dentist, addEvent(ctx, s.outbox, domain.EventDentistUpdated, dentist)
+}
+
+// Delete - exclude a dentist by ID, adding the dentist.deleted event to the outbox.
+func (s *dentistMemoryStore) Delete(ctx context.Context, entityID int) error {
+	dentist, err := s.memoryStore.GetByID(ctx, entityID)
+	if err != nil {
+		return err
+	}
+	if err := s.memoryStore.Delete(ctx, entityID); err != nil {
+		return err
+	}
+	return addEvent(ctx, s.outbox, domain.EventDentistDeleted, dentist)
+}
+
+// dentistSorts - how each sort field of the dentists list orders two dentists.
+var dentistSorts = map[string]func(a, b domain.Dentist) bool{
+	"id":       func(a, b domain.Dentist) bool { return a.Id < b.Id },
+	"name":     func(a, b domain.Dentist) bool { return a.Name < b.Name },
+	"lastName": func(a, b domain.Dentist) bool { return a.LastName < b.LastName },
+	"cro":      func(a, b domain.Dentist) bool { return a.CRO < b.CRO },
+}
+
+// List - Return a page of the dentists matching the filter, sorted by id unless requested otherwise.
+func (s *dentistMemoryStore) List(ctx context.Context, q domain.ListQuery[domain.DentistFilter]) (domain.Page[domain.Dentist], error) {
+	dentists, err := s.GetAll(ctx)
+	if err != nil {
+		return domain.Page[domain.Dentist]{}, err
+	}
+	return paginate(dentists, q, func(d domain.Dentist) bool {
+		return (q.Filter.Name == "" || hasPrefixFold(d.Name, q.Filter.Name) || hasPrefixFold(d.LastName, q.Filter.Name)) &&
+			(q.Filter.CRO == "" || d.CRO == q.Filter.CRO)
+	}, dentistSorts, "id")
+}
+
+// patientMemoryStore - validates and normalizes created_at the same way the SQL store does, adding the events of the
+// changes to the outbox.
+type patientMemoryStore struct {
+	*memoryStore[domain.Patient]
+	outbox OutboxStore
+}
+
+func (s *patientMemoryStore) Save(ctx context.Context, patient domain.Patient) (domain.Patient, error) {
+	createdAt, err := time.Parse("02/01/2006 15:04:05", patient.CreatedAt)
+	if err != nil {
+		return domain.Patient{}, errors.New("failed to convert patient created_at field")
+	}
+	patient.CreatedAt = createdAt.Format("02/01/2006 15:04")
+	patient, err = s.memoryStore.Save(ctx, patient)
+	if err != nil {
+		return domain.Patient{}, err
+	}
+	return patient, addEvent(ctx, s.outbox, domain.EventPatientCreated, patient)
+}
+
+func (s *patientMemoryStore) Update(ctx context.Context, entityID int, patient domain.Patient) (domain.Patient, error) {
+	if _, err := time.Parse("02/01/2006 15:04", patient.CreatedAt); err != nil {
+		return domain.Patient{}, errors.New("failed to convert patient created_at field: " + patient.CreatedAt)
+	}
+	patient, err := s.memoryStore.Update(ctx, entityID, patient)
+	if err != nil {
+		return domain.Patient{}, err
+	}
+	return patient, addEvent(ctx, s.outbox, domain.EventPatientUpdated, patient)
+}
+
+// Delete - exclude a patient by ID, adding the patient.deleted event to the outbox.
+func (s *patientMemoryStore) Delete(ctx context.Context, entityID int) error {
+	patient, err := s.memoryStore.GetByID(ctx, entityID)
+	if err != nil {
+		return err
+	}
+	if err := s.memoryStore.Delete(ctx, entityID); err != nil {
+		return err
+	}
+	return addEvent(ctx, s.outbox, domain.EventPatientDeleted, patient)
+}
+
+// patientSorts - how each sort field of the patients list orders two patients.
+var patientSorts = map[string]func(a, b domain.Patient) bool{
+	"id":       func(a, b domain.Patient) bool { return a.Id < b.Id },
+	"name":     func(a, b domain.Patient) bool { return a.Name < b.Name },
+	"lastName": func(a, b domain.Patient) bool { return a.LastName < b.LastName },
+	"rg":       func(a, b domain.Patient) bool { return a.RG < b.RG },
+	"createdAt": func(a, b domain.Patient) bool {
+		aCreatedAt, _ := time.Parse("02/01/2006 15:04", a.CreatedAt)
+		bCreatedAt, _ := time.Parse("02/01/2006 15:04", b.CreatedAt)
+		return aCreatedAt.Before(bCreatedAt)
+	},
+}
+
+// List - Return a page of the patients matching the filter, sorted by id unless requested otherwise.
+func (s *patientMemoryStore) List(ctx context.Context, q domain.ListQuery[domain.PatientFilter]) (domain.Page[domain.Patient], error) {
+	patients, err := s.GetAll(ctx)
+	if err != nil {
+		return domain.Page[domain.Patient]{}, err
+	}
+	return paginate(patients, q, func(p domain.Patient) bool {
+		return (q.Filter.Name == "" || hasPrefixFold(p.Name, q.Filter.Name) || hasPrefixFold(p.LastName, q.Filter.Name)) &&
+			(q.Filter.RG == "" || p.RG == q.Filter.RG)
+	}, patientSorts, "id")
+}
+
+// paginate - filter, sort and slice rows ordered by ID as the SQL stores do with WHERE, ORDER BY and LIMIT, ties keep
+// the ID order in the direction requested.
+func paginate[T, F any](rows []T, q domain.ListQuery[F], match func(T) bool, sorts map[string]func(a, b T) bool, defaultField string) (domain.Page[T], error) {
+	field, descending := q.SortBy(defaultField)
+	less, ok := sorts[field]
+	if !ok {
+		return domain.Page[T]{}, fmt.Errorf("%w: %s", domain.ErrInvalidSort, field)
+	}
+
+	var matched []T
+	for _, row := range rows {
+		if match(row) {
+			matched = append(matched, row)
+		}
+	}
+	if descending {
+		for i, j := 0, len(matched)-1; i < j; i, j = i+1, j-1 {
+			matched[i], matched[j] = matched[j], matched[i]
+		}
+		sort.SliceStable(matched, func(i, j int) bool { return less(matched[j], matched[i]) })
+	} else {
+		sort.SliceStable(matched, func(i, j int) bool { return less(matched[i], matched[j]) })
+	}
+
+	start := q.Offset()
+	if start > len(matched) {
+		start = len(matched)
+	}
+	end := start + q.Limit
+	if end > len(matched) {
+		end = len(matched)
+	}
+	return domain.NewPage(matched[start:end], q, len(matched)), nil
+}
+
+// hasPrefixFold - verify if s starts with prefix ignoring case, as the LIKE of the SQL stores does.
+func hasPrefixFold(s, prefix string) bool {
+	return len(s) >= len(prefix) && strings.EqualFold(s[:len(prefix)], prefix)
+}
@@ -0,0 +1,106 @@
+package store
+
+import (
+	"context"
+	"github.com/ronilsonalves/GoLang-in-a-spring-cloud-architecture/scheduling-service/internal/domain"
+	"github.com/ronilsonalves/GoLang-in-a-spring-cloud-architecture/scheduling-service/pkg/tracing"
+	"sync"
+	"time"
+)
+
+// NewMemoryOutbox - Initialize OutboxStore interface kept in memory, used for tests and local development
+func NewMemoryOutbox() OutboxStore {
+	return &outboxMemoryStore{}
+}
+
+type outboxMemoryStore struct {
+	mu       sync.Mutex
+	messages []outboxMemoryRow
+}
+
+type outboxMemoryRow struct {
+	message      domain.OutboxMessage
+	sent         bool
+	dead         bool
+	claimedUntil time.Time
+}
+
+// Add - Insert a new message into the outbox
+func (s *outboxMemoryStore) Add(ctx context.Context, message domain.OutboxMessage) (domain.OutboxMessage, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	message.Id = int64(len(s.messages) + 1)
+	message.AggregateKey = message.Event.AggregateKey()
+	s.messages = append(s.messages, outboxMemoryRow{message: message})
+	return message, nil
+}
+
+// ClaimPending - Claim the oldest messages neither sent nor dead for the duration provided, in the order they were
+// recorded. Messages claimed by another relay are skipped along with the newer ones of their aggregates.
+func (s *outboxMemoryStore) ClaimPending(ctx context.Context, limit int, claim time.Duration) ([]domain.OutboxMessage, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now()
+	claimed := map[string]bool{}
+	var messages []domain.OutboxMessage
+	for i := range s.messages {
+		row := &s.messages[i]
+		if len(messages) == limit {
+			break
+		}
+		if row.sent || row.dead || claimed[row.message.AggregateKey] {
+			continue
+		}
+		if row.claimedUntil.After(now) {
+			claimed[row.message.AggregateKey] = true
+			continue
+		}
+		row.claimedUntil = now.Add(claim)
+		messages = append(messages, row.message)
+	}
+	return messages, nil
+}
+
+// MarkSent - record a message as published, so it isn't published again.
+func (s *outboxMemoryStore) MarkSent(ctx context.Context, id int64) error {
+	return s.change(id, func(row *outboxMemoryRow) { row.sent = true })
+}
+
+// MarkFailed - record a failed attempt to publish a message, it stays pending and claimed, so it's retried once the
+// claim expires.
+func (s *outboxMemoryStore) MarkFailed(ctx context.Context, id int64, cause string) error {
+	return s.change(id, func(row *outboxMemoryRow) { row.message.Attempts++ })
+}
+
+// MarkDead - record the last failed attempt to publish a message, it's parked and never published again, so the
+// newer messages of its aggregate go on.
+func (s *outboxMemoryStore) MarkDead(ctx context.Context, id int64, cause string) error {
+	return s.change(id, func(row *outboxMemoryRow) {
+		row.message.Attempts++
+		row.dead = true
+	})
+}
+
+// change - apply a change to the message of the ID provided.
+func (s *outboxMemoryStore) change(id int64, apply func(row *outboxMemoryRow)) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if id < 1 || id > int64(len(s.messages)) {
+		return ErrNotFound
+	}
+	apply(&s.messages[id-1])
+	return nil
+}
+
+// addEvent - add the event of a change to the outbox provided, called by the memory stores right after the change.
+func addEvent(ctx context.Context, outbox OutboxStore, eventType domain.EventType, payload interface{}) error {
+	event, err := domain.NewEvent(eventType, payload)
+	if err != nil {
+		return err
+	}
+	_, err = outbox.Add(ctx, domain.OutboxMessage{Event: event, TraceParent: tracing.TraceParent(ctx)})
+	return err
+}
@@ -0,0 +1,172 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"github.com/ronilsonalves/GoLang-in-a-spring-cloud-architecture/scheduling-service/internal/domain"
+	"github.com/ronilsonalves/GoLang-in-a-spring-cloud-architecture/scheduling-service/pkg/tracing"
+	"time"
+)
+
+// outboxCreatedAtLayout - the format the occurrence of the outbox events is read at, seconds included.
+const outboxCreatedAtLayout = "02/01/2006 15:04:05"
+
+const outboxQuery = "SELECT o.id, o.event_id, o.event_type, o.aggregate_key, o.schema_version, o.payload, o.trace_parent, DATE_FORMAT(o.created_at,'%d/%m/%Y %H:%i:%s'), o.attempts FROM outbox o"
+
+// maxOutboxErrorLength - the length of the last_error column, longer errors are truncated.
+const maxOutboxErrorLength = 500
+
+// OutboxStore - Set the contract for the store of the messages waiting to be published. The stores of the changes
+// record their messages in the same transaction as the change, Add is for messages not tied to one.
+//
+// The relays of every instance share the outbox: ClaimPending hands each pending message to a single relay until its
+// claim expires, and never a message while an older one of the same aggregate is claimed, so the events of an entity
+// are published in order. Delivery is at least once, a message is published again when its relay stops or takes
+// longer than the claim before marking it, so consumers drop the repeated ones by the event ID.
+type OutboxStore interface {
+	Add(ctx context.Context, message domain.OutboxMessage) (domain.OutboxMessage, error)
+	ClaimPending(ctx context.Context, limit int, claim time.Duration) ([]domain.OutboxMessage, error)
+	MarkSent(ctx context.Context, id int64) error
+	MarkFailed(ctx context.Context, id int64, cause string) error
+	MarkDead(ctx context.Context, id int64, cause string) error
+}
+
+// NewSQLOutbox - Initialize OutboxStore interface backed by the provided database
+func NewSQLOutbox(db *sql.DB) OutboxStore {
+	return &outboxStore{db: db}
+}
+
+type outboxStore struct {
+	db *sql.DB
+}
+
+// Add - Insert a new message into the outbox
+func (s *outboxStore) Add(ctx context.Context, message domain.OutboxMessage) (domain.OutboxMessage, error) {
+	return insertOutbox(ctx, s.db, message)
+}
+
+// ClaimPending - Claim the oldest messages neither sent nor dead for the duration provided, in the order they were
+// recorded. Messages claimed by another relay are skipped along with the newer ones of their aggregates. The rows are
+// locked while claimed, so relays running at once claim different messages.
+func (s *outboxStore) ClaimPending(ctx context.Context, limit int, claim time.Duration) ([]domain.OutboxMessage, error) {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	now := time.Now()
+	// a message is claimable when no older message of its aggregate is claimed, whether by this relay or another one
+	rows, err := tx.QueryContext(ctx, outboxQuery+" WHERE o.sent_at IS NULL AND o.dead_at IS NULL AND (o.claimed_until IS NULL OR o.claimed_until <= ?)"+
+		" AND NOT EXISTS (SELECT 1 FROM outbox c WHERE c.aggregate_key = o.aggregate_key AND c.id < o.id AND c.sent_at IS NULL AND c.dead_at IS NULL AND c.claimed_until > ?)"+
+		" ORDER BY o.id LIMIT ? FOR UPDATE", toSQLDateTime(now), toSQLDateTime(now), limit)
+	if err != nil {
+		return nil, err
+	}
+	messages, err := scanOutbox(rows)
+	if err != nil {
+		return nil, err
+	}
+	for _, message := range messages {
+		if _, err := tx.ExecContext(ctx, "UPDATE outbox SET claimed_until = ? WHERE id = ?", toSQLDateTime(now.Add(claim)), message.Id); err != nil {
+			return nil, err
+		}
+	}
+	return messages, tx.Commit()
+}
+
+func scanOutbox(rows *sql.Rows) ([]domain.OutboxMessage, error) {
+	defer rows.Close()
+
+	var messages []domain.OutboxMessage
+	for rows.Next() {
+		var message domain.OutboxMessage
+		var occurredAt string
+		err := rows.Scan(
+			&message.Id,
+			&message.Event.ID,
+			&message.Event.Type,
+			&message.AggregateKey,
+			&message.Event.SchemaVersion,
+			&message.Event.Payload,
+			&message.TraceParent,
+			&occurredAt,
+			&message.Attempts)
+		if err != nil {
+			return messages, err
+		}
+		message.Event.OccurredAt, err = time.ParseInLocation(outboxCreatedAtLayout, occurredAt, time.Local)
+		if err != nil {
+			return messages, err
+		}
+		messages = append(messages, message)
+	}
+	return messages, rows.Err()
+}
+
+// MarkSent - record a message as published, so it isn't published again.
+func (s *outboxStore) MarkSent(ctx context.Context, id int64) error {
+	_, err := s.db.ExecContext(ctx, "UPDATE outbox SET sent_at = ? WHERE id = ?", toSQLDateTime(time.Now()), id)
+	return err
+}
+
+// MarkFailed - record a failed attempt to publish a message, it stays pending and claimed, so it's retried once the
+// claim expires.
+func (s *outboxStore) MarkFailed(ctx context.Context, id int64, cause string) error {
+	_, err := s.db.ExecContext(ctx, "UPDATE outbox SET attempts = attempts + 1, last_error = ? WHERE id = ?", truncateOutboxError(cause), id)
+	return err
+}
+
+// MarkDead - record the last failed attempt to publish a message, it's parked and never published again, so the
+// newer messages of its aggregate go on.
+func (s *outboxStore) MarkDead(ctx context.Context, id int64, cause string) error {
+	_, err := s.db.ExecContext(ctx, "UPDATE outbox SET attempts = attempts + 1, last_error = ?, dead_at = ? WHERE id = ?",
+		truncateOutboxError(cause), toSQLDateTime(time.Now()), id)
+	return err
+}
+
+// truncateOutboxError - cut the error to the length of the last_error column.
+func truncateOutboxError(cause string) string {
+	if len(cause) > maxOutboxErrorLength {
+		return cause[:maxOutboxErrorLength]
+	}
+	return cause
+}
+
+// execer - the database or the transaction a statement runs at.
+type execer interface {
+	ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error)
+}
+
+// querier - the database or the transaction a query runs at.
+type querier interface {
+	QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error)
+}
+
+// insertOutbox - record a message at the outbox, called with the transaction of the change the message tells about.
+func insertOutbox(ctx context.Context, db execer, message domain.OutboxMessage) (domain.OutboxMessage, error) {
+	message.AggregateKey = message.Event.AggregateKey()
+	result, err := db.ExecContext(ctx, "INSERT INTO outbox(event_id, event_type, aggregate_key, schema_version, payload, trace_parent, created_at) VALUES (?,?,?,?,?,?,?)",
+		message.Event.ID,
+		message.Event.Type,
+		message.AggregateKey,
+		message.Event.SchemaVersion,
+		[]byte(message.Event.Payload),
+		message.TraceParent,
+		toSQLDateTime(message.Event.OccurredAt))
+	if err != nil {
+		return domain.OutboxMessage{}, err
+	}
+	message.Id, err = result.LastInsertId()
+	return message, err
+}
+
+// recordEvent - record the event of a change at the outbox, within the transaction of the change.
+func recordEvent(ctx context.Context, tx execer, eventType domain.EventType, payload interface{}) error {
+	event, err := domain.NewEvent(eventType, payload)
+	if err != nil {
+		return err
+	}
+	_, err = insertOutbox(ctx, tx, domain.OutboxMessage{Event: event, TraceParent: tracing.TraceParent(ctx)})
+	return err
+}
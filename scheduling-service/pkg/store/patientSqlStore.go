@@ -0,0 +1,174 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"github.com/ronilsonalves/GoLang-in-a-spring-cloud-architecture/scheduling-service/internal/domain"
+	"time"
+)
+
+const patientQuery = "SELECT p.id, p.last_name, p.name, p.rg, DATE_FORMAT(p.created_at,'%d/%m/%Y %H:%i') FROM patients p"
+
+// patientSortColumns - the column each sort field of the patients list maps to.
+var patientSortColumns = map[string]string{
+	"id":        "p.id",
+	"name":      "p.name",
+	"lastName":  "p.last_name",
+	"rg":        "p.rg",
+	"createdAt": "p.created_at",
+}
+
+// PatientStore - Set the contract for the store of patients that is made of a composition of Store interface.
+type PatientStore interface {
+	Store[domain.Patient]
+	Lister[domain.Patient, domain.PatientFilter]
+}
+
+// NewSQLPatient - Initialize PatientStore interface backed by the provided database
+func NewSQLPatient(db *sql.DB) PatientStore {
+	return &patientStore{db: db}
+}
+
+type patientStore struct {
+	db *sql.DB
+}
+
+// GetAll - Return all patients.
+func (s *patientStore) GetAll(ctx context.Context) ([]domain.Patient, error) {
+	return s.queryPatients(ctx, patientQuery)
+}
+
+// List - Return a page of the patients matching the filter, sorted by id unless requested otherwise.
+func (s *patientStore) List(ctx context.Context, q domain.ListQuery[domain.PatientFilter]) (domain.Page[domain.Patient], error) {
+	order, err := orderBy(q, patientSortColumns, "id", "p.id")
+	if err != nil {
+		return domain.Page[domain.Patient]{}, err
+	}
+	var filter sqlFilter
+	if q.Filter.Name != "" {
+		filter.add("(p.name LIKE ? OR p.last_name LIKE ?)", likePrefix(q.Filter.Name), likePrefix(q.Filter.Name))
+	}
+	if q.Filter.RG != "" {
+		filter.add("p.rg = ?", q.Filter.RG)
+	}
+
+	var total int
+	if err := s.db.QueryRowContext(ctx, "SELECT COUNT(*) FROM patients p"+filter.where(), filter.args...).Scan(&total); err != nil {
+		return domain.Page[domain.Patient]{}, err
+	}
+	patients, err := s.queryPatients(ctx, patientQuery+filter.where()+order+" LIMIT ? OFFSET ?", append(filter.args, q.Limit, q.Offset())...)
+	if err != nil {
+		return domain.Page[domain.Patient]{}, err
+	}
+	return domain.NewPage(patients, q, total), nil
+}
+
+func (s *patientStore) queryPatients(ctx context.Context, query string, args ...interface{}) ([]domain.Patient, error) {
+	rows, err := s.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var patients []domain.Patient
+	for rows.Next() {
+		patient, err := scanPatient(rows)
+		if err != nil {
+			return patients, err
+		}
+		patients = append(patients, patient)
+	}
+	return patients, rows.Err()
+}
+
+// GetByID - Return a patient by ID
+func (s *patientStore) GetByID(ctx context.Context, entityID int) (domain.Patient, error) {
+	row := s.db.QueryRowContext(ctx, patientQuery+" WHERE p.id = ?", entityID)
+	patient, err := scanPatient(row)
+	if errors.Is(err, sql.ErrNoRows) {
+		return domain.Patient{}, ErrNotFound
+	}
+	return patient, err
+}
+
+// Save - Insert a new patient, recording the patient.created event in the same transaction.
+func (s *patientStore) Save(ctx context.Context, patient domain.Patient) (domain.Patient, error) {
+	patCreatedAtParsed, err := time.Parse("02/01/2006 15:04:05", patient.CreatedAt)
+	if err != nil {
+		return domain.Patient{}, errors.New("failed to convert patient created_at field")
+	}
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return domain.Patient{}, err
+	}
+	defer tx.Rollback()
+
+	result, err := tx.ExecContext(ctx, "INSERT INTO patients(last_name, name, rg, created_at) VALUES (?,?,?,?)",
+		patient.LastName,
+		patient.Name,
+		patient.RG,
+		patCreatedAtParsed)
+	if err != nil {
+		return domain.Patient{}, err
+	}
+	lastInsertedID, err := result.LastInsertId()
+	if err != nil {
+		return domain.Patient{}, err
+	}
+	patient.Id = int(lastInsertedID)
+	if err := recordEvent(ctx, tx, domain.EventPatientCreated, patient); err != nil {
+		return domain.Patient{}, err
+	}
+	if err := tx.Commit(); err != nil {
+		return domain.Patient{}, err
+	}
+	return patient, nil
+}
+
+// Update - update a patient by ID, recording the patient.updated event in the same transaction.
+func (s *patientStore) Update(ctx context.Context, entityID int, patient domain.Patient) (domain.Patient, error) {
+	paCreatedAtParsed, err := time.Parse("02/01/2006 15:04", patient.CreatedAt)
+	if err != nil {
+		return domain.Patient{}, errors.New("failed to convert patient created_at field: " + patient.CreatedAt)
+	}
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return domain.Patient{}, err
+	}
+	defer tx.Rollback()
+
+	_, err = tx.ExecContext(ctx, "UPDATE patients SET last_name = ?, name = ?, rg = ?, created_at = ? WHERE id = ?",
+		patient.LastName,
+		patient.Name,
+		patient.RG,
+		paCreatedAtParsed,
+		entityID)
+	if err != nil {
+		return domain.Patient{}, err
+	}
+	patient.Id = entityID
+	if err := recordEvent(ctx, tx, domain.EventPatientUpdated, patient); err != nil {
+		return domain.Patient{}, err
+	}
+	if err := tx.Commit(); err != nil {
+		return domain.Patient{}, err
+	}
+	return patient, nil
+}
+
+// Delete - exclude a patient by ID, recording the patient.deleted event in the same transaction.
+func (s *patientStore) Delete(ctx context.Context, entityID int) error {
+	return deleteWithEvent(ctx, s.db, "patients", patientQuery+" WHERE p.id = ?", scanPatient, entityID, domain.EventPatientDeleted)
+}
+
+func scanPatient(row scanner) (domain.Patient, error) {
+	var patient domain.Patient
+	err := row.Scan(
+		&patient.Id,
+		&patient.LastName,
+		&patient.Name,
+		&patient.RG,
+		&patient.CreatedAt)
+	return patient, err
+}
@@ -0,0 +1,140 @@
+package store
+
+import (
+	"context"
+	"github.com/ronilsonalves/GoLang-in-a-spring-cloud-architecture/scheduling-service/internal/domain"
+	"sort"
+	"sync"
+	"time"
+)
+
+// NewMemorySchedule - Initialize ScheduleStore interface kept in memory, dentists are looked up on the store provided.
+func NewMemorySchedule(dentists Store[domain.Dentist]) ScheduleStore {
+	return &scheduleMemoryStore{
+		schedules: make(map[int]domain.DentistSchedule),
+		dentists:  dentists,
+	}
+}
+
+type scheduleMemoryStore struct {
+	mu              sync.RWMutex
+	schedules       map[int]domain.DentistSchedule
+	lastExceptionID int
+	dentists        Store[domain.Dentist]
+}
+
+// GetByDentistID - Return the working hours, breaks and exceptions of a dentist
+func (s *scheduleMemoryStore) GetByDentistID(ctx context.Context, dentistID int) (domain.DentistSchedule, error) {
+	if _, err := s.dentists.GetByID(ctx, dentistID); err != nil {
+		return domain.DentistSchedule{}, err
+	}
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.load(dentistID), nil
+}
+
+// GetByDentistCRO - Return the working hours, breaks and exceptions of a dentist through your license number
+func (s *scheduleMemoryStore) GetByDentistCRO(ctx context.Context, licenseNumber string) (domain.DentistSchedule, error) {
+	dentists, err := s.dentists.GetAll(ctx)
+	if err != nil {
+		return domain.DentistSchedule{}, err
+	}
+	for _, dentist := range dentists {
+		if dentist.CRO == licenseNumber {
+			return s.GetByDentistID(ctx, dentist.Id)
+		}
+	}
+	return domain.DentistSchedule{}, ErrNotFound
+}
+
+// GetAllByDateTimeInterval - Return the schedules configured, keyed by dentist ID, with the exceptions overlapping a
+// datetime interval. Dentists without working hours, breaks or exceptions at the interval are left out.
+func (s *scheduleMemoryStore) GetAllByDateTimeInterval(ctx context.Context, startDateTime, endDateTime time.Time) (map[int]domain.DentistSchedule, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	schedules := make(map[int]domain.DentistSchedule)
+	for dentistID := range s.schedules {
+		schedule := s.load(dentistID)
+		exceptions := []domain.ScheduleException{}
+		for _, exception := range schedule.Exceptions {
+			start, err := domain.ParseDateTime(exception.DateAndTime)
+			if err != nil {
+				return nil, err
+			}
+			end, err := domain.ParseDateTime(exception.EndDateAndTime)
+			if err != nil {
+				return nil, err
+			}
+			if domain.Overlaps(start, end, startDateTime, endDateTime) {
+				exceptions = append(exceptions, exception)
+			}
+		}
+		schedule.Exceptions = exceptions
+		if len(schedule.WorkingHours) > 0 || len(schedule.Breaks) > 0 || len(schedule.Exceptions) > 0 {
+			schedules[dentistID] = schedule
+		}
+	}
+	return schedules, nil
+}
+
+// SaveWeekly - Replace the working hours and breaks of a dentist, exceptions are kept.
+func (s *scheduleMemoryStore) SaveWeekly(ctx context.Context, schedule domain.DentistSchedule) (domain.DentistSchedule, error) {
+	if _, err := s.dentists.GetByID(ctx, schedule.DentistID); err != nil {
+		return domain.DentistSchedule{}, err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	saved := s.load(schedule.DentistID)
+	saved.WorkingHours = append([]domain.WorkingHours{}, schedule.WorkingHours...)
+	saved.Breaks = append([]domain.ScheduleBreak{}, schedule.Breaks...)
+	sort.SliceStable(saved.WorkingHours, func(i, j int) bool { return saved.WorkingHours[i].Weekday < saved.WorkingHours[j].Weekday })
+	sort.SliceStable(saved.Breaks, func(i, j int) bool { return saved.Breaks[i].Weekday < saved.Breaks[j].Weekday })
+	s.schedules[schedule.DentistID] = saved
+	return s.load(schedule.DentistID), nil
+}
+
+// SaveException - Insert a new exception into a dentist schedule
+func (s *scheduleMemoryStore) SaveException(ctx context.Context, dentistID int, exception domain.ScheduleException) (domain.ScheduleException, error) {
+	if _, err := s.dentists.GetByID(ctx, dentistID); err != nil {
+		return domain.ScheduleException{}, err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.lastExceptionID++
+	exception.Id = s.lastExceptionID
+	schedule := s.load(dentistID)
+	schedule.Exceptions = append(schedule.Exceptions, exception)
+	sortByDateAndTime(schedule.Exceptions, func(e domain.ScheduleException) string { return e.DateAndTime })
+	s.schedules[dentistID] = schedule
+	return exception, nil
+}
+
+// DeleteException - exclude an exception from a dentist schedule
+func (s *scheduleMemoryStore) DeleteException(ctx context.Context, dentistID, exceptionID int) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	schedule := s.load(dentistID)
+	for i, exception := range schedule.Exceptions {
+		if exception.Id == exceptionID {
+			schedule.Exceptions = append(schedule.Exceptions[:i], schedule.Exceptions[i+1:]...)
+			s.schedules[dentistID] = schedule
+			return nil
+		}
+	}
+	return ErrNotFound
+}
+
+// load - return a copy of a dentist schedule, empty when nothing was configured.
+func (s *scheduleMemoryStore) load(dentistID int) domain.DentistSchedule {
+	schedule := s.schedules[dentistID]
+	return domain.DentistSchedule{
+		DentistID:    dentistID,
+		WorkingHours: append([]domain.WorkingHours{}, schedule.WorkingHours...),
+		Breaks:       append([]domain.ScheduleBreak{}, schedule.Breaks...),
+		Exceptions:   append([]domain.ScheduleException{}, schedule.Exceptions...),
+	}
+}
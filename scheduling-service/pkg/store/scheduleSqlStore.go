@@ -0,0 +1,273 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"github.com/ronilsonalves/GoLang-in-a-spring-cloud-architecture/scheduling-service/internal/domain"
+	"time"
+)
+
+// ScheduleStore - Set the contract for the store of dentists schedules, ErrNotFound is returned for unknown dentists.
+type ScheduleStore interface {
+	GetByDentistID(ctx context.Context, dentistID int) (domain.DentistSchedule, error)
+	GetByDentistCRO(ctx context.Context, licenseNumber string) (domain.DentistSchedule, error)
+	GetAllByDateTimeInterval(ctx context.Context, startDateTime, endDateTime time.Time) (map[int]domain.DentistSchedule, error)
+	SaveWeekly(ctx context.Context, schedule domain.DentistSchedule) (domain.DentistSchedule, error)
+	SaveException(ctx context.Context, dentistID int, exception domain.ScheduleException) (domain.ScheduleException, error)
+	DeleteException(ctx context.Context, dentistID, exceptionID int) error
+}
+
+// NewSQLSchedule - Initialize ScheduleStore interface backed by the provided database
+func NewSQLSchedule(db *sql.DB) ScheduleStore {
+	return &scheduleStore{db: db}
+}
+
+type scheduleStore struct {
+	db *sql.DB
+}
+
+// GetByDentistID - Return the working hours, breaks and exceptions of a dentist
+func (s *scheduleStore) GetByDentistID(ctx context.Context, dentistID int) (domain.DentistSchedule, error) {
+	if err := s.db.QueryRowContext(ctx, "SELECT id FROM dentists WHERE id = ?", dentistID).Scan(&dentistID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return domain.DentistSchedule{}, ErrNotFound
+		}
+		return domain.DentistSchedule{}, err
+	}
+	return loadSchedule(ctx, s.db, dentistID)
+}
+
+// GetByDentistCRO - Return the working hours, breaks and exceptions of a dentist through your license number
+func (s *scheduleStore) GetByDentistCRO(ctx context.Context, licenseNumber string) (domain.DentistSchedule, error) {
+	var dentistID int
+	if err := s.db.QueryRowContext(ctx, "SELECT id FROM dentists WHERE cro = ?", licenseNumber).Scan(&dentistID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return domain.DentistSchedule{}, ErrNotFound
+		}
+		return domain.DentistSchedule{}, err
+	}
+	return loadSchedule(ctx, s.db, dentistID)
+}
+
+// GetAllByDateTimeInterval - Return the schedules configured, keyed by dentist ID, with the exceptions overlapping a
+// datetime interval. Dentists without working hours, breaks or exceptions at the interval are left out.
+func (s *scheduleStore) GetAllByDateTimeInterval(ctx context.Context, startDateTime, endDateTime time.Time) (map[int]domain.DentistSchedule, error) {
+	return loadSchedules(ctx, s.db, scheduleFilter{
+		weekly:         "TRUE",
+		exceptions:     "start_date_and_time < ? AND end_date_and_time > ?",
+		exceptionsArgs: []interface{}{toSQLDateTime(endDateTime), toSQLDateTime(startDateTime)},
+	})
+}
+
+// SaveWeekly - Replace the working hours and breaks of a dentist, exceptions are kept. The dentist row is locked as
+// the appointments are while checked against the schedule, so a booking never sees half a schedule.
+func (s *scheduleStore) SaveWeekly(ctx context.Context, schedule domain.DentistSchedule) (domain.DentistSchedule, error) {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return domain.DentistSchedule{}, err
+	}
+	defer tx.Rollback()
+
+	dentistID := schedule.DentistID
+	if err := lockDentist(ctx, tx, dentistID); err != nil {
+		return domain.DentistSchedule{}, err
+	}
+	if _, err := tx.ExecContext(ctx, "DELETE FROM dentist_working_hours WHERE dentist_id = ?", dentistID); err != nil {
+		return domain.DentistSchedule{}, err
+	}
+	if _, err := tx.ExecContext(ctx, "DELETE FROM dentist_breaks WHERE dentist_id = ?", dentistID); err != nil {
+		return domain.DentistSchedule{}, err
+	}
+	for _, hours := range schedule.WorkingHours {
+		if _, err := tx.ExecContext(ctx, "INSERT INTO dentist_working_hours(dentist_id, weekday, start_time, end_time) VALUES (?,?,?,?)",
+			dentistID, int(hours.Weekday), hours.StartTime, hours.EndTime); err != nil {
+			return domain.DentistSchedule{}, err
+		}
+	}
+	for _, pause := range schedule.Breaks {
+		if _, err := tx.ExecContext(ctx, "INSERT INTO dentist_breaks(dentist_id, weekday, start_time, end_time) VALUES (?,?,?,?)",
+			dentistID, int(pause.Weekday), pause.StartTime, pause.EndTime); err != nil {
+			return domain.DentistSchedule{}, err
+		}
+	}
+	if err := tx.Commit(); err != nil {
+		return domain.DentistSchedule{}, err
+	}
+	return loadSchedule(ctx, s.db, dentistID)
+}
+
+// SaveException - Insert a new exception into a dentist schedule, holding the dentist row locked as SaveWeekly does.
+func (s *scheduleStore) SaveException(ctx context.Context, dentistID int, exception domain.ScheduleException) (domain.ScheduleException, error) {
+	start, err := domain.ParseDateTime(exception.DateAndTime)
+	if err != nil {
+		return domain.ScheduleException{}, errors.New("failed to convert datetime")
+	}
+	end, err := domain.ParseDateTime(exception.EndDateAndTime)
+	if err != nil {
+		return domain.ScheduleException{}, errors.New("failed to convert datetime")
+	}
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return domain.ScheduleException{}, err
+	}
+	defer tx.Rollback()
+
+	if err := lockDentist(ctx, tx, dentistID); err != nil {
+		return domain.ScheduleException{}, err
+	}
+	result, err := tx.ExecContext(ctx, "INSERT INTO dentist_schedule_exceptions(dentist_id, start_date_and_time, end_date_and_time, reason) VALUES (?,?,?,?)",
+		dentistID,
+		toSQLDateTime(start),
+		toSQLDateTime(end),
+		exception.Reason)
+	if err != nil {
+		return domain.ScheduleException{}, err
+	}
+	lastInsertedID, err := result.LastInsertId()
+	if err != nil {
+		return domain.ScheduleException{}, err
+	}
+	if err := tx.Commit(); err != nil {
+		return domain.ScheduleException{}, err
+	}
+	exception.Id = int(lastInsertedID)
+	return exception, nil
+}
+
+// DeleteException - exclude an exception from a dentist schedule, holding the dentist row locked as SaveWeekly does.
+func (s *scheduleStore) DeleteException(ctx context.Context, dentistID, exceptionID int) error {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if err := lockDentist(ctx, tx, dentistID); err != nil {
+		return err
+	}
+	result, err := tx.ExecContext(ctx, "DELETE FROM dentist_schedule_exceptions WHERE id = ? AND dentist_id = ?", exceptionID, dentistID)
+	if err != nil {
+		return err
+	}
+	count, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if count == 0 {
+		return ErrNotFound
+	}
+	return tx.Commit()
+}
+
+// lockDentist - lock the row of a dentist until the transaction ends, returning ErrNotFound when there's none.
+func lockDentist(ctx context.Context, tx *sql.Tx, dentistID int) error {
+	err := tx.QueryRowContext(ctx, "SELECT id FROM dentists WHERE id = ? FOR UPDATE", dentistID).Scan(&dentistID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return ErrNotFound
+	}
+	return err
+}
+
+// loadSchedule - read every working hours, break and exception of a dentist known to exist, at the database or at the
+// transaction holding the dentist row locked.
+func loadSchedule(ctx context.Context, db querier, dentistID int) (domain.DentistSchedule, error) {
+	schedules, err := loadSchedules(ctx, db, scheduleFilter{
+		weekly:         "dentist_id = ?",
+		weeklyArgs:     []interface{}{dentistID},
+		exceptions:     "dentist_id = ?",
+		exceptionsArgs: []interface{}{dentistID},
+	})
+	if err != nil {
+		return domain.DentistSchedule{}, err
+	}
+	if schedule, ok := schedules[dentistID]; ok {
+		return schedule, nil
+	}
+	return emptySchedule(dentistID), nil
+}
+
+// scheduleFilter - the conditions selecting the working hours and breaks, and the exceptions, loaded by loadSchedules.
+type scheduleFilter struct {
+	weekly         string
+	weeklyArgs     []interface{}
+	exceptions     string
+	exceptionsArgs []interface{}
+}
+
+// loadSchedules - read the working hours, breaks and exceptions selected by the filter, three queries whatever the
+// number of dentists, grouped by dentist ID.
+func loadSchedules(ctx context.Context, db querier, filter scheduleFilter) (map[int]domain.DentistSchedule, error) {
+	schedules := make(map[int]domain.DentistSchedule)
+	scheduleOf := func(dentistID int) domain.DentistSchedule {
+		if schedule, ok := schedules[dentistID]; ok {
+			return schedule
+		}
+		return emptySchedule(dentistID)
+	}
+
+	rows, err := db.QueryContext(ctx, "SELECT dentist_id, weekday, TIME_FORMAT(start_time,'%H:%i'), TIME_FORMAT(end_time,'%H:%i') FROM dentist_working_hours WHERE "+filter.weekly+" ORDER BY dentist_id, weekday, start_time", filter.weeklyArgs...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var dentistID int
+		var hours domain.WorkingHours
+		if err := rows.Scan(&dentistID, &hours.Weekday, &hours.StartTime, &hours.EndTime); err != nil {
+			return nil, err
+		}
+		schedule := scheduleOf(dentistID)
+		schedule.WorkingHours = append(schedule.WorkingHours, hours)
+		schedules[dentistID] = schedule
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	breakRows, err := db.QueryContext(ctx, "SELECT dentist_id, weekday, TIME_FORMAT(start_time,'%H:%i'), TIME_FORMAT(end_time,'%H:%i') FROM dentist_breaks WHERE "+filter.weekly+" ORDER BY dentist_id, weekday, start_time", filter.weeklyArgs...)
+	if err != nil {
+		return nil, err
+	}
+	defer breakRows.Close()
+	for breakRows.Next() {
+		var dentistID int
+		var pause domain.ScheduleBreak
+		if err := breakRows.Scan(&dentistID, &pause.Weekday, &pause.StartTime, &pause.EndTime); err != nil {
+			return nil, err
+		}
+		schedule := scheduleOf(dentistID)
+		schedule.Breaks = append(schedule.Breaks, pause)
+		schedules[dentistID] = schedule
+	}
+	if err := breakRows.Err(); err != nil {
+		return nil, err
+	}
+
+	exceptionRows, err := db.QueryContext(ctx, "SELECT dentist_id, id, DATE_FORMAT(start_date_and_time,'%d/%m/%Y %H:%i'), DATE_FORMAT(end_date_and_time,'%d/%m/%Y %H:%i'), reason FROM dentist_schedule_exceptions WHERE "+filter.exceptions+" ORDER BY dentist_id, start_date_and_time", filter.exceptionsArgs...)
+	if err != nil {
+		return nil, err
+	}
+	defer exceptionRows.Close()
+	for exceptionRows.Next() {
+		var dentistID int
+		var exception domain.ScheduleException
+		if err := exceptionRows.Scan(&dentistID, &exception.Id, &exception.DateAndTime, &exception.EndDateAndTime, &exception.Reason); err != nil {
+			return nil, err
+		}
+		schedule := scheduleOf(dentistID)
+		schedule.Exceptions = append(schedule.Exceptions, exception)
+		schedules[dentistID] = schedule
+	}
+	return schedules, exceptionRows.Err()
+}
+
+// emptySchedule - the schedule of a dentist without working hours, breaks or exceptions.
+func emptySchedule(dentistID int) domain.DentistSchedule {
+	return domain.DentistSchedule{
+		DentistID:    dentistID,
+		WorkingHours: []domain.WorkingHours{},
+		Breaks:       []domain.ScheduleBreak{},
+		Exceptions:   []domain.ScheduleException{},
+	}
+}
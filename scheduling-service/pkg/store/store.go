@@ -1,9 +1,24 @@
 package store
 
-type Store interface {
-	GetAll(tableName string) (interface{}, error)
-	GetByID(entityID int, tableName string) (interface{}, error)
-	Save(entity interface{}, tableName string) (interface{}, error)
-	Update(entityID int, entity interface{}, tableName string) (interface{}, error)
-	Delete(entityID int, tableName string) error
+import (
+	"context"
+	"errors"
+	"github.com/ronilsonalves/GoLang-in-a-spring-cloud-architecture/scheduling-service/internal/domain"
+)
+
+// ErrNotFound - returned by any store when there's no row matching the provided ID.
+var ErrNotFound = errors.New("entity not found at database")
+
+// Store - Set the typed contract shared by every entity store.
+type Store[T any] interface {
+	GetAll(ctx context.Context) ([]T, error)
+	GetByID(ctx context.Context, entityID int) (T, error)
+	Save(ctx context.Context, entity T) (T, error)
+	Update(ctx context.Context, entityID int, entity T) (T, error)
+	Delete(ctx context.Context, entityID int) error
+}
+
+// Lister - Set the contract for stores whose lists are paged, sorted and filtered by F.
+type Lister[T, F any] interface {
+	List(ctx context.Context, q domain.ListQuery[F]) (domain.Page[T], error)
 }
@@ -0,0 +1,179 @@
+package tracing
+
+import (
+	"context"
+	"database/sql/driver"
+	semconv "go.opentelemetry.io/otel/semconv/v1.12.0"
+	"go.opentelemetry.io/otel/trace"
+	"strings"
+)
+
+// WrapConnector - a connector whose statements each run within a client span named after their operation, e.g.
+// SELECT, so every query of the stores shows up at the trace of the request. The statements with arguments are always
+// prepared, so each one gets a single span, the ones without run directly as the migrations' DDL needs.
+func WrapConnector(connector driver.Connector, system string) driver.Connector {
+	return &tracedConnector{Connector: connector, system: system}
+}
+
+type tracedConnector struct {
+	driver.Connector
+	system string
+}
+
+// Connect - open a connection of the wrapped connector.
+func (c *tracedConnector) Connect(ctx context.Context) (driver.Conn, error) {
+	conn, err := c.Connector.Connect(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &tracedConn{Conn: conn, system: c.system}, nil
+}
+
+type tracedConn struct {
+	driver.Conn
+	system string
+}
+
+// PrepareContext - prepare a statement whose runs are traced.
+func (c *tracedConn) PrepareContext(ctx context.Context, query string) (driver.Stmt, error) {
+	var stmt driver.Stmt
+	var err error
+	if preparer, ok := c.Conn.(driver.ConnPrepareContext); ok {
+		stmt, err = preparer.PrepareContext(ctx, query)
+	} else {
+		stmt, err = c.Conn.Prepare(query)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &tracedStmt{Stmt: stmt, query: query, system: c.system}, nil
+}
+
+// ExecContext - run a statement without arguments within a span, the ones with arguments are prepared.
+func (c *tracedConn) ExecContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Result, error) {
+	execer, ok := c.Conn.(driver.ExecerContext)
+	if !ok || len(args) > 0 {
+		return nil, driver.ErrSkip
+	}
+	ctx, span := start(ctx, c.system, query)
+	result, err := execer.ExecContext(ctx, query, args)
+	End(span, err)
+	return result, err
+}
+
+// QueryContext - run a query without arguments within a span, the ones with arguments are prepared.
+func (c *tracedConn) QueryContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Rows, error) {
+	queryer, ok := c.Conn.(driver.QueryerContext)
+	if !ok || len(args) > 0 {
+		return nil, driver.ErrSkip
+	}
+	ctx, span := start(ctx, c.system, query)
+	rows, err := queryer.QueryContext(ctx, query, args)
+	End(span, err)
+	return rows, err
+}
+
+// BeginTx - start a transaction of the wrapped connection, its statements are prepared through PrepareContext.
+func (c *tracedConn) BeginTx(ctx context.Context, opts driver.TxOptions) (driver.Tx, error) {
+	if beginner, ok := c.Conn.(driver.ConnBeginTx); ok {
+		return beginner.BeginTx(ctx, opts)
+	}
+	return c.Conn.Begin()
+}
+
+// Ping - check the wrapped connection is still alive.
+func (c *tracedConn) Ping(ctx context.Context) error {
+	if pinger, ok := c.Conn.(driver.Pinger); ok {
+		return pinger.Ping(ctx)
+	}
+	return nil
+}
+
+// ResetSession - reset the wrapped connection before it's reused.
+func (c *tracedConn) ResetSession(ctx context.Context) error {
+	if resetter, ok := c.Conn.(driver.SessionResetter); ok {
+		return resetter.ResetSession(ctx)
+	}
+	return nil
+}
+
+// IsValid - whether the wrapped connection can be reused.
+func (c *tracedConn) IsValid() bool {
+	if validator, ok := c.Conn.(driver.Validator); ok {
+		return validator.IsValid()
+	}
+	return true
+}
+
+// CheckNamedValue - convert the arguments as the wrapped connection does.
+func (c *tracedConn) CheckNamedValue(value *driver.NamedValue) error {
+	if checker, ok := c.Conn.(driver.NamedValueChecker); ok {
+		return checker.CheckNamedValue(value)
+	}
+	return driver.ErrSkip
+}
+
+type tracedStmt struct {
+	driver.Stmt
+	query  string
+	system string
+}
+
+// ExecContext - run the statement within a span.
+func (s *tracedStmt) ExecContext(ctx context.Context, args []driver.NamedValue) (driver.Result, error) {
+	ctx, span := start(ctx, s.system, s.query)
+	var result driver.Result
+	var err error
+	if execer, ok := s.Stmt.(driver.StmtExecContext); ok {
+		result, err = execer.ExecContext(ctx, args)
+	} else {
+		result, err = s.Stmt.Exec(values(args))
+	}
+	End(span, err)
+	return result, err
+}
+
+// QueryContext - run the query within a span, which ends once the rows are returned.
+func (s *tracedStmt) QueryContext(ctx context.Context, args []driver.NamedValue) (driver.Rows, error) {
+	ctx, span := start(ctx, s.system, s.query)
+	var rows driver.Rows
+	var err error
+	if queryer, ok := s.Stmt.(driver.StmtQueryContext); ok {
+		rows, err = queryer.QueryContext(ctx, args)
+	} else {
+		rows, err = s.Stmt.Query(values(args))
+	}
+	End(span, err)
+	return rows, err
+}
+
+// CheckNamedValue - convert the arguments as the wrapped statement does.
+func (s *tracedStmt) CheckNamedValue(value *driver.NamedValue) error {
+	if checker, ok := s.Stmt.(driver.NamedValueChecker); ok {
+		return checker.CheckNamedValue(value)
+	}
+	return driver.ErrSkip
+}
+
+// start - start the span of a statement, its arguments are left out as they may be personal data.
+func start(ctx context.Context, system, query string) (context.Context, trace.Span) {
+	operation := system
+	if fields := strings.Fields(query); len(fields) > 0 {
+		operation = strings.ToUpper(fields[0])
+	}
+	return Tracer().Start(ctx, operation,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			semconv.DBSystemKey.String(system),
+			semconv.DBStatementKey.String(query),
+			semconv.DBOperationKey.String(operation),
+		))
+}
+
+func values(args []driver.NamedValue) []driver.Value {
+	converted := make([]driver.Value, len(args))
+	for i, arg := range args {
+		converted[i] = arg.Value
+	}
+	return converted
+}
@@ -0,0 +1,75 @@
+package tracing
+
+import (
+	"context"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.12.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// instrumentationName - the tracer of the spans started by the service.
+const instrumentationName = "github.com/ronilsonalves/GoLang-in-a-spring-cloud-architecture/scheduling-service"
+
+// traceParentHeader - the W3C header carrying the trace and the parent span.
+const traceParentHeader = "traceparent"
+
+// Setup - export the spans sampled at the ratio provided through the exporter, along with the service name, and
+// propagate the W3C trace context and baggage. The spans aren't recorded when the exporter is nil, the trace context
+// of the requests is still passed on to the messages published. The shutdown returned exports the spans left.
+func Setup(serviceName string, exporter sdktrace.SpanExporter, sampleRatio float64) func(ctx context.Context) error {
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{}))
+	if exporter == nil {
+		return func(ctx context.Context) error { return nil }
+	}
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(sampleRatio))),
+		sdktrace.WithResource(resource.NewWithAttributes(semconv.SchemaURL, semconv.ServiceNameKey.String(serviceName))),
+	)
+	otel.SetTracerProvider(provider)
+	return provider.Shutdown
+}
+
+// Tracer - the tracer of the service spans, the one of the provider set up once Setup runs.
+func Tracer() trace.Tracer {
+	return otel.Tracer(instrumentationName)
+}
+
+// End - end a span, recording the error provided when there's one.
+func End(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
+
+// Inject - write the trace context of ctx into the headers of a request or message.
+func Inject(ctx context.Context, carrier propagation.TextMapCarrier) {
+	otel.GetTextMapPropagator().Inject(ctx, carrier)
+}
+
+// Extract - continue the trace context read from the headers of a request or message.
+func Extract(ctx context.Context, carrier propagation.TextMapCarrier) context.Context {
+	return otel.GetTextMapPropagator().Extract(ctx, carrier)
+}
+
+// TraceParent - the W3C traceparent of the span of ctx, empty when there's none, kept along with the outbox messages
+// so they're published in the trace of the request that recorded them.
+func TraceParent(ctx context.Context) string {
+	carrier := propagation.MapCarrier{}
+	propagation.TraceContext{}.Inject(ctx, carrier)
+	return carrier[traceParentHeader]
+}
+
+// ContextWithTraceParent - continue the trace of the W3C traceparent provided, ctx itself when it's empty.
+func ContextWithTraceParent(ctx context.Context, traceParent string) context.Context {
+	if traceParent == "" {
+		return ctx
+	}
+	return propagation.TraceContext{}.Extract(ctx, propagation.MapCarrier{traceParentHeader: traceParent})
+}
@@ -1,6 +1,7 @@
 package web
 
 import (
+	"errors"
 	"github.com/gin-gonic/gin"
 	"time"
 )
@@ -16,7 +17,9 @@ type response struct {
 	Data interface{}
 }
 
+// BadResponse - answer an error, the message is kept at the request errors so the access log tells why it failed.
 func BadResponse(ctx *gin.Context, statusCode int, status, message string) {
+	ctx.Error(errors.New(message)).SetType(gin.ErrorTypePublic)
 	ctx.AbortWithStatusJSON(statusCode, errorResponse{
 		StatusCode: statusCode,
 		Status:     status,